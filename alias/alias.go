@@ -0,0 +1,133 @@
+package alias
+
+import (
+	_ "embed"
+	"encoding/json"
+	"sort"
+)
+
+// Categories maps the names of categories unicode has renamed to the names that replaced them.
+// Names are the slugs the categories are stored by.
+var Categories = map[string]string{}
+
+// Splits maps the categories unicode has divided between several categories to where their subcategories went.
+// Emoji 12.0 divided "Smileys & People" into "Smileys & Emotion" and "People & Body", moving the components
+// and clothing out to the "Component" and "Objects" categories.
+var Splits = map[string]*Split{}
+
+// Subcategories maps the names of subcategories unicode has renamed to the names that replaced them.
+// Subcategories whose emoji were spread over several subcategories, such as "face-positive", are kept as they are.
+var Subcategories = map[string]string{}
+
+// mapping is the aliases.json file the Categories, Splits and Subcategories are read from. Renames unicode makes
+// to its groups are added to the file, so that reading and diffing datasets and repairing them follow the new names.
+//
+//go:embed aliases.json
+var mapping []byte
+
+func init() {
+	aliases := struct {
+		Categories    *map[string]string `json:"categories"`
+		Splits        *map[string]*Split `json:"splits"`
+		Subcategories *map[string]string `json:"subcategories"`
+	}{&Categories, &Splits, &Subcategories}
+	if err := json.Unmarshal(mapping, &aliases); err != nil {
+		panic(err)
+	}
+}
+
+// Category returns the current name of a category, given the name of the subcategory it is referenced with
+// to place emoji of a category that has been split. Names that have not changed are returned as they are.
+func Category(name, subcategory string) string {
+	if split, ok := Splits[name]; ok && len(subcategory) != 0 {
+		if category, ok := split.Subcategories[subcategory]; ok {
+			return category
+		}
+		for former, current := range Subcategories {
+			if current == subcategory {
+				if category, ok := split.Subcategories[former]; ok {
+					return category
+				}
+			}
+		}
+		return split.Default
+	}
+	return follow(Categories, name)
+}
+
+// CategoryNames returns the name of a category followed by the names it has been renamed from or to,
+// then the categories it was split from, to find a category stored under another of its names.
+func CategoryNames(name string) []string {
+	var (
+		current = follow(Categories, name)
+		splits  = []string{}
+	)
+	for former, split := range Splits {
+		if former != name && split.Has(current) {
+			splits = append(splits, former)
+		}
+	}
+	sort.Strings(splits)
+	return append(namesOf(Categories, name), splits...)
+}
+
+// Subcategory returns the current name of a subcategory. Names that have not changed are returned as they are.
+func Subcategory(name string) string {
+	return follow(Subcategories, name)
+}
+
+// SubcategoryNames returns the name of a subcategory followed by the names it has been renamed from or to,
+// to find a subcategory stored under another of its names.
+func SubcategoryNames(name string) []string {
+	return namesOf(Subcategories, name)
+}
+
+// follow returns the name the aliases lead to from the name, stopping at a name seen before.
+func follow(aliases map[string]string, name string) string {
+	seen := map[string]bool{name: true}
+	for {
+		next, ok := aliases[name]
+		if ok == false || seen[next] {
+			return name
+		}
+		name, seen[next] = next, true
+	}
+}
+
+// namesOf returns the name, its current name and every name that leads to the same current name.
+func namesOf(aliases map[string]string, name string) []string {
+	var (
+		current = follow(aliases, name)
+		names   = []string{name}
+		others  = []string{}
+	)
+	if current != name {
+		names = append(names, current)
+	}
+	for former := range aliases {
+		if former != name && former != current && follow(aliases, former) == current {
+			others = append(others, former)
+		}
+	}
+	sort.Strings(others)
+	return append(names, others...)
+}
+
+// Split is where the subcategories of a divided category went, with every subcategory not listed going to the Default.
+type Split struct {
+	Default       string            `json:"default"`
+	Subcategories map[string]string `json:"subcategories"`
+}
+
+// Has method reports whether any subcategory of the divided category went to the category.
+func (pointer *Split) Has(category string) bool {
+	if pointer.Default == category {
+		return true
+	}
+	for _, current := range pointer.Subcategories {
+		if current == category {
+			return true
+		}
+	}
+	return false
+}
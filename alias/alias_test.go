@@ -0,0 +1,25 @@
+package alias
+
+import "testing"
+
+// TestCategory checks that the aliases.json mapping is read and places the subcategories of a divided category,
+// including those that have since been renamed.
+func TestCategory(t *testing.T) {
+	for _, c := range []struct {
+		name, subcategory, want string
+	}{
+		{"smileys-and-people", "face-positive", "smileys-and-emotion"},
+		{"smileys-and-people", "face-unwell", "smileys-and-emotion"},
+		{"smileys-and-people", "skin-tone", "component"},
+		{"smileys-and-people", "person-gesture", "people-and-body"},
+		{"smileys-and-people", "", "smileys-and-people"},
+		{"animals-and-nature", "cat-face", "animals-and-nature"},
+	} {
+		if got := Category(c.name, c.subcategory); got != c.want {
+			t.Errorf("Category(%q, %q) = %q, want %q", c.name, c.subcategory, got, c.want)
+		}
+	}
+	if got := Subcategory("face-sick"); got != "face-unwell" {
+		t.Errorf("Subcategory(%q) = %q, want %q", "face-sick", got, "face-unwell")
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+)
+
+func altMain(arguments *arguments.Arguments) {
+	var (
+		emojipedia = emojipedia.Get()
+		locale     = arguments.Get(0)
+		scanner    = bufio.NewScanner(os.Stdin)
+	)
+	for scanner.Scan() {
+		fmt.Println(emojipedia.Replace(scanner.Text(), func(_ string, e *emoji.Emoji) string {
+			return emoji.AltText(e, locale)
+		}))
+	}
+	if err := scanner.Err(); err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "stdin", err), err)
+	}
+}
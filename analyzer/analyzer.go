@@ -0,0 +1,152 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/gellel/emojipedia/text"
+)
+
+var (
+	analyzers = map[string]*Analyzer{}
+)
+
+var (
+	// Default analyzes keywords when they are indexed and search terms when they are looked up.
+	Default = Register(New("en", English, "a", "an", "and", "as", "at", "by", "for", "from", "in", "into", "is", "it", "of", "on", "or", "the", "to", "with"))
+)
+
+// New instantiates a new Analyzer for the locale, stemming with the argument function (which may be nil) and dropping the stop words.
+func New(locale string, stem func(word string) string, stopwords ...string) *Analyzer {
+	analyzer := &Analyzer{Locale: locale, Stem: stem, Stopwords: map[string]bool{}}
+	for _, word := range stopwords {
+		analyzer.Stopwords[word] = true
+	}
+	return analyzer
+}
+
+// For returns the Analyzer registered for the locale or its base language ("en-GB" falls back to "en").
+// Locales without one are only lowercased and slugged.
+func For(locale string) *Analyzer {
+	locale = strings.ToLower(strings.Replace(locale, "_", "-", -1))
+	for _, key := range []string{locale, strings.Split(locale, "-")[0]} {
+		if analyzer, ok := analyzers[key]; ok {
+			return analyzer
+		}
+	}
+	return New(locale, nil)
+}
+
+// Register makes the Analyzer available to For by its locale and returns it.
+func Register(analyzer *Analyzer) *Analyzer {
+	analyzers[strings.ToLower(analyzer.Locale)] = analyzer
+	return analyzer
+}
+
+// Use sets the Default Analyzer to that of the locale.
+func Use(locale string) {
+	Default = For(locale)
+}
+
+// English is a light stemmer for English words: plurals and the -ed and -ing endings are removed,
+// restoring the silent e of short stems and undoubling final consonants, so "smiles", "smiling" and "smiled" stem to "smile".
+func English(word string) string {
+	switch {
+	case len(word) <= 3:
+		return word
+	case strings.HasSuffix(word, "sses"):
+		word = strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ies"):
+		word = strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+	case strings.HasSuffix(word, "s"):
+		word = strings.TrimSuffix(word, "s")
+	}
+	for _, suffix := range []string{"ing", "ed"} {
+		stem := strings.TrimSuffix(word, suffix)
+		if stem == word || len(stem) < 3 || vowel(stem) == false {
+			continue
+		}
+		last := len(stem) - 1
+		switch {
+		case stem[last] == stem[last-1] && strings.IndexByte("lsz", stem[last]) == -1 && consonant(stem, last):
+			stem = stem[:last]
+		case strings.HasSuffix(stem, "at"), strings.HasSuffix(stem, "bl"), strings.HasSuffix(stem, "iz"):
+			stem = stem + "e"
+		case measure(stem) == 1 && cvc(stem):
+			stem = stem + "e"
+		}
+		return stem
+	}
+	return word
+}
+
+// Analyzer turns keywords and search terms into the terms they are indexed and matched by.
+type Analyzer struct {
+	Locale    string
+	Stem      func(word string) string
+	Stopwords map[string]bool
+}
+
+// Key method returns the key a keyword or search term is indexed by: its Terms joined by hyphens.
+// Keywords made only of stop words return an empty string and are not indexed.
+func (pointer *Analyzer) Key(s string) string {
+	return strings.Join(pointer.Terms(s), "-")
+}
+
+// Terms method slugs the argument string and returns its words, without stop words and stemmed.
+func (pointer *Analyzer) Terms(s string) []string {
+	terms := []string{}
+	for _, word := range strings.Split(text.Normalize(s), "-") {
+		if len(word) == 0 || pointer.Stopwords[word] {
+			continue
+		}
+		if pointer.Stem != nil {
+			word = pointer.Stem(word)
+		}
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+// consonant checks whether the byte at i of an English word is a consonant. A y following a consonant is a vowel.
+func consonant(word string, i int) bool {
+	switch word[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || consonant(word, i-1) == false
+	}
+	return true
+}
+
+// cvc checks whether an English word ends consonant, vowel, consonant where the final consonant is not w, x or y.
+func cvc(word string) bool {
+	n := len(word)
+	return n >= 3 && consonant(word, n-3) && consonant(word, n-2) == false && consonant(word, n-1) && strings.IndexByte("wxy", word[n-1]) == -1
+}
+
+// measure counts the vowel and consonant sequences of an English word, as in the Porter stemmer.
+func measure(word string) int {
+	var (
+		m     = 0
+		after = false
+	)
+	for i := range word {
+		c := consonant(word, i)
+		if c && after {
+			m++
+		}
+		after = c == false
+	}
+	return m
+}
+
+// vowel checks whether an English word contains a vowel.
+func vowel(word string) bool {
+	for i := range word {
+		if consonant(word, i) == false {
+			return true
+		}
+	}
+	return false
+}
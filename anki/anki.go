@@ -0,0 +1,40 @@
+package anki
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/text"
+)
+
+// Write writes a note for each emoji.Emoji as the tab separated text Anki imports from File > Import.
+// The front of each note is the emoji character and the back its name, keywords and description.
+// Notes are tagged with the category and subcategory of the emoji, so decks can be studied a category at a time.
+func Write(writer io.Writer, emoji []*emoji.Emoji) error {
+	_, err := fmt.Fprintln(writer, "#separator:tab\n#html:true\n#columns:Front\tBack\tTags")
+	if err != nil {
+		return err
+	}
+	for _, e := range emoji {
+		back := []string{fmt.Sprintf("<b>%s</b>", html.EscapeString(e.Name))}
+		if e.Keywords != nil && e.Keywords.Len() != 0 {
+			back = append(back, html.EscapeString(e.Keywords.Join(", ")))
+		}
+		if len(e.Description) != 0 && e.Description != "NIL" {
+			back = append(back, html.EscapeString(e.Description))
+		}
+		_, err := fmt.Fprintf(writer, "%s\t%s\t%s\n", text.Emojize(e.Unicode), field(strings.Join(back, "<br>")), field(strings.TrimSpace(e.Category+" "+e.Subcategory)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// field removes the tabs and line breaks that would split a note into several fields or notes.
+func field(s string) string {
+	return strings.NewReplacer("\t", " ", "\r", " ", "\n", "<br>").Replace(s)
+}
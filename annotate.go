@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+)
+
+const (
+	annotation string = "{{.Character}} [{{.Name}}]"
+)
+
+func annotateMain(arguments *arguments.Arguments) {
+	var (
+		emojipedia = emojipedia.Get()
+		format     = arguments.Get(0)
+		scanner    = bufio.NewScanner(os.Stdin)
+	)
+	if len(format) == 0 {
+		format = annotation
+	}
+	t, err := template.New(ANNOTATE).Parse(format)
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "template", err), err)
+	}
+	for scanner.Scan() {
+		fmt.Println(emojipedia.Replace(scanner.Text(), func(character string, e *emoji.Emoji) string {
+			b := &strings.Builder{}
+			err := t.Execute(b, struct {
+				*emoji.Emoji
+				Alt       string
+				Character string
+			}{e, emoji.AltText(e, ""), character})
+			if err != nil {
+				fail(fmt.Sprintf(errorCannotOpen, "template", err), err)
+			}
+			return b.String()
+		}))
+	}
+	if err := scanner.Err(); err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "stdin", err), err)
+	}
+}
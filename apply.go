@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/categories"
+	"github.com/gellel/emojipedia/category"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/jsonpatch"
+	"github.com/gellel/emojipedia/keyword"
+	"github.com/gellel/emojipedia/keywords"
+	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/subcategories"
+	"github.com/gellel/emojipedia/subcategory"
+	"github.com/gellel/emojipedia/weights"
+)
+
+func applyMain(arguments *arguments.Arguments) {
+	var (
+		content []byte
+		err     error
+		file    = arguments.Get(0)
+	)
+	switch file {
+	case "", "-":
+		file = "stdin"
+		content, err = ioutil.ReadAll(os.Stdin)
+	default:
+		content, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		failWith(usage, fmt.Sprintf(errorCannotOpen, file, err), err)
+	}
+	update := &jsonpatch.Update{}
+	if err = store.Decode(file, content, update); err != nil {
+		failWith(usage, fmt.Sprintf(errorCannotOpen, file, err), err)
+	}
+	// Every patch is applied before anything is written, so a patch that does not apply leaves the dataset as it was.
+	// The patched emoji are written before any is removed, so a write that fails removes nothing.
+	var (
+		patched  = []*emoji.Emoji{}
+		previous = []*emoji.Emoji{}
+		removed  = []string{}
+	)
+	for _, f := range update.Files {
+		current, err := emoji.Read(f.Key)
+		switch {
+		case os.IsNotExist(err) && f.Removed == false:
+			current = &[]byte{}
+		case err != nil:
+			fail(fmt.Sprintf(errorApply, f.Path, err), err)
+		default:
+			e, err := emoji.Parse(current)
+			if err != nil {
+				fail(fmt.Sprintf(errorApply, f.Path, err), err)
+			}
+			previous = append(previous, e)
+		}
+		if f.Removed {
+			removed = append(removed, f.Key)
+			continue
+		}
+		next, err := f.Patch.Apply(*current)
+		if err != nil {
+			fail(fmt.Sprintf(errorApply, f.Path, err), err)
+		}
+		e, err := emoji.Parse(&next)
+		if err != nil {
+			fail(fmt.Sprintf(errorApply, f.Path, err), err)
+		}
+		patched = append(patched, e)
+	}
+	for _, e := range patched {
+		if err := emoji.Write(e); err != nil {
+			fail(fmt.Sprintf(errorCannotWrite, e.Name, err), err)
+		}
+	}
+	if err := relink(previous, patched); err != nil {
+		fail(fmt.Sprintf(errorApply, file, err), err)
+	}
+	for _, key := range removed {
+		if err := emoji.Remove(key); err != nil {
+			fail(fmt.Sprintf(errorRemovePackage, key, err), err)
+		}
+	}
+	fmt.Println(fmt.Sprintf(successApply, len(patched), len(removed), file))
+}
+
+// relink replaces the unlinked emoji with the linked emoji in the stored categories, subcategories and keywords,
+// and in the keyword terms counted by the stored weights. An emoji linked under the name it was unlinked by keeps
+// its place in the packages it still belongs to. Packages that have not been built are skipped.
+func relink(unlinked, linked []*emoji.Emoji) error {
+	names := map[string]bool{}
+	for _, e := range unlinked {
+		names[e.Name] = true
+	}
+	// link returns the names of the slice without the unlinked emoji, followed by the linked emoji that belong
+	// to the slice, and whether any name changed.
+	link := func(s *slice.Slice, belongs func(e *emoji.Emoji) bool) (*slice.Slice, bool) {
+		var (
+			changed = false
+			kept    = map[string]bool{}
+			next    = &slice.Slice{}
+		)
+		s.Each(func(_ int, value interface{}) {
+			name := value.(string)
+			if names[name] && placed(name, linked, belongs) == false {
+				changed = true
+				return
+			}
+			kept[name] = true
+			next.Append(name)
+		})
+		for _, e := range linked {
+			if belongs(e) && kept[e.Name] == false {
+				kept[e.Name], changed = true, true
+				next.Append(e.Name)
+			}
+		}
+		return next, changed
+	}
+	if c, err := categories.Open(); err == nil {
+		var failed error
+		c.Each(func(x *category.Category) {
+			if next, changed := link(x.Emoji, func(e *emoji.Emoji) bool { return e.Category == x.Name }); changed && failed == nil {
+				failed = category.Write(x.SetEmoji(next))
+			}
+		})
+		if failed != nil {
+			return failed
+		}
+	}
+	if s, err := subcategories.Open(); err == nil {
+		var failed error
+		s.Each(func(x *subcategory.Subcategory) {
+			if next, changed := link(x.Emoji, func(e *emoji.Emoji) bool { return e.Subcategory == x.Name }); changed && failed == nil {
+				failed = subcategory.Write(x.SetEmoji(next))
+			}
+		})
+		if failed != nil {
+			return failed
+		}
+	}
+	if k, err := keywords.Open(); err == nil {
+		for _, e := range linked {
+			if e.Keywords == nil {
+				continue
+			}
+			e.Keywords.Each(func(_ int, i interface{}) {
+				if k.Has(i.(string)) == false {
+					k.Assign(i.(string), &slice.Slice{})
+				}
+			})
+		}
+		var failed error
+		k.Each(func(key string, s *slice.Slice) {
+			next, changed := link(s, func(e *emoji.Emoji) bool { return hasKeyword(e, key) })
+			switch {
+			case changed == false || failed != nil:
+			case next.Len() == 0:
+				// Keywords left without emoji are removed, as a partial keywords build does.
+				failed = keyword.Remove(key)
+			default:
+				failed = keyword.Write(key, next)
+			}
+		})
+		if failed != nil {
+			return failed
+		}
+	}
+	if w, err := weights.Open(); err == nil {
+		for _, e := range unlinked {
+			w.Subtract(keywordTerms(e)...)
+		}
+		for _, e := range linked {
+			w.Add(keywordTerms(e)...)
+		}
+		return weights.Write(w)
+	}
+	return nil
+}
+
+// placed checks whether an emoji linked under the name belongs to the package the function checks.
+func placed(name string, linked []*emoji.Emoji, belongs func(e *emoji.Emoji) bool) bool {
+	for _, e := range linked {
+		if e.Name == name && belongs(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasKeyword checks whether the Emoji is tagged with the keyword.
+func hasKeyword(e *emoji.Emoji, key string) bool {
+	found := false
+	if e.Keywords != nil {
+		e.Keywords.Each(func(_ int, i interface{}) {
+			found = found || i.(string) == key
+		})
+	}
+	return found
+}
+
+// keywordTerms returns the terms of every keyword of the Emoji, as the keywords build counts them into the weights.Weights.
+func keywordTerms(e *emoji.Emoji) []string {
+	terms := []string{}
+	if e.Keywords != nil {
+		e.Keywords.Each(func(_ int, i interface{}) {
+			terms = append(terms, strings.Split(i.(string), "-")...)
+		})
+	}
+	return terms
+}
@@ -2,6 +2,7 @@ package arguments
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/gellel/emojipedia/slice"
 )
@@ -34,6 +35,14 @@ func (pointer *Arguments) Get(i int) (argument string) {
 	return argument
 }
 
+// Append method adds the arguments after the last argument, such as names read from stdin.
+func (pointer *Arguments) Append(args ...string) *Arguments {
+	for _, arg := range args {
+		pointer.slice.Append(arg)
+	}
+	return pointer
+}
+
 // Each method executes a provided function once for each argument.
 func (pointer *Arguments) Each(f func(i int, argument string)) *Arguments {
 	pointer.slice.Each(func(i int, x interface{}) {
@@ -42,6 +51,39 @@ func (pointer *Arguments) Each(f func(i int, argument string)) *Arguments {
 	return pointer
 }
 
+// Flag removes every "--name" or "--name=value" argument and returns the last value found
+// and a boolean indicating if the flag was present. Valueless flags return an empty string.
+func (pointer *Arguments) Flag(name string) (string, bool) {
+	values := pointer.Flags(name)
+	if len(values) == 0 {
+		return "", false
+	}
+	return values[len(values)-1], true
+}
+
+// Flags removes every "--name" or "--name=value" argument and returns their values in order,
+// for flags that may be given more than once. Valueless flags contribute an empty string.
+func (pointer *Arguments) Flags(name string) []string {
+	var (
+		prefix = "--" + name
+		s      = slice.New()
+		values = []string{}
+	)
+	pointer.slice.Each(func(_ int, x interface{}) {
+		argument := x.(string)
+		switch {
+		case argument == prefix:
+			values = append(values, "")
+		case strings.HasPrefix(argument, prefix+"="):
+			values = append(values, strings.TrimPrefix(argument, prefix+"="))
+		default:
+			s.Append(argument)
+		}
+	})
+	pointer.slice = s
+	return values
+}
+
 // Next unshifts the first element of the Arguments struct and returns the modified struct.
 func (pointer *Arguments) Next() *Arguments {
 	if pointer.slice.Len() != 0 {
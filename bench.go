@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/gellel/emojipedia/analyzer"
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/keyword"
+	"github.com/gellel/emojipedia/keywords"
+	"github.com/gellel/emojipedia/pkg"
+	"github.com/gellel/emojipedia/text"
+)
+
+// benchmark is an operation timed by the bench command. The same operations are measured by the Benchmark
+// functions of the pkg, emojipedia and keyword packages, which "go test -bench ." runs against the local dataset.
+type benchmark struct {
+	f    func()
+	name string
+	skip error
+}
+
+// measurement is how long, and how much memory, one run of a benchmark took on average.
+type measurement struct {
+	allocs int64
+	bytes  int64
+	n      int
+	ns     int64
+}
+
+// measure runs the operation for at least a second, doubling its runs until then, and averages the runs.
+func measure(f func()) measurement {
+	var (
+		before, after runtime.MemStats
+		elapsed       time.Duration
+		n             = 1
+	)
+	for {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		for i := 0; i < n; i++ {
+			f()
+		}
+		elapsed = time.Since(start)
+		runtime.ReadMemStats(&after)
+		if elapsed >= time.Second || n >= 1e9 {
+			break
+		}
+		n *= 2
+	}
+	return measurement{
+		allocs: int64(after.Mallocs-before.Mallocs) / int64(n),
+		bytes:  int64(after.TotalAlloc-before.TotalAlloc) / int64(n),
+		n:      n,
+		ns:     elapsed.Nanoseconds() / int64(n)}
+}
+
+func benchMain(arguments *arguments.Arguments) {
+	var (
+		e, errEmojipedia = emojipedia.Open()
+		k, errKeywords   = keywords.Open()
+		_, errUnicode    = pkg.Open()
+		key              = analyzer.Default.Key(arguments.Get(0))
+		sample           = &strings.Builder{}
+	)
+	if errEmojipedia == nil {
+		e.Each(func(_ string, emoji *emoji.Emoji) {
+			fmt.Fprintf(sample, "some text %s ", text.Emojize(emoji.Unicode))
+		})
+	}
+	if errKeywords == nil && len(key) == 0 && k.Len() != 0 {
+		key = k.Keys().Fetch(0).(string)
+	}
+	benchmarks := []benchmark{
+		benchmark{name: "parse document", skip: errUnicode, f: func() {
+			pkg.Open()
+		}},
+		benchmark{name: "open emojipedia", skip: errEmojipedia, f: func() {
+			emojipedia.Open()
+		}},
+		benchmark{name: fmt.Sprintf("keyword search (%s)", key), skip: errKeywords, f: func() {
+			keyword.Open(key)
+		}},
+		benchmark{name: "extract from text", skip: errEmojipedia, f: func() {
+			e.Replace(sample.String(), func(character string, _ *emoji.Emoji) string {
+				return character
+			})
+		}}}
+	fmt.Fprintln(writer, "Operation\t|Iterations\t|ns/op\t|B/op\t|allocs/op")
+	for _, bench := range benchmarks {
+		if bench.skip != nil {
+			fmt.Fprintln(writer, fmt.Sprintf("%s\t|skipped: %s\t|\t|\t|", bench.name, bench.skip))
+			continue
+		}
+		result := measure(bench.f)
+		fmt.Fprintln(writer, fmt.Sprintf("%s\t|%v\t|%v\t|%v\t|%v", bench.name, result.n, result.ns, result.bytes, result.allocs))
+	}
+	writer.Flush()
+}
@@ -1,26 +1,332 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
-	"os"
+	"io"
+	"strings"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/categories"
+	"github.com/gellel/emojipedia/checkpoint"
 	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/index"
+	"github.com/gellel/emojipedia/integrity"
+	"github.com/gellel/emojipedia/keywords"
+	"github.com/gellel/emojipedia/manifest"
 	"github.com/gellel/emojipedia/pkg"
+	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/stdin"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/subcategories"
+	"github.com/gellel/emojipedia/webhook"
+)
+
+var (
+	previous *emojipedia.Emojipedia
 )
 
 func build(name string, f func(document *goquery.Document)) {
-	fmt.Println(fmt.Sprintf(statusBuildPackage, name))
-	if _, err := os.Stat(directory.Unicode); os.IsNotExist(err) {
-		fmt.Println(fmt.Sprintf(errorCannotFind, "unicode"))
-		os.Exit(2)
-	}
+	prebuild(name)
 	document, err := pkg.Open()
 	if err != nil {
-		fmt.Println(fmt.Sprintf(errorCannotOpen, "unicode", err))
-		os.Exit(1)
+		fail(fmt.Sprintf(errorCannotOpen, "unicode", err), err)
 	}
+	selectable(document)
 	f(document)
+	postbuild(name)
+}
+
+// selectable fails with the usage when --category names a category the unicode chart in the document does not have,
+// rather than building none of its emoji.
+func selectable(document *goquery.Document) {
+	unselectable(pkg.Unknown(document))
+}
+
+// unselectable fails with the usage naming the categories of --category that are unknown, if there are any.
+func unselectable(unknown []string) {
+	if len(unknown) != 0 {
+		failWith(usage, fmt.Sprintf(errorCategory, strings.Join(unknown, ", ")), ErrUnknownCategory)
+	}
+}
+
+// making adapts a Make function that returns an error to build, failing the build of the named package with the error.
+func making(name string, f func(document *goquery.Document) error) func(document *goquery.Document) {
+	return func(document *goquery.Document) {
+		if err := f(document); err != nil {
+			fail(fmt.Sprintf(errorBuildPackage, name, err), err)
+		}
+	}
+}
+
+// buildStream builds the named package from the unicode chart read row by row. As the chart is not read whole,
+// the categories of --category are checked against the stored categories, when they are built.
+func buildStream(name string, f func(reader io.Reader) error) {
+	prebuild(name)
+	if stored, err := categories.Open(); err == nil {
+		unknown := []string{}
+		for _, c := range pkg.Categories {
+			if stored.Has(c) == false {
+				unknown = append(unknown, c)
+			}
+		}
+		unselectable(unknown)
+	}
+	reader, err := pkg.Reader()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "unicode", err), err)
+	}
+	defer reader.Close()
+	if err := f(reader); err != nil {
+		fail(fmt.Sprintf(errorBuildPackage, name, err), err)
+	}
+	postbuild(name)
+}
+
+func prebuild(name string) {
+	fmt.Println(fmt.Sprintf(statusBuildPackage, name))
+	if err := store.Writable(); err != nil {
+		fail(fmt.Sprintf(errorBuildPackage, name, err), err)
+	}
+	lock()
+	if manifest.Inspect(UNICODE, directory.Unicode).Exists == false {
+		fail(store.ErrMissingUnicodeFile.Error(), store.ErrMissingUnicodeFile)
+	}
+	pinned(name)
+	if name == EMOJIPEDIA && (webhook.Enabled() || sourced(GEMOJI)) {
+		previous, _ = emojipedia.Open()
+	}
+}
+
+// pinned fails the build of the named package when --unicode-version asks for another version than the stored chart,
+// or pins the version of the stored chart when none was asked for.
+func pinned(name string) {
+	metadata, err := pkg.Meta()
+	if err != nil {
+		return
+	}
+	switch {
+	case len(pkg.Version) == 0:
+		pkg.Version = metadata.Version
+	case metadata.Version != pkg.Version:
+		stored := metadata.Version
+		if len(stored) == 0 {
+			stored = "latest"
+		}
+		failWith(missing, fmt.Sprintf(errorPinned, name, stored, pkg.Version), ErrPinned)
+	}
+}
+
+func postbuild(name string) {
+	if name == EMOJIPEDIA && sourced(UNICODE) && sourced(GEMOJI) {
+		mergeGemoji()
+	}
+	if name == EMOJIPEDIA && sourced(UNICODE) && sourced(CLDR) {
+		mergeCLDR()
+	}
+	if name == EMOJIPEDIA && sourced(UNICODE) && sourced(EMOJIPEDIA) {
+		mergeEmojipediaOrg()
+	}
+	if name == EMOJIPEDIA && (sourced(OPENMOJI) || sourced(JOYPIXELS)) {
+		mergeSources()
+	}
+	if problems := integrity.Check(); problems.Len() != 0 {
+		fmt.Println(fmt.Sprintf(errorIntegrity, name, problems.Len()))
+		problems.Each(func(_ int, i interface{}) {
+			fmt.Println(i.(string))
+		})
+		exit(exitFailed)
+	}
+	if err := manifest.Touch(name); err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "manifest", err), err)
+	}
+	if name == EMOJIPEDIA && webhook.Enabled() {
+		notify(previous)
+	}
 	fmt.Println(fmt.Sprintf("successfully built %s", name))
-	os.Exit(0)
+	exit(exitOK)
+}
+
+// notify sends the configured webhook a summary of how the stored emoji differ from the previous dataset.
+// Failing to notify is reported but does not fail the build.
+func notify(previous *emojipedia.Emojipedia) {
+	if previous == nil {
+		previous = emojipedia.New()
+	}
+	current, err := emojipedia.Open()
+	if err != nil {
+		fmt.Println(fmt.Sprintf(errorWebhook, err))
+		return
+	}
+	if err := webhook.Notify(summarize(previous, current)); err != nil {
+		fmt.Println(fmt.Sprintf(errorWebhook, err))
+	}
+}
+
+func buildMain(arguments *arguments.Arguments) {
+	_, restart := arguments.Flag("restart")
+	switch command(arguments, ALL) {
+	case "", A, ALL:
+		buildAll(restart)
+	default:
+		a := stdin.Arg{
+			About:   "fetch and build every package, resuming an interrupted build unless --restart is given",
+			Example: "emojipedia build all --restart",
+			Short:   A,
+			Verbose: ALL}
+		fmt.Fprintln(writer, "usage: emojipedia [-b build] [<option>] [--flags]")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "building every package")
+		fmt.Fprintln(writer, a)
+		fmt.Fprintln(writer)
+		writer.Flush()
+		unknown(arguments)
+	}
+}
+
+// buildAll fetches the unicode.org chart and builds every package from it, storing a checkpoint.Checkpoint
+// after each stage and every few emoji. An interrupted build resumes from its checkpoint unless restart is set
+// or the chart has changed since.
+func buildAll(restart bool) {
+	if restart {
+		if err := checkpoint.Remove(); err != nil {
+			fail(fmt.Sprintf(errorCannotWrite, directory.Checkpoint, err), err)
+		}
+	}
+	progress, err := checkpoint.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, directory.Checkpoint, err), err)
+	}
+	if progress.Resumes(pkg.Fetched(), pkg.Categories) == false {
+		progress = checkpoint.New()
+	}
+	if len(progress.Stages) != 0 {
+		fmt.Println(fmt.Sprintf(statusResume, strings.ToLower(strings.Join(progress.Stages, ", ")), progress.Row))
+	}
+	progress.Categories = pkg.Categories
+	save := func() {
+		if err := checkpoint.Write(progress); err != nil {
+			fail(fmt.Sprintf(errorCannotWrite, directory.Checkpoint, err), err)
+		}
+	}
+	if progress.Completed(UNICODE) == false {
+		fmt.Println(fmt.Sprintf(statusBuildPackage, strings.ToLower(UNICODE)))
+		if err := collect(); err != nil {
+			fail(fmt.Sprintf(errorBuildPackage, strings.ToLower(UNICODE), err), err)
+		}
+		if err := manifest.Touch(UNICODE); err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, "manifest", err), err)
+		}
+		progress.Chart = pkg.Fetched()
+		progress.Complete(UNICODE)
+		save()
+	}
+	pinned(strings.ToLower(ALL))
+	document, err := pkg.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "unicode", err), err)
+	}
+	selectable(document)
+	if webhook.Enabled() || sourced(GEMOJI) {
+		previous, _ = emojipedia.Open()
+	}
+	stages := []struct {
+		name string
+		make func(document *goquery.Document)
+	}{
+		{CATEGORIES, making(CATEGORIES, categories.Make)},
+		{SUBCATEGORIES, making(SUBCATEGORIES, subcategories.Make)},
+		{KEYWORDS, keywords.Make},
+		{EMOJIPEDIA, func(document *goquery.Document) {
+			err := emojipedia.Resume(document, progress.Row, func(row int) {
+				progress.Row = row
+				save()
+			})
+			if err != nil {
+				fail(fmt.Sprintf(errorBuildPackage, EMOJIPEDIA, err), err)
+			}
+		}}}
+	for _, stage := range stages {
+		if progress.Completed(stage.name) {
+			continue
+		}
+		fmt.Println(fmt.Sprintf(statusBuildPackage, strings.ToLower(stage.name)))
+		stage.make(document)
+		if err := manifest.Touch(stage.name); err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, "manifest", err), err)
+		}
+		progress.Complete(stage.name)
+		save()
+	}
+	if sourced(GEMOJI) {
+		mergeGemoji()
+	}
+	if sourced(CLDR) {
+		mergeCLDR()
+	}
+	if sourced(EMOJIPEDIA) {
+		mergeEmojipediaOrg()
+	}
+	if sourced(OPENMOJI) || sourced(JOYPIXELS) {
+		mergeSources()
+	}
+	// The checkpoint is removed whether or not the dataset is intact, so a build run after a failed integrity check
+	// builds every package again rather than resuming with every stage complete.
+	if err := checkpoint.Remove(); err != nil {
+		fail(fmt.Sprintf(errorCannotWrite, directory.Checkpoint, err), err)
+	}
+	if problems := integrity.Check(); problems.Len() != 0 {
+		fmt.Println(fmt.Sprintf(errorIntegrity, strings.ToLower(ALL), problems.Len()))
+		problems.Each(func(_ int, i interface{}) {
+			fmt.Println(i.(string))
+		})
+		exit(exitFailed)
+	}
+	if webhook.Enabled() {
+		notify(previous)
+	}
+	fmt.Println(fmt.Sprintf(successBuildPackage, strings.ToLower(ALL)))
+	exit(exitOK)
+}
+
+// reindex writes the index.Index of the stored emoji and keywords when they have changed, so that lookups read
+// only what they need. A dataset without emoji is left without an index.Index.
+func reindex() {
+	if index.Changed() == false {
+		return
+	}
+	// Commands holding the dataset lock rebuild the index before releasing it; the others take the lock so
+	// the index is not rebuilt while another run changes the dataset, and leave the index to the next run if it is held.
+	if locked == false {
+		release, err := store.Lock()
+		if err != nil {
+			return
+		}
+		defer release()
+	}
+	emojipedia, err := emojipedia.Open()
+	if err != nil {
+		return
+	}
+	i := index.New()
+	emojipedia.Each(func(_ string, e *emoji.Emoji) {
+		if content, err := json.Marshal(e); err == nil {
+			i.Add(e.Name, e.ID, content)
+		}
+	})
+	if keywords, err := keywords.Open(); err == nil {
+		keywords.Each(func(key string, s *slice.Slice) {
+			names := []string{}
+			s.Each(func(_ int, x interface{}) {
+				names = append(names, x.(string))
+			})
+			i.Keyword(key, names...)
+		})
+	}
+	if err := index.Write(i); err != nil {
+		fmt.Println(fmt.Sprintf(errorCannotWrite, directory.Index, err))
+	}
 }
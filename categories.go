@@ -6,6 +6,7 @@ import (
 
 	"github.com/gellel/emojipedia/arguments"
 	"github.com/gellel/emojipedia/categories"
+	"github.com/gellel/emojipedia/pattern"
 	"github.com/gellel/emojipedia/slice"
 	"github.com/gellel/emojipedia/stdin"
 )
@@ -13,6 +14,7 @@ import (
 func categoriesGet(arguments *arguments.Arguments) {
 	var (
 		categories = categories.Get()
+		unknown    = []string{}
 	)
 	fmt.Fprintln(writer, "Name\t|Number\t|Subcategories")
 	if arguments.Get(0) != "" {
@@ -25,19 +27,27 @@ func categoriesGet(arguments *arguments.Arguments) {
 					output        = fmt.Sprintf("%v\t|%v\t|%v", name, number, subcategories)
 				)
 				fmt.Fprintln(writer, output)
+			} else if strings.HasPrefix(argument, "--") == false {
+				unknown = append(unknown, argument)
 			}
 		})
 		writer.Flush()
+		notFound(unknown, C, CATEGORIES)
 	}
 }
 
 func categoriesKeys(arguments *arguments.Arguments) {
 	var (
 		categories = categories.Get()
+		n          = 0
+		patterns   = patternsOf(arguments)
 	)
 	fmt.Fprintln(writer, "N\t|Name")
-	categories.Keys().Sort().Each(func(i int, x interface{}) {
-		fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v", i, x.(string)))
+	categories.Keys().Sort().Each(func(_ int, x interface{}) {
+		if pattern.Any(patterns, x.(string)) {
+			fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v", n, x.(string)))
+			n++
+		}
 	})
 	writer.Flush()
 }
@@ -71,9 +81,9 @@ func categoriesNumber(arguments *arguments.Arguments) {
 }
 
 func categoriesMain(arguments *arguments.Arguments) {
-	switch strings.ToUpper(arguments.Get(0)) {
+	switch command(arguments, BUILD, GET, KEYS, LIST, NUMBER, REMOVE) {
 	case B, BUILD:
-		build(CATEGORIES, categories.Make)
+		build(CATEGORIES, making(CATEGORIES, categories.Make))
 	case G, GET:
 		categoriesGet(arguments.Next())
 	case K, KEYS:
@@ -92,6 +102,8 @@ func categoriesMain(arguments *arguments.Arguments) {
 				Verbose: BUILD}
 			g = stdin.Arg{
 				About:   "get one or more categories",
+				Args:    "<category> [...<category>]",
+				Example: "emojipedia categories get smileys-and-emotion",
 				Short:   G,
 				Verbose: GET}
 			k = stdin.Arg{
@@ -125,5 +137,6 @@ func categoriesMain(arguments *arguments.Arguments) {
 		})
 		fmt.Fprintln(writer)
 		writer.Flush()
+		unknown(arguments)
 	}
 }
@@ -1,16 +1,21 @@
 package categories
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
-	"os"
+	"sort"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/gellel/emojipedia/category"
+	"github.com/gellel/emojipedia/conflict"
 	"github.com/gellel/emojipedia/directory"
 	"github.com/gellel/emojipedia/lexicon"
 	"github.com/gellel/emojipedia/pkg"
 	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/store"
 	"github.com/gellel/emojipedia/text"
 )
 
@@ -18,12 +23,12 @@ var _ categories = (*Categories)(nil)
 
 // New instantiates a new empty Categories pointer.
 func New() *Categories {
-	return &Categories{&lexicon.Lexicon{}}
+	return &Categories{lexicon.NewOrdered()}
 }
 
 // NewCategories creates a new Categories pointer, accepting zero or more category.Category pointers as arguments.
 func NewCategories(category ...*category.Category) *Categories {
-	categories := &Categories{&lexicon.Lexicon{}}
+	categories := &Categories{lexicon.NewOrdered()}
 	for _, category := range category {
 		categories.Add(category)
 	}
@@ -44,85 +49,123 @@ func Lexicon() (*lexicon.Lexicon, error) {
 	if err != nil {
 		return nil, err
 	}
-	return categories.lexicon, nil
+	return categories.lexicon.Lexicon(), nil
 }
 
-func Make(document *goquery.Document) {
-	var key string
+// Make builds Category dependencies from HTML scraped from unicode.org.
+// Returns the error of the first category heading that cannot be built, in which case nothing is stored, or of the first
+// Category that cannot be written. Categories rejected as invalid in store.Strict mode are skipped.
+func Make(document *goquery.Document) error {
+	var (
+		err error
+		key string
+	)
 	categories := New()
 	document.Find("tr").Each(func(i int, selection *goquery.Selection) {
 		selection.Find("th.bighead a").Each(func(j int, s *goquery.Selection) {
-			var (
-				anchor, _     = s.Attr("href")
-				emoji         = &slice.Slice{}
-				href          = (pkg.URL + anchor)
-				position      = i
-				name          = text.Normalize(s.Text())
-				number        = categories.Len()
-				subcategories = &slice.Slice{}
-				category      = category.NewCategory(anchor, href, name, number, position, emoji, subcategories)
-			)
+			key = ""
+			anchor, _ := s.Attr("href")
+			category, buildErr := category.NewBuilder().
+				Anchor(anchor).
+				Href(pkg.Address() + anchor).
+				Name(text.Normalize(s.Text())).
+				Number(categories.Len()).
+				Position(i).
+				Build()
+			if buildErr != nil {
+				if err == nil {
+					err = fmt.Errorf("row %v: %s", i, buildErr)
+				}
+				return
+			}
 			categories.Add(category)
 			key = category.Name
 		})
 		selection.Find("th.mediumhead a").Each(func(j int, s *goquery.Selection) {
-			var (
-				category, _ = categories.Get(key)
-				subcategory = text.Normalize(s.Text())
-			)
-			category.Subcategories.Append(subcategory)
+			if category, ok := categories.Get(key); ok {
+				category.Subcategories.Append(text.Normalize(s.Text()))
+			}
 		})
 		selection.Find("td").Eq(3).Each(func(j int, s *goquery.Selection) {
-			var (
-				category, _ = categories.Get(key)
-				name        = text.Normalize(s.Text())
-			)
-			category.Emoji.Append(name)
+			if category, ok := categories.Get(key); ok {
+				category.Emoji.Append(text.Normalize(s.Text()))
+			}
 		})
 	})
+	if err != nil {
+		return err
+	}
 	categories.Each(func(c *category.Category) {
-		category.Write(c)
+		if pkg.Selected(c.Name) == false || err != nil {
+			return
+		}
+		if writeErr := category.Write(c); writeErr != nil && errors.Is(writeErr, store.ErrInvalid) == false {
+			err = writeErr
+		}
 	})
+	return err
 }
 
-// Open attempts to open all Category data from the emojipedia/categories folder.
+// Open attempts to open all Category data from the emojipedia/categories folder, in chart order.
 func Open() (*Categories, error) {
 	files, err := ioutil.ReadDir(directory.Category)
 	if err != nil {
-		return nil, err
+		return nil, store.Missing(err, store.ErrMissingCategories)
 	}
-	categories := New()
+	var (
+		held   = map[string]*category.Category{}
+		values = []*category.Category{}
+	)
 	for _, file := range files {
 		name := strings.TrimSuffix(file.Name(), ".json")
-		category, err := category.Open(name)
+		stored, err := category.Open(name)
 		if err != nil {
 			return nil, err
 		}
-		categories.Add(category)
+		// A category unicode has divided is read as its shares, joining the categories they went to.
+		for _, share := range category.Split(stored) {
+			if c, ok := held[share.Name]; ok {
+				c.Union(share)
+				continue
+			}
+			held[share.Name], values = share, append(values, share)
+		}
 	}
-	return categories, nil
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Position < values[j].Position
+	})
+	return NewCategories(values...), nil
 }
 
 // Remove deletes all Category data stored in the dependencies folder.
 func Remove() error {
-	return os.Remove(directory.Category)
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	return store.Trash(directory.Category)
 }
 
 type categories interface {
 	Add(category *category.Category) *Categories
 	Each(f func(category *category.Category)) *Categories
+	Every(f func(category *category.Category) bool) bool
 	Fetch(key string) *category.Category
+	Find(f func(category *category.Category) bool) (*category.Category, bool)
 	Get(key string) (*category.Category, bool)
 	Has(key string) bool
 	Keys() *slice.Slice
 	Len() int
+	Map(f func(category *category.Category) interface{}) *slice.Slice
+	Merge(categories *Categories, strategy conflict.Strategy) *Categories
+	Reduce(f func(accumulator interface{}, category *category.Category) interface{}, initial interface{}) interface{}
 	Remove(key string) bool
+	Some(f func(category *category.Category) bool) bool
 	Values() *slice.Slice
 }
 
 // Categories is a map-like struct with methods used to perform traversal and retrieval of category.Category pointers.
 type Categories struct {
-	lexicon *lexicon.Lexicon
+	lexicon *lexicon.OrderedLexicon
 }
 
 // Add method adds one category.Category to the Categories using the category.Category.Name as the key reference.
@@ -131,7 +174,7 @@ func (pointer *Categories) Add(category *category.Category) *Categories {
 	return pointer
 }
 
-// Each method executes a provided function once for each category.Category pointer.
+// Each method executes a provided function once for each category.Category pointer, in the order they were added.
 func (pointer *Categories) Each(f func(category *category.Category)) *Categories {
 	pointer.lexicon.Each(func(key string, i interface{}) {
 		f(i.(*category.Category))
@@ -139,12 +182,34 @@ func (pointer *Categories) Each(f func(category *category.Category)) *Categories
 	return pointer
 }
 
+// Every method tests whether every category.Category pointer passes the provided function, in the same order as Each.
+// Returns true when the Categories is empty.
+func (pointer *Categories) Every(f func(category *category.Category) bool) bool {
+	every := true
+	pointer.Each(func(category *category.Category) {
+		every = every && f(category)
+	})
+	return every
+}
+
 // Fetch retrieves the category.Category pointer held by the argument key. Panics if key does not exist.
 func (pointer *Categories) Fetch(key string) *category.Category {
 	property, _ := pointer.Get(key)
 	return property
 }
 
+// Find method returns the first category.Category pointer, in the same order as Each, that passes the provided function
+// and a boolean indicating if one was found.
+func (pointer *Categories) Find(f func(category *category.Category) bool) (*category.Category, bool) {
+	var found *category.Category
+	pointer.Each(func(category *category.Category) {
+		if found == nil && f(category) {
+			found = category
+		}
+	})
+	return found, found != nil
+}
+
 // Get returns the category.Category pointer held by the argument key and a boolean indicating if it was successfully retrieved.
 // Panics if cannot convert to category.Category pointer.
 func (pointer *Categories) Get(key string) (*category.Category, bool) {
@@ -160,7 +225,7 @@ func (pointer *Categories) Has(key string) bool {
 	return pointer.lexicon.Has(key)
 }
 
-// Keys method returns a slice.Slice of a given Categories' own property names, in the same order as we get with a normal loop.
+// Keys method returns a slice.Slice of a given Categories' own property names, in the order they were added.
 func (pointer *Categories) Keys() *slice.Slice {
 	slice := slice.New()
 	pointer.lexicon.Each(func(key string, i interface{}) {
@@ -174,13 +239,80 @@ func (pointer *Categories) Len() int {
 	return pointer.lexicon.Len()
 }
 
+// Map method executes a provided function once for each category.Category pointer and returns a slice.Slice of the returned values,
+// in the same order as Each.
+func (pointer *Categories) Map(f func(category *category.Category) interface{}) *slice.Slice {
+	slice := slice.New()
+	pointer.Each(func(category *category.Category) {
+		slice.Append(f(category))
+	})
+	return slice
+}
+
+// MarshalJSON encodes the Categories as a plain JSON object keyed by name.
+func (pointer *Categories) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pointer.lexicon)
+}
+
+// Reduce method executes a provided function once for each category.Category pointer, in the same order as Each,
+// passing the value returned by the previous call (or the initial value) and returning the last value.
+func (pointer *Categories) Reduce(f func(accumulator interface{}, category *category.Category) interface{}, initial interface{}) interface{} {
+	accumulator := initial
+	pointer.Each(func(category *category.Category) {
+		accumulator = f(accumulator, category)
+	})
+	return accumulator
+}
+
+// Merge method adds a copy of every category.Category of the argument Categories to the Categories.
+// Names held by both are resolved by the conflict.Strategy: kept, replaced by the merged copy or combined by category.Category.Union.
+func (pointer *Categories) Merge(categories *Categories, strategy conflict.Strategy) *Categories {
+	categories.Each(func(category *category.Category) {
+		held, ok := pointer.Get(category.Name)
+		switch {
+		case ok == false || strategy == conflict.Replace:
+			pointer.Add(category.Copy())
+		case strategy == conflict.Union:
+			held.Union(category)
+		}
+	})
+	return pointer
+}
+
 // Remove method removes a entry from the Categories if it exists. Returns a boolean to confirm if it succeeded.
 func (pointer *Categories) Remove(key string) bool {
 	return pointer.lexicon.Remove(key)
 }
 
+// Some method tests whether at least one category.Category pointer passes the provided function.
+func (pointer *Categories) Some(f func(category *category.Category) bool) bool {
+	_, ok := pointer.Find(f)
+	return ok
+}
+
+// UnmarshalJSON decodes a plain JSON object keyed by name into the Categories in chart order, replacing its contents.
+func (pointer *Categories) UnmarshalJSON(content []byte) error {
+	values := map[string]*category.Category{}
+	err := json.Unmarshal(content, &values)
+	if err != nil {
+		return err
+	}
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return values[keys[i]].Position < values[keys[j]].Position
+	})
+	pointer.lexicon = lexicon.NewOrdered()
+	for _, key := range keys {
+		pointer.lexicon.Add(key, values[key])
+	}
+	return nil
+}
+
 // Values method returns a Slice of a given Categories's own enumerable property values,
-// in the same order as that provided by a for...in loop.
+// in the order they were added.
 func (pointer *Categories) Values() *slice.Slice {
 	slice := slice.New()
 	pointer.lexicon.Each(func(key string, i interface{}) {
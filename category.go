@@ -9,13 +9,14 @@ import (
 
 	"github.com/gellel/emojipedia/arguments"
 	"github.com/gellel/emojipedia/category"
+	"github.com/gellel/emojipedia/store"
 )
 
 func categoryMain(arguments *arguments.Arguments) {
 	c, err := category.Open(arguments.Get(0))
 	switch err == nil {
 	case true:
-		switch strings.ToUpper(arguments.Next().Get(0)) {
+		switch command(arguments.Next(), ANCHOR, EMOJI, HREF, NUMBER, POSITION, SUBCATEGORIES, TABLE) {
 		case A, ANCHOR:
 			fmt.Println(c.Anchor)
 		case E, EMOJI:
@@ -55,13 +56,13 @@ func categoryMain(arguments *arguments.Arguments) {
 			writer.Flush()
 		default:
 			var (
-				a = stdin.Arg{"get the category href", A, ANCHOR}
-				e = stdin.Arg{"show all emoji (list)", E, EMOJI}
-				h = stdin.Arg{"get the full emoji category URL", H, HREF}
-				n = stdin.Arg{"get the categorical number", N, NUMBER}
-				p = stdin.Arg{"show the position the category was parsed", P, POSITION}
-				s = stdin.Arg{"show all subcategories for category (list)", S, SUBCATEGORIES}
-				t = stdin.Arg{"table the category", T, TABLE}
+				a = stdin.Arg{About: "get the category href", Short: A, Verbose: ANCHOR}
+				e = stdin.Arg{About: "show all emoji (list)", Short: E, Verbose: EMOJI}
+				h = stdin.Arg{About: "get the full emoji category URL", Short: H, Verbose: HREF}
+				n = stdin.Arg{About: "get the categorical number", Short: N, Verbose: NUMBER}
+				p = stdin.Arg{About: "show the position the category was parsed", Short: P, Verbose: POSITION}
+				s = stdin.Arg{About: "show all subcategories for category (list)", Short: S, Verbose: SUBCATEGORIES}
+				t = stdin.Arg{About: "table the category", Short: T, Verbose: TABLE}
 			)
 			fmt.Fprintln(writer, fmt.Sprintf("usage: emojipedia [-cc category] %s [<option>] [--flags]", c.Name))
 			fmt.Fprintln(writer)
@@ -70,8 +71,12 @@ func categoryMain(arguments *arguments.Arguments) {
 			})
 			fmt.Fprintln(writer)
 			writer.Flush()
+			unknown(arguments)
 		}
 	default:
-		fmt.Println(fmt.Sprintf(errorChoiceNotFound, arguments.Get(0), "-cc", strings.ToLower(CATEGORY)))
+		if fieldError, ok := err.(*store.FieldError); ok {
+			fail(fieldError.Error(), err)
+		}
+		fail(fmt.Sprintf(errorChoiceNotFound, arguments.Get(0), "-cc", strings.ToLower(CATEGORY)), err)
 	}
 }
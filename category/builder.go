@@ -0,0 +1,68 @@
+package category
+
+import (
+	"fmt"
+
+	"github.com/gellel/emojipedia/slice"
+)
+
+// NewBuilder instantiates a new Builder of an empty Category.
+func NewBuilder() *Builder {
+	return &Builder{category: New()}
+}
+
+// Builder assembles a Category one named field at a time in place of the positional NewCategory,
+// checking that the Category can be stored when it is built.
+type Builder struct {
+	category *Category
+}
+
+// Anchor sets the anchor of the Category on the unicode.org page.
+func (pointer *Builder) Anchor(anchor string) *Builder {
+	pointer.category.SetAnchor(anchor)
+	return pointer
+}
+
+// Build returns the Category, or an error if it has no name.
+func (pointer *Builder) Build() (*Category, error) {
+	if len(pointer.category.Name) == 0 {
+		return nil, fmt.Errorf("category: cannot build a category without a name")
+	}
+	return pointer.category.Copy(), nil
+}
+
+// Emoji sets the names of the emoji in the Category.
+func (pointer *Builder) Emoji(emoji ...string) *Builder {
+	pointer.category.SetEmoji(slice.Strings(emoji...))
+	return pointer
+}
+
+// Href sets the address of the Category on the unicode.org page.
+func (pointer *Builder) Href(href string) *Builder {
+	pointer.category.SetHref(href)
+	return pointer
+}
+
+// Name sets the name of the Category.
+func (pointer *Builder) Name(name string) *Builder {
+	pointer.category.SetName(name)
+	return pointer
+}
+
+// Number sets the number of the Category in the unicode.org chart.
+func (pointer *Builder) Number(number int) *Builder {
+	pointer.category.SetNumber(number)
+	return pointer
+}
+
+// Position sets the row of the Category in the unicode.org chart.
+func (pointer *Builder) Position(position int) *Builder {
+	pointer.category.SetPosition(position)
+	return pointer
+}
+
+// Subcategories sets the names of the subcategories in the Category.
+func (pointer *Builder) Subcategories(subcategories ...string) *Builder {
+	pointer.category.SetSubcategories(slice.Strings(subcategories...))
+	return pointer
+}
@@ -1,14 +1,16 @@
 package category
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
-	"path/filepath"
 
+	"github.com/gellel/emojipedia/alias"
 	"github.com/gellel/emojipedia/directory"
 	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/subcategory"
+	"github.com/gellel/emojipedia/text"
 )
 
 var _ category = (*Category)(nil)
@@ -30,6 +32,8 @@ func New() *Category {
 }
 
 // NewCategory creates a new Category pointer, requiring all struct features as arguments.
+//
+// Deprecated: use NewBuilder, which names each field and checks the Category when it is built.
 func NewCategory(anchor, href, name string, number, position int, emoji, subcategories *slice.Slice) *Category {
 	return &Category{
 		Anchor:        anchor,
@@ -50,27 +54,101 @@ func Get(name string) *Category {
 	return category
 }
 
-// Open attempts to open a Category from the emojipedia/categories folder.
+// Open attempts to open a Category from the emojipedia/categories folder. A Category read from the category unicode
+// divided it from holds only its share of the subcategories and emoji.
 func Open(name string) (*Category, error) {
 	content, err := Read(name)
 	if err != nil {
 		return nil, err
 	}
-	return Parse(content)
+	category, err := Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	for _, share := range Split(category) {
+		if share.Name == alias.Category(name, "") {
+			return share, nil
+		}
+	}
+	return category, nil
 }
 
+// Parse decodes stored Category content, giving the category and its subcategories the names unicode has since renamed them to.
 func Parse(content *[]byte) (*Category, error) {
 	category := &Category{}
-	err := json.Unmarshal(*content, category)
+	err := store.Unmarshal(*content, category)
 	if err != nil {
 		return nil, err
 	}
+	category.Name = alias.Category(category.Name, "")
+	if category.Subcategories != nil {
+		category.Subcategories.Map(func(_ int, i interface{}) interface{} {
+			if name, ok := i.(string); ok {
+				return alias.Subcategory(name)
+			}
+			return nil
+		})
+	}
 	return category, nil
 }
 
+// Split returns the shares of a Category unicode has since divided between several categories, named after the
+// categories they went to. Subcategories are shared out by alias.Category and emoji go with the stored subcategory
+// holding them, or to the alias.Split Default when none does. A Category that was not divided is returned alone.
+func Split(category *Category) []*Category {
+	split, ok := alias.Splits[category.Name]
+	if ok == false {
+		return []*Category{category}
+	}
+	var (
+		owners = map[string]string{}
+		shares = []*Category{}
+	)
+	share := func(name string) *Category {
+		for _, c := range shares {
+			if c.Name == name {
+				return c
+			}
+		}
+		c := category.Copy().SetName(name).SetEmoji(&slice.Slice{}).SetSubcategories(&slice.Slice{})
+		shares = append(shares, c)
+		return c
+	}
+	if category.Subcategories != nil {
+		category.Subcategories.Each(func(_ int, i interface{}) {
+			name := i.(string)
+			owner := alias.Category(category.Name, name)
+			share(owner).Subcategories.Append(name)
+			if s, err := subcategory.Open(name); err == nil && s.Emoji != nil {
+				s.Emoji.Each(func(_ int, e interface{}) {
+					owners[e.(string)] = owner
+				})
+			}
+		})
+	}
+	if category.Emoji != nil {
+		category.Emoji.Each(func(_ int, i interface{}) {
+			owner, ok := owners[i.(string)]
+			if ok == false {
+				owner = split.Default
+			}
+			share(owner).Emoji.Append(i)
+		})
+	}
+	return shares
+}
+
+// Read attempts to read the raw content of a Category by its name, or by another name unicode has known it by.
 func Read(name string) (*[]byte, error) {
-	filepath := filepath.Join(directory.Category, fmt.Sprintf("%s.json", name))
-	reader, err := os.Open(filepath)
+	var (
+		reader *os.File
+		err    error
+	)
+	for _, name := range alias.CategoryNames(name) {
+		if reader, err = os.Open(store.Path(directory.Category, name)); err == nil {
+			break
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -84,24 +162,44 @@ func Read(name string) (*[]byte, error) {
 
 // Remove deletes the Category data stored in the dependencies folder.
 func Remove(name string) error {
-	return os.Remove(filepath.Join(directory.Category, fmt.Sprintf("%s.json", name)))
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := store.Trash(store.Path(directory.Category, name))
+	if err != nil {
+		return err
+	}
+	return store.Forget(directory.Category, name)
 }
 
 // Write stores and Category pointer to the dependencies folder.
 func Write(category *Category) error {
-	err := os.MkdirAll(directory.Category,  os.ModePerm)
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(directory.Category, os.ModePerm)
 	if err != nil {
 		return err
 	}
-	content, err := json.Marshal(category)
+	if store.Strict {
+		if err := category.Validate(); err != nil {
+			return store.Invalid(err)
+		}
+	}
+	content, err := store.Marshal(category)
 	if err != nil {
 		return err
 	}
-	filepath := filepath.Join(directory.Category, fmt.Sprintf("%s.json", category.Name))
-	return ioutil.WriteFile(filepath, content,  os.ModePerm)
+	filepath, err := store.Assign(directory.Category, category.Name)
+	if err != nil {
+		return err
+	}
+	return store.WriteFile(filepath, content)
 }
 
 type category interface {
+	Copy() *Category
+	SubcategoryRefs() []*subcategory.Subcategory
 	SetAnchor(anchor string) *Category
 	SetEmoji(category *slice.Slice) *Category
 	SetHref(href string) *Category
@@ -109,6 +207,8 @@ type category interface {
 	SetNumber(number int) *Category
 	SetPosition(position int) *Category
 	SetSubcategories(subcategories *slice.Slice) *Category
+	Union(category *Category) *Category
+	Validate() error
 }
 
 // Category stores the categorical superset of the emoji data.
@@ -122,6 +222,25 @@ type Category struct {
 	Subcategories *slice.Slice `json:"subcategories"`
 }
 
+// Copy method returns a deep copy of the Category, sharing no slices with it.
+func (pointer *Category) Copy() *Category {
+	category := *pointer
+	category.Emoji, category.Subcategories = pointer.Emoji.Copy(), pointer.Subcategories.Copy()
+	return &category
+}
+
+// SubcategoryRefs resolves the Category.Subcategories names to their stored subcategory.Subcategory pointers.
+// Names that cannot be opened are skipped; use the integrity package to report them.
+func (pointer *Category) SubcategoryRefs() []*subcategory.Subcategory {
+	refs := []*subcategory.Subcategory{}
+	pointer.Subcategories.Each(func(_ int, i interface{}) {
+		if s, err := subcategory.Open(i.(string)); err == nil {
+			refs = append(refs, s)
+		}
+	})
+	return refs
+}
+
 // SetAnchor sets the Category.Anchor property.
 func (pointer *Category) SetAnchor(anchor string) *Category {
 	pointer.Anchor = anchor
@@ -163,3 +282,37 @@ func (pointer *Category) SetSubcategories(subcategories *slice.Slice) *Category
 	pointer.Subcategories = subcategories
 	return pointer
 }
+
+// Union method fills the empty anchor, href and name of the Category from the argument Category
+// and adds the emoji and subcategories the Category does not hold.
+func (pointer *Category) Union(category *Category) *Category {
+	if len(pointer.Anchor) == 0 {
+		pointer.Anchor = category.Anchor
+	}
+	if len(pointer.Href) == 0 {
+		pointer.Href = category.Href
+	}
+	if len(pointer.Name) == 0 {
+		pointer.Name = category.Name
+	}
+	if pointer.Emoji == nil {
+		pointer.Emoji = &slice.Slice{}
+	}
+	if pointer.Subcategories == nil {
+		pointer.Subcategories = &slice.Slice{}
+	}
+	pointer.Emoji.Union(category.Emoji)
+	pointer.Subcategories.Union(category.Subcategories)
+	return pointer
+}
+
+// Validate checks that the Category can be stored and looked up: it is named and its href is a web address.
+func (pointer *Category) Validate() error {
+	switch {
+	case len(pointer.Name) == 0:
+		return fmt.Errorf("category: category at position %d has no name", pointer.Position)
+	case len(pointer.Href) != 0 && text.IsURL(pointer.Href) == false:
+		return fmt.Errorf("category: \"%s\" has the href \"%s\" which is not a web address", pointer.Name, pointer.Href)
+	}
+	return nil
+}
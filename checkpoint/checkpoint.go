@@ -0,0 +1,99 @@
+package checkpoint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/store"
+)
+
+// New instantiates a new Checkpoint pointer for a build that has not started.
+func New() *Checkpoint {
+	return &Checkpoint{Categories: []string{}, Row: -1, Stages: []string{}}
+}
+
+// Open attempts to open the Checkpoint of the active profile. A profile without a Checkpoint opens a new Checkpoint.
+func Open() (*Checkpoint, error) {
+	content, err := ioutil.ReadFile(directory.Checkpoint)
+	if os.IsNotExist(err) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := New()
+	err = store.Decode(directory.Checkpoint, content, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	return checkpoint, nil
+}
+
+// Remove deletes the Checkpoint stored in the active profile, so the next build starts over.
+func Remove() error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.Remove(directory.Checkpoint)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Write stores the Checkpoint in the active profile.
+func Write(checkpoint *Checkpoint) error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(filepath.Dir(directory.Checkpoint), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	content, err := store.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	return store.WriteFile(directory.Checkpoint, content)
+}
+
+// Checkpoint is how far a build of every package got: the stages it completed and the row index of the
+// unicode.org chart of the last emoji it wrote. The chart it was building from and the categories it was
+// restricted to are kept so that a Checkpoint of another build is not resumed.
+type Checkpoint struct {
+	Categories []string  `json:"categories"`
+	Chart      time.Time `json:"chart"`
+	Row        int       `json:"row"`
+	Stages     []string  `json:"stages"`
+}
+
+// Complete method records the stage as completed.
+func (pointer *Checkpoint) Complete(stage string) *Checkpoint {
+	if pointer.Completed(stage) == false {
+		pointer.Stages = append(pointer.Stages, stage)
+	}
+	return pointer
+}
+
+// Completed method returns whether the stage was completed.
+func (pointer *Checkpoint) Completed(stage string) bool {
+	for _, s := range pointer.Stages {
+		if s == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// Resumes method returns whether the Checkpoint was stored by a build of the chart modified at the argument time,
+// restricted to the same categories. A Checkpoint of a build that has not fetched its chart resumes any chart.
+func (pointer *Checkpoint) Resumes(chart time.Time, categories []string) bool {
+	if strings.Join(pointer.Categories, ",") != strings.Join(categories, ",") {
+		return false
+	}
+	return pointer.Chart.IsZero() || pointer.Chart.Equal(chart)
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gellel/emojipedia/categories"
+	"github.com/gellel/emojipedia/category"
+	"github.com/gellel/emojipedia/cldr"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/keyword"
+	"github.com/gellel/emojipedia/keywords"
+	"github.com/gellel/emojipedia/lexicon"
+	"github.com/gellel/emojipedia/merge"
+	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/subcategories"
+	"github.com/gellel/emojipedia/subcategory"
+	"github.com/gellel/emojipedia/text"
+)
+
+// validateCLDR reports the stored emoji whose names differ from their English CLDR short names.
+// When preferred, the emoji are renamed to their CLDR names and the categories, subcategories and keywords
+// referencing them are rewritten. Names already held by another emoji are reported and left alone.
+func validateCLDR(prefer bool) {
+	e, err := emojipedia.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, EMOJIPEDIA, err), err)
+	}
+	annotations := &lexicon.Lexicon{}
+	for _, url := range cldr.Addresses("en") {
+		l, err := cldr.Open(url)
+		if err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, url, err), err)
+		}
+		l.Each(func(ID string, i interface{}) {
+			annotations.Add(ID, i)
+		})
+	}
+	mismatches := cldr.Validate(e, annotations)
+	fmt.Fprintln(writer, "ID\t|Name\t|CLDR")
+	for _, m := range mismatches {
+		fmt.Fprintln(writer, fmt.Sprintf("%s\t|%s\t|%s", m.ID, m.Name, m.CLDR))
+	}
+	writer.Flush()
+	fmt.Println(fmt.Sprintf("%v of %v emoji are not named as in CLDR", len(mismatches), e.Len()))
+	if prefer == false || len(mismatches) == 0 {
+		return
+	}
+	if err := store.Writable(); err != nil {
+		fail(fmt.Sprintf(errorBuildPackage, EMOJIPEDIA, err), err)
+	}
+	lock()
+	var (
+		failed  error
+		fetched = time.Now()
+		renamed = map[string]string{}
+	)
+	// Each emoji is written under its new name before its former file is removed, so a failed write loses nothing.
+	// The emoji renamed before a failure are still renamed in the packages referencing them.
+	for _, m := range mismatches {
+		if x, ok := e.Get(m.CLDR); ok && x.ID != m.ID {
+			fmt.Println(fmt.Sprintf("cannot rename \"%s\" to \"%s\"; the name is held by %s", m.Name, m.CLDR, x.ID))
+			continue
+		}
+		x := e.Fetch(m.ID)
+		former := x.Name
+		x.SetName(m.CLDR).Record("name", cldr.Name, fetched)
+		if failed = emoji.Write(x); failed == nil && store.ByID == false {
+			failed = emoji.Remove(former)
+		}
+		if failed != nil {
+			break
+		}
+		renamed[m.Name] = m.CLDR
+	}
+	if err := rename(renamed); err != nil && failed == nil {
+		failed = err
+	}
+	if failed != nil {
+		fail(fmt.Sprintf(errorBuildPackage, EMOJIPEDIA, failed), failed)
+	}
+	fmt.Println(fmt.Sprintf("renamed %v emoji to their CLDR names", len(renamed)))
+}
+
+// mergeCLDR merges the CLDR annotations of English, and of the --locale when one is set, into the stored emoji
+// by the configured precedence. The text-to-speech name of each locale is kept under emoji.Emoji.Names for alt text.
+func mergeCLDR() {
+	current, err := emojipedia.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, EMOJIPEDIA, err), err)
+	}
+	locales := []string{"en"}
+	if len(language) != 0 && language != "en" {
+		locales = append(locales, language)
+	}
+	var (
+		fetched    = time.Now()
+		supplement = emojipedia.New()
+	)
+	for _, locale := range locales {
+		for _, url := range cldr.Addresses(locale) {
+			annotations, err := cldr.Open(url)
+			if err != nil {
+				fail(fmt.Sprintf(errorCannotOpen, url, err), err)
+			}
+			annotations.Each(func(ID string, i interface{}) {
+				e, ok := current.Get(ID)
+				if ok == false {
+					return
+				}
+				candidate, ok := supplement.Get(ID)
+				if ok == false {
+					candidate = emoji.New()
+					candidate.ID, candidate.Names = e.ID, &lexicon.Lexicon{}
+					supplement.Add(candidate)
+				}
+				annotation := i.(*cldr.Annotation)
+				if len(annotation.Name) != 0 {
+					candidate.Names.Add(locale, annotation.Name)
+				}
+				if locale == "en" {
+					for _, keyword := range annotation.Keywords {
+						candidate.Keywords.Append(text.Normalize(keyword))
+					}
+				}
+			})
+		}
+	}
+	supplement.Each(func(_ string, e *emoji.Emoji) {
+		e.Stamp(cldr.Name, fetched)
+	})
+	merged := merge.All(precedence, map[string]*emojipedia.Emojipedia{merge.Unicode: current, merge.CLDR: supplement})
+	merged.Each(func(_ string, e *emoji.Emoji) {
+		if err := emoji.Write(e); err != nil {
+			fail(fmt.Sprintf(errorBuildPackage, EMOJIPEDIA, err), err)
+		}
+	})
+	fmt.Println(fmt.Sprintf("merged cldr annotations into %v emoji", supplement.Len()))
+}
+
+// rename rewrites the emoji names referenced by the stored categories, subcategories and keywords,
+// and returns the first error writing them. Packages that have not been built are skipped.
+func rename(renamed map[string]string) error {
+	replace := func(s *slice.Slice) bool {
+		changed := false
+		s.Each(func(i int, value interface{}) {
+			if name, ok := renamed[value.(string)]; ok {
+				s.Replace(i, name)
+				changed = true
+			}
+		})
+		return changed
+	}
+	if c, err := categories.Open(); err == nil {
+		var failed error
+		c.Each(func(x *category.Category) {
+			if failed == nil && replace(x.Emoji) {
+				failed = category.Write(x)
+			}
+		})
+		if failed != nil {
+			return failed
+		}
+	}
+	if s, err := subcategories.Open(); err == nil {
+		var failed error
+		s.Each(func(x *subcategory.Subcategory) {
+			if failed == nil && replace(x.Emoji) {
+				failed = subcategory.Write(x)
+			}
+		})
+		if failed != nil {
+			return failed
+		}
+	}
+	if k, err := keywords.Open(); err == nil {
+		var failed error
+		k.Each(func(key string, s *slice.Slice) {
+			if failed == nil && replace(s) {
+				failed = keyword.Write(key, s)
+			}
+		})
+		return failed
+	}
+	return nil
+}
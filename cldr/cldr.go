@@ -0,0 +1,102 @@
+package cldr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/lexicon"
+	"github.com/gellel/emojipedia/source"
+	"github.com/gellel/emojipedia/text"
+)
+
+const (
+	// Name is the source name CLDR is selected and recorded by.
+	Name = "cldr"
+	// URL is the CLDR annotations of a locale, naming and describing the emoji made of a single character.
+	URL = "https://raw.githubusercontent.com/unicode-org/cldr-json/main/cldr-json/cldr-annotations-full/annotations/%s/annotations.json"
+	// Derived is the CLDR derived annotations of a locale, naming the emoji sequences such as skin tone variants.
+	Derived = "https://raw.githubusercontent.com/unicode-org/cldr-json/main/cldr-json/cldr-annotations-derived-full/annotationsDerived/%s/annotations.json"
+)
+
+// Addresses returns the URLs of both the CLDR annotations and derived annotations of a locale.
+func Addresses(locale string) []string {
+	return []string{fmt.Sprintf(URL, locale), fmt.Sprintf(Derived, locale)}
+}
+
+// Open attempts to read a CLDR annotations file from a local file or, if the argument is a URL, over HTTP.
+func Open(path string) (*lexicon.Lexicon, error) {
+	reader, err := client.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return Parse(reader)
+}
+
+// Parse reads CLDR annotations, or derived annotations, into a lexicon.Lexicon of Annotation pointers keyed by emoji ID.
+func Parse(reader io.Reader) (*lexicon.Lexicon, error) {
+	document := map[string]struct {
+		Annotations map[string]struct {
+			Default []string `json:"default"`
+			TTS     []string `json:"tts"`
+		} `json:"annotations"`
+	}{}
+	err := json.NewDecoder(reader).Decode(&document)
+	if err != nil {
+		return nil, err
+	}
+	lexicon := &lexicon.Lexicon{}
+	for _, section := range document {
+		for character, annotation := range section.Annotations {
+			a := &Annotation{Keywords: annotation.Default}
+			if len(annotation.TTS) != 0 {
+				a.Name = annotation.TTS[0]
+			}
+			codes := []string{}
+			for _, r := range character {
+				codes = append(codes, fmt.Sprintf("%x", r))
+			}
+			lexicon.Add(source.ID(strings.Join(codes, "-")), a)
+		}
+	}
+	return lexicon, nil
+}
+
+// Validate compares the names of the emoji with their CLDR short names, returning a Mismatch for every emoji
+// whose name differs, in unicode.org order. Emoji CLDR does not annotate are not reported.
+func Validate(emojipedia *emojipedia.Emojipedia, annotations *lexicon.Lexicon) []*Mismatch {
+	mismatches := []*Mismatch{}
+	emojipedia.Each(func(ID string, e *emoji.Emoji) {
+		i, ok := annotations.Get(ID)
+		if ok == false || len(i.(*Annotation).Name) == 0 {
+			return
+		}
+		if name := text.Normalize(i.(*Annotation).Name); name != e.Name {
+			mismatches = append(mismatches, &Mismatch{CLDR: name, ID: ID, Name: e.Name, Number: e.Number})
+		}
+	})
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].Number < mismatches[j].Number
+	})
+	return mismatches
+}
+
+// Annotation is the CLDR short name and keywords of an emoji in one locale.
+type Annotation struct {
+	Keywords []string
+	Name     string
+}
+
+// Mismatch is an emoji whose name differs from its CLDR short name.
+type Mismatch struct {
+	CLDR   string
+	ID     string
+	Name   string
+	Number int
+}
@@ -0,0 +1,346 @@
+package client
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrDisallowed is returned for requests the robots.txt of a polite host does not allow.
+	ErrDisallowed = errors.New("client: disallowed by robots.txt")
+)
+
+var (
+	// CA is the path of a PEM bundle of certificate authorities trusted in addition to the system pool,
+	// such as the root of a corporate TLS-intercepting proxy.
+	CA string
+	// Certificate and Key are the paths of a PEM client certificate and its private key presented to hosts requiring one.
+	Certificate, Key string
+	// Insecure skips verification of the certificates presented by hosts. Only for diagnosing TLS problems.
+	Insecure bool
+	// Proxy is the URL of the proxy every request is sent through.
+	// When it is empty the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored.
+	Proxy string
+)
+
+var (
+	// Concurrency is the number of requests that may be in flight to one host at a time.
+	Concurrency = 2
+	// Delay is the minimum time between the start of two requests to one host.
+	Delay time.Duration
+	// Header holds extra headers sent with every request.
+	Header = http.Header{}
+	// HTTP is the underlying client requests are sent with.
+	HTTP = &http.Client{Timeout: 5 * time.Minute}
+	// Polite lists the hosts whose robots.txt is fetched and obeyed, including its Crawl-delay.
+	Polite = []string{"emojipedia.org"}
+	// UserAgent identifies the program to the hosts it requests.
+	UserAgent = "emojipedia (+https://github.com/gellel/emojipedia)"
+)
+
+var (
+	hosts = map[string]*host{}
+	mutex sync.Mutex
+)
+
+// Configure applies the Proxy and TLS settings to the transport of the HTTP client. Call it after changing them.
+func Configure() error {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if len(Proxy) != 0 {
+		u, err := url.Parse(Proxy)
+		if err != nil || len(u.Host) == 0 {
+			return fmt.Errorf("client: proxy \"%s\" is not a url", Proxy)
+		}
+		transport.Proxy = http.ProxyURL(u)
+	}
+	config := &tls.Config{InsecureSkipVerify: Insecure}
+	if len(CA) != 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		content, err := ioutil.ReadFile(CA)
+		if err != nil {
+			return err
+		}
+		if pool.AppendCertsFromPEM(content) == false {
+			return fmt.Errorf("client: %s holds no PEM certificates", CA)
+		}
+		config.RootCAs = pool
+	}
+	if len(Certificate) != 0 || len(Key) != 0 {
+		certificate, err := tls.LoadX509KeyPair(Certificate, Key)
+		if err != nil {
+			return err
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	transport.TLSClientConfig = config
+	HTTP.Transport = transport
+	return nil
+}
+
+// Get requests the argument URL with the shared configuration.
+func Get(url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return Do(req)
+}
+
+// Fetch requests the argument URL with the shared configuration, returning any response other than 200 OK as an error.
+func Fetch(url string) (*http.Response, error) {
+	resp, err := Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+	return resp, nil
+}
+
+// Open returns a reader of the argument path: the body of the response to it if it is an http or https URL,
+// otherwise the local file. The reader must be closed.
+func Open(path string) (io.ReadCloser, error) {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		resp, err := Fetch(path)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Body, nil
+	}
+	return os.Open(path)
+}
+
+// Do sends the request with the configured User-Agent and headers. Requests wait for a free slot of their host's Concurrency
+// and for its Delay to pass, and requests to a Polite host must be allowed by its robots.txt.
+// The slot is held until the body of a successful response is closed; other responses are returned with their body
+// already closed and empty.
+func Do(req *http.Request) (*http.Response, error) {
+	if len(req.Header.Get("User-Agent")) == 0 {
+		req.Header.Set("User-Agent", UserAgent)
+	}
+	for key, values := range Header {
+		req.Header[key] = values
+	}
+	h := lookup(req.URL.Scheme, req.URL.Hostname())
+	if h.allowed(req.URL.Path) == false {
+		return nil, ErrDisallowed
+	}
+	h.acquire()
+	resp, err := HTTP.Do(req)
+	if err != nil {
+		h.release()
+		return nil, err
+	}
+	// The body of an unsuccessful response is closed at once, so callers that only check the status cannot hold the slot.
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		h.release()
+		resp.Body = http.NoBody
+		return resp, nil
+	}
+	resp.Body = &body{ReadCloser: resp.Body, release: h.release}
+	return resp, nil
+}
+
+// Reset forgets the robots.txt and request times of every host, so changes to the configuration apply to the next request.
+func Reset() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	hosts = map[string]*host{}
+}
+
+// lookup returns the state of the named host, creating it if required.
+func lookup(scheme, name string) *host {
+	mutex.Lock()
+	defer mutex.Unlock()
+	name = strings.ToLower(name)
+	if h, ok := hosts[name]; ok {
+		return h
+	}
+	n := Concurrency
+	if n < 1 {
+		n = 1
+	}
+	h := &host{delay: Delay, name: name, scheme: scheme, slots: make(chan struct{}, n)}
+	for _, polite := range Polite {
+		if name == polite || strings.HasSuffix(name, "."+polite) {
+			h.polite = true
+		}
+	}
+	hosts[name] = h
+	return h
+}
+
+// body releases the host slot of a response once it is closed.
+type body struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// Close closes the response body and releases its host slot.
+func (pointer *body) Close() error {
+	err := pointer.ReadCloser.Close()
+	pointer.once.Do(pointer.release)
+	return err
+}
+
+// host is the request state of one host.
+type host struct {
+	delay  time.Duration
+	last   time.Time
+	mutex  sync.Mutex
+	name   string
+	once   sync.Once
+	polite bool
+	robots *robots
+	scheme string
+	slots  chan struct{}
+}
+
+// acquire waits for a free slot and for the host's delay to pass since its previous request.
+func (pointer *host) acquire() {
+	pointer.slots <- struct{}{}
+	pointer.mutex.Lock()
+	defer pointer.mutex.Unlock()
+	if wait := pointer.delay - time.Since(pointer.last); wait > 0 {
+		time.Sleep(wait)
+	}
+	pointer.last = time.Now()
+}
+
+// allowed checks the path against the robots.txt of a polite host, fetching it on first use.
+// A robots.txt that cannot be fetched allows everything.
+func (pointer *host) allowed(path string) bool {
+	if pointer.polite == false {
+		return true
+	}
+	pointer.once.Do(func() {
+		req, err := http.NewRequest(http.MethodGet, pointer.scheme+"://"+pointer.name+"/robots.txt", nil)
+		if err != nil {
+			return
+		}
+		req.Header.Set("User-Agent", UserAgent)
+		resp, err := HTTP.Do(req)
+		if err != nil {
+			return
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			return
+		}
+		pointer.robots = parse(resp.Body, UserAgent)
+		pointer.mutex.Lock()
+		if pointer.robots.delay > pointer.delay {
+			pointer.delay = pointer.robots.delay
+		}
+		pointer.mutex.Unlock()
+	})
+	return pointer.robots == nil || pointer.robots.allowed(path)
+}
+
+// release frees a slot taken by acquire.
+func (pointer *host) release() {
+	<-pointer.slots
+}
+
+// parse reads the robots.txt rules applying to the user agent: those of a group naming its product token, otherwise those for "*".
+func parse(reader io.Reader, agent string) *robots {
+	var (
+		groups  = map[string]*robots{}
+		current = []*robots{}
+		product = strings.ToLower(strings.Fields(agent + " x")[0])
+		scanner = bufio.NewScanner(reader)
+		started = false
+	)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i != -1 {
+			line = line[:i]
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key, value := strings.ToLower(strings.TrimSpace(parts[0])), strings.TrimSpace(parts[1])
+		switch key {
+		case "user-agent":
+			if started {
+				current, started = []*robots{}, false
+			}
+			value = strings.ToLower(value)
+			if groups[value] == nil {
+				groups[value] = &robots{}
+			}
+			current = append(current, groups[value])
+		case "allow", "disallow":
+			started = true
+			for _, r := range current {
+				r.rules = append(r.rules, rule{allow: key == "allow", path: value})
+			}
+		case "crawl-delay":
+			started = true
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+				for _, r := range current {
+					r.delay = time.Duration(seconds * float64(time.Second))
+				}
+			}
+		}
+	}
+	for name, r := range groups {
+		if name != "*" && strings.Contains(product, name) {
+			return r
+		}
+	}
+	if r, ok := groups["*"]; ok {
+		return r
+	}
+	return &robots{}
+}
+
+// robots holds the robots.txt rules that apply to the program.
+type robots struct {
+	delay time.Duration
+	rules []rule
+}
+
+// allowed checks the path against the longest matching rule. Allow rules win ties and unmatched paths are allowed.
+func (pointer *robots) allowed(path string) bool {
+	var (
+		allow  = true
+		length = -1
+	)
+	for _, r := range pointer.rules {
+		if len(r.path) == 0 || strings.HasPrefix(path, r.path) == false {
+			continue
+		}
+		if len(r.path) > length || (len(r.path) == length && r.allow) {
+			allow, length = r.allow, len(r.path)
+		}
+	}
+	return allow
+}
+
+// rule is an Allow or Disallow line of a robots.txt.
+type rule struct {
+	allow bool
+	path  string
+}
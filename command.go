@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/pattern"
+)
+
+// commands are the verbose names of the commands of the program.
+var commands = []string{
+	ALT,
+	ANNOTATE,
+	APPLY,
+	BENCH,
+	BUILD,
+	CATEGORIES,
+	CATEGORY,
+	CHECKUPSTREAM,
+	DAEMON,
+	DIFF,
+	DISCORD,
+	DOCTOR,
+	EMOJI,
+	EMOJIPEDIA,
+	FAV,
+	FETCHDATASET,
+	FLAG,
+	GEN,
+	GREP,
+	HELP,
+	HISTORY,
+	IMAGES,
+	KEYWORDS,
+	NEW,
+	PACK,
+	PROFILES,
+	RENDER,
+	REPAIR,
+	RESTORELAST,
+	SERVE,
+	SHELL,
+	STATUS,
+	SUBCATEGORIES,
+	SUBCATEGORY,
+	TAG,
+	TOPICS,
+	TREE,
+	UNICODE,
+	WATCH}
+
+// aliases are the alternative names commands can be given by.
+var aliases = map[string]string{
+	"ENCYCLOPEDIA": EMOJIPEDIA,
+	"LS":           LIST,
+	"RM":           REMOVE,
+	"SEARCH":       GREP}
+
+// command returns the command the first argument names, upper-cased, for a dispatcher of the argument verbose commands.
+// Aliases are replaced by the command they stand for and the unambiguous start of a command by the command,
+// so "emojipedia key b" builds the keywords. The start of several commands fails naming them.
+// Short commands, flags and anything else are returned as given for the dispatcher to match or reject.
+func command(arguments *arguments.Arguments, commands ...string) string {
+	name := strings.ToUpper(arguments.Get(0))
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+	if len(name) == 0 || strings.HasPrefix(name, "-") {
+		return name
+	}
+	candidates := []string{}
+	for _, c := range commands {
+		if c == name {
+			return name
+		}
+		if strings.HasPrefix(c, name) {
+			candidates = append(candidates, strings.ToLower(c))
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return name
+	case 1:
+		return strings.ToUpper(candidates[0])
+	}
+	failWith(usage, fmt.Sprintf(errorAmbiguousCommand, strings.ToLower(name), strings.Join(candidates, ", ")), ErrAmbiguousCommand)
+	return name
+}
+
+// patternsOf compiles the arguments into the globs, or with --regex the regular expressions, that keys and remove
+// select names with. Flags are skipped, and no arguments select every name.
+func patternsOf(arguments *arguments.Arguments) []*pattern.Pattern {
+	var (
+		_, regex    = arguments.Flag("regex")
+		expressions = []string{}
+	)
+	arguments.Each(func(_ int, argument string) {
+		if strings.HasPrefix(argument, "--") == false {
+			expressions = append(expressions, argument)
+		}
+	})
+	patterns, err := pattern.CompileAll(expressions, regex)
+	if err != nil {
+		failWith(usage, fmt.Sprintf(errorPattern, strings.Join(expressions, " "), err), err)
+	}
+	return patterns
+}
@@ -0,0 +1,13 @@
+package conflict
+
+// Strategy decides what the Merge method of a collection does with a key held by both collections.
+type Strategy string
+
+const (
+	// Keep keeps the value already held by the collection being merged into.
+	Keep Strategy = "keep"
+	// Replace replaces the held value with a copy of the merged value.
+	Replace Strategy = "replace"
+	// Union keeps the held value, filling its empty fields from the merged value and combining their lists.
+	Union Strategy = "union"
+)
@@ -0,0 +1,243 @@
+package confusable
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gellel/emojipedia/emojidata"
+	"github.com/gellel/emojipedia/flag"
+	"github.com/gellel/emojipedia/keycap"
+	"github.com/gellel/emojipedia/segment"
+	"github.com/gellel/emojipedia/store"
+)
+
+const (
+	// Path is the route identifiers are checked from.
+	Path string = "/identifier/check"
+)
+
+const (
+	// Emoji is the kind of a Finding holding an emoji, such as "😀" or "👩‍💻".
+	Emoji string = "emoji"
+	// Flag is the kind of a Finding holding a regional indicator or subdivision flag.
+	Flag string = "flag"
+	// Invisible is the kind of a Finding holding characters that render as nothing, such as a variation selector
+	// after a letter or a stray zero width joiner.
+	Invisible string = "invisible"
+	// Keycap is the kind of a Finding holding a keycap sequence, which reads as the digit or symbol it encloses.
+	Keycap string = "keycap"
+	// Lookalike is the kind of a Finding holding an emoji that reads as ASCII text, such as "🅰" or "❗".
+	Lookalike string = "lookalike"
+	// Pictographic is the kind of a Finding holding a character reserved for future emoji.
+	Pictographic string = "pictographic"
+)
+
+var (
+	// Lookalikes are the emoji that read as ASCII text, by the text they read as.
+	Lookalikes = map[rune]string{
+		'©':          "(c)",
+		'®':          "(r)",
+		'‼':          "!!",
+		'⁉':          "!?",
+		'™':          "tm",
+		'ℹ':          "i",
+		'Ⓜ':          "M",
+		'❌':          "x",
+		'❓':          "?",
+		'❔':          "?",
+		'❕':          "!",
+		'❗':          "!",
+		'➕':          "+",
+		'➖':          "-",
+		'➗':          "/",
+		'✖':          "x",
+		'➰':          "~",
+		'〰':          "~",
+		'⭕':          "o",
+		'\U0001F170': "A",
+		'\U0001F171': "B",
+		'\U0001F17E': "O",
+		'\U0001F17F': "P",
+		'\U0001F18E': "AB",
+		'\U0001F191': "CL",
+		'\U0001F192': "COOL",
+		'\U0001F193': "FREE",
+		'\U0001F194': "ID",
+		'\U0001F195': "NEW",
+		'\U0001F196': "NG",
+		'\U0001F197': "OK",
+		'\U0001F198': "SOS",
+		'\U0001F199': "UP!",
+		'\U0001F19A': "VS",
+		'\U0001F51F': "10",
+		'\U0001F4AF': "100"}
+)
+
+// Finding is an emoji or emoji-like cluster of an identifier along with the ASCII text it can be replaced by.
+type Finding struct {
+	Start       int    `json:"start"`       // Start is the byte offset of the cluster in the identifier.
+	End         int    `json:"end"`         // End is the byte offset following the cluster in the identifier.
+	Kind        string `json:"kind"`        // Kind classifies the cluster, such as Emoji or Keycap.
+	Replacement string `json:"replacement"` // Replacement is the ASCII text suggested in place of the cluster, which may be empty.
+	Text        string `json:"text"`        // Text is the cluster itself.
+}
+
+// Report is the outcome of checking an identifier: its Findings and the identifier with each replaced.
+type Report struct {
+	Findings   []Finding `json:"findings"`
+	Identifier string    `json:"identifier"`
+	Safe       bool      `json:"safe"`
+	Suggestion string    `json:"suggestion"`
+}
+
+// Check returns a Finding for each emoji, emoji-like symbol and invisible emoji component in the identifier, in order.
+// Whether a character is an emoji is answered by the stored emoji properties, and emoji are replaced by their
+// formal Unicode names. Check returns the error of emojidata.Available rather than miss every emoji when the
+// emoji properties are not built.
+func Check(identifier string) ([]Finding, error) {
+	if err := emojidata.Available(); err != nil {
+		return nil, err
+	}
+	findings := []Finding{}
+	for _, cluster := range segment.Segment(identifier) {
+		if kind, replacement, ok := classify(cluster.Text); ok {
+			findings = append(findings, Finding{
+				End:         cluster.End,
+				Kind:        kind,
+				Replacement: replacement,
+				Start:       cluster.Start,
+				Text:        cluster.Text})
+		}
+	}
+	return findings, nil
+}
+
+// Handler answers GET requests such as "/identifier/check?identifier=cool🅰dam" with the Report of the identifier.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		report, err := Of(r.URL.Query().Get("identifier"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		content, err := store.Marshal(report)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content)
+	})
+}
+
+// IsSafe checks that the identifier holds no emoji, emoji-like symbol or invisible emoji component.
+// No identifier is safe while the emoji properties are not built.
+func IsSafe(identifier string) bool {
+	findings, err := Check(identifier)
+	return err == nil && len(findings) == 0
+}
+
+// Of returns the Report of the identifier, or the error of Check.
+func Of(identifier string) (*Report, error) {
+	findings, err := Check(identifier)
+	if err != nil {
+		return nil, err
+	}
+	return &Report{
+		Findings:   findings,
+		Identifier: identifier,
+		Safe:       len(findings) == 0,
+		Suggestion: replace(identifier, findings)}, nil
+}
+
+// Sanitize returns the identifier with each Finding replaced by its suggested ASCII text, or the error of Check.
+func Sanitize(identifier string) (string, error) {
+	findings, err := Check(identifier)
+	if err != nil {
+		return "", err
+	}
+	return replace(identifier, findings), nil
+}
+
+// classify returns the kind of an emoji or emoji-like cluster and its replacement. Clusters of plain text return false.
+func classify(cluster string) (string, string, bool) {
+	if base, ok := keycap.BaseOf(cluster); ok {
+		return Keycap, string(base), true
+	}
+	if iso, ok := flag.CountryOf(cluster); ok {
+		return Flag, strings.ToLower(strings.Replace(iso, "-", "_", -1)), true
+	}
+	if runes := []rune(emojidata.Normalize(cluster)); len(runes) == 1 {
+		if text, ok := Lookalikes[runes[0]]; ok {
+			return Lookalike, text, true
+		}
+		// A regional indicator without its pair renders as the letter it stands for.
+		if runes[0] >= '\U0001F1E6' && runes[0] <= '\U0001F1FF' {
+			return Lookalike, string('A' + runes[0] - '\U0001F1E6'), true
+		}
+	}
+	var (
+		names    = []string{}
+		emoji    bool
+		visible  = []rune{}
+		symbolic bool
+	)
+	for _, r := range cluster {
+		switch {
+		case invisible(r):
+			continue
+		case r > 0x7F && emojidata.IsEmoji(r):
+			emoji = true
+		case emojidata.IsExtendedPictographic(r):
+			symbolic = true
+		default:
+			visible = append(visible, r)
+			continue
+		}
+		if name := emojidata.FormalName(r); len(name) != 0 {
+			names = append(names, strings.ToLower(strings.NewReplacer(" ", "_", "-", "_").Replace(name)))
+		}
+	}
+	switch {
+	case emoji:
+		return Emoji, strings.Join(names, "_"), true
+	case symbolic:
+		return Pictographic, strings.Join(names, "_"), true
+	case len(string(visible)) != len(cluster):
+		return Invisible, string(visible), true
+	}
+	return "", "", false
+}
+
+// invisible checks whether the rune is an emoji component that renders as nothing, or only alters its neighbours:
+// variation selectors, the zero width joiner, skin tone modifiers, the enclosing keycap and tags.
+func invisible(r rune) bool {
+	switch {
+	case r == emojidata.TextSelector, r == emojidata.EmojiSelector, r == emojidata.Keycap, r == '\u200D':
+		return true
+	case r >= '\U0001F3FB' && r <= '\U0001F3FF':
+		return true
+	case r >= '\U000E0020' && r <= flag.Cancel:
+		return true
+	}
+	return false
+}
+
+// replace returns the identifier with each of the findings replaced.
+func replace(identifier string, findings []Finding) string {
+	var (
+		b     strings.Builder
+		start int
+	)
+	for _, finding := range findings {
+		b.WriteString(identifier[start:finding.Start])
+		b.WriteString(finding.Replacement)
+		start = finding.End
+	}
+	b.WriteString(identifier[start:])
+	return b.String()
+}
@@ -1,38 +1,98 @@
 package main
 
 const (
+	ALFRED        string = "ALFRED"
+	ALT           string = "ALT"
 	ANCHOR        string = "ANCHOR"
+	ANKI          string = "ANKI"
+	ANNOTATE      string = "ANNOTATE"
+	APPLY         string = "APPLY"
+	ATOM          string = "ATOM"
+	BENCH         string = "BENCH"
+	BLOCK         string = "BLOCK"
 	CATEGORIES    string = "CATEGORIES"
 	CATEGORY      string = "CATEGORY"
+	CHECKUPSTREAM string = "CHECK-UPSTREAM"
 	CODES         string = "CODES"
 	DESCRIPTION   string = "DESCRIPTION"
+	DAEMON        string = "DAEMON"
+	DIFF          string = "DIFF"
+	CLDR          string = "CLDR"
+	DISCORD       string = "DISCORD"
+	DOCTOR        string = "DOCTOR"
+	DOT           string = "DOT"
 	EMOJIPEDIA    string = "EMOJIPEDIA"
 	EMOJI         string = "EMOJI"
+	FAV           string = "FAV"
+	FETCHDATASET  string = "FETCH-DATASET"
+	FLAG          string = "FLAG"
+	FORMAL        string = "FORMAL"
+	GEMOJI        string = "GEMOJI"
+	HISTORY       string = "HISTORY"
+	EMOJIDATA     string = "EMOJIDATA"
+	ESPANSO       string = "ESPANSO"
+	ID            string = "ID"
+	JOYPIXELS     string = "JOYPIXELS"
+	JSON          string = "JSON"
 	IMAGE         string = "IMAGE"
+	IMAGES        string = "IMAGES"
 	HREF          string = "HREF"
 	KEYWORDS      string = "KEYWORDS"
+	NEW           string = "NEW"
 	NUMBER        string = "NUMBER"
+	OPENMOJI      string = "OPENMOJI"
+	PACK          string = "PACK"
+	PROVENANCE    string = "PROVENANCE"
+	RAYCAST       string = "RAYCAST"
+	RSS           string = "RSS"
+	RELATED       string = "RELATED"
+	RENDER        string = "RENDER"
+	REPAIR        string = "REPAIR"
+	RESTORELAST   string = "RESTORE-LAST"
+	SENTIMENT     string = "SENTIMENT"
+	SCHEMA        string = "SCHEMA"
+	SERVE         string = "SERVE"
+	SHELL         string = "SHELL"
+	STATUS        string = "STATUS"
+	STREAM        string = "STREAM"
 	SUBCATEGORIES string = "SUBCATEGORIES"
 	SUBCATEGORY   string = "SUBCATEGORY"
+	TAG           string = "TAG"
+	TOPICS        string = "TOPICS"
 	UNICODE       string = "UNICODE"
+	VALIDATE      string = "VALIDATE"
+	VSCODE        string = "VSCODE"
+	WATCH         string = "WATCH"
 )
 
 const (
-	A string = "-A"
+	A   string = "-A"
+	ADD string = "ADD"
+	ALL string = "ALL"
+	AK  string = A + "K"
+	AN  string = A + "N"
+	AP  string = A + "P"
+	AT  string = A + "T"
 )
 
 const (
 	B     string = "-B"
+	BB    string = B + "B"
 	BUILD string = "BUILD"
 )
 
 const (
-	C  string = "-C"
-	CC string = C + "C"
+	C     string = "-C"
+	CC    string = C + "C"
+	CLEAR string = "CLEAR"
+	CU    string = C + "U"
 )
 
 const (
-	D string = "-D"
+	D  string = "-D"
+	DC string = D + "C"
+	DM string = D + "M"
+	DR string = D + "R"
 )
 
 const (
@@ -41,22 +101,35 @@ const (
 )
 
 const (
-	G   string = "-G"
-	GET string = "GET"
+	F  string = "-F"
+	FD string = F + "D"
+	FV string = F + "V"
+)
+
+const (
+	G    string = "-G"
+	GC   string = "GC"
+	GEN  string = "GEN"
+	GET  string = "GET"
+	GR   string = G + "R"
+	GREP string = "GREP"
 )
 
 const (
 	H    string = "-H"
 	HELP string = "HELP"
+	HI   string = H + "I"
 )
 
 const (
-	I string = "-I"
+	I  string = "-I"
+	IM string = I + "M"
 )
 
 const (
-	K    string = "-K"
-	KEYS string = "KEYS"
+	K      string = "-K"
+	KEYGEN string = "KEYGEN"
+	KEYS   string = "KEYS"
 )
 
 const (
@@ -65,45 +138,106 @@ const (
 )
 
 const (
-	N string = "-N"
+	N  string = "-N"
+	NW string = N + "W"
 )
 
 const (
 	P        string = "-P"
+	PK       string = P + "K"
+	PP       string = P + "P"
 	POSITION string = "POSITION"
+	PREFER   string = "PREFER"
+	PROFILES string = "PROFILES"
 )
 
 const (
-	R      string = "-R"
-	REMOVE string = "REMOVE"
+	R        string = "-R"
+	REGISTER string = "REGISTER"
+	REMOVE   string = "REMOVE"
+	RL       string = R + "L"
+	RN       string = R + "N"
+	RP       string = R + "P"
+	RS       string = R + "S"
 )
 
 const (
 	S  string = "-S"
+	SC string = S + "C"
+	SH string = S + "H"
 	SS string = S + "S"
+	ST string = S + "T"
+	SV string = S + "V"
 )
 
 const (
 	T     string = "-T"
 	TABLE string = "TABLE"
+	TG    string = T + "G"
+	TP    string = T + "P"
+	TREE  string = "TREE"
 )
 
 const (
 	U string = "-U"
 )
 
+const (
+	V string = "-V"
+)
+
+const (
+	W string = "-W"
+)
+
 const (
 	param string = "  [%s %s]\t%s"
 )
 
+const (
+	altDescription string = "replace emoji read from stdin with screen reader friendly text"
+)
+
+const (
+	annotateDescription string = "follow each emoji read from stdin with its name [template]"
+)
+
+const (
+	applyDescription string = "apply the json patches of \"diff --patch\" to the stored emoji <file|->"
+)
+
+const (
+	benchDescription string = "benchmark parsing, storage and lookups against the local dataset [keyword]"
+)
+
+const (
+	buildDescription string = "fetch and build every package, resuming an interrupted build [--restart]"
+)
+
 const (
 	categoriesDescription string = "browse categorical insights"
 )
 
+const (
+	checkUpstreamDescription string = "report emoji added, changed or removed on unicode.org since the last build"
+)
+
 const (
 	categoryDescription string = "access a specific category"
 )
 
+const (
+	diffDescription string = "compare the emoji of two profiles <profile> [profile] [--patch]"
+)
+
+const (
+	discordDescription string = "register the discord bot's /emoji command"
+)
+
+const (
+	doctorDescription string = "diagnose storage, datasets, configuration and network access"
+)
+
 const (
 	emojiDescription string = "access a specific unicode emoji character"
 )
@@ -112,10 +246,99 @@ const (
 	emojipediaDescription string = "explore the emoji catalogue"
 )
 
+const (
+	favDescription string = "add, remove and list favorite emoji, ranked first by search"
+)
+
+const (
+	fetchDatasetDescription string = "download and install a published dataset pack <url|file|version> [--verify=key.pub]"
+)
+
+const (
+	flagDescription string = "convert country codes to flag emoji and back"
+)
+
+const (
+	genDescription string = "generate files describing the dataset"
+)
+
+const (
+	packDescription string = "write the dataset to a versioned, checksummed tarball [file] [--version=15.1] [--sign=key] [keygen name]"
+)
+
+const (
+	profilesDescription string = "list the stored dataset profiles"
+)
+
+const (
+	grepDescription string = "print lines containing the named emoji <name|keyword|emoji> [files...]"
+)
+
+const (
+	helpDescription string = "list every command with the flags it reads and the packages it needs [command...] [--as=json]"
+)
+
+const (
+	historyDescription string = "list or clear the emoji used recently, ranked first by search"
+)
+
+const (
+	imagesDescription string = "list the stored emoji artwork or remove what is no longer referenced"
+)
+
 const (
 	keywordsDescription string = "see emojis classified by keywords"
 )
 
+const (
+	tagDescription string = "add, remove and list your own tags on emoji, matched by search"
+)
+
+const (
+	treeDescription string = "show the category, subcategory and emoji hierarchy [depth] [categories...]"
+)
+
+const (
+	topicsDescription string = "cluster emoji by shared keywords into named topics such as celebration or weather"
+)
+
+const (
+	unicodeDescription string = "fetch the unicode.org emoji chart and emoji properties"
+)
+
+const (
+	watchDescription string = "refetch and rebuild every package on an interval [24h]"
+)
+
+const (
+	renderDescription string = "render the artwork of an emoji to png files <emoji> [sizes...] [--art=openmoji] [--out=folder]"
+)
+
+const (
+	repairDescription string = "regenerate corrupt or missing emoji, migrate renamed categories and rebuild indexes [-d description]"
+)
+
+const (
+	restoreDescription string = "move the files removed most recently back out of the trash"
+)
+
+const (
+	daemonDescription string = "keep the dataset in memory and answer get commands over a socket [path]"
+)
+
+const (
+	serveDescription string = "serve the slack and discord command endpoints and the emoji escapes [address]"
+	shellDescription string = "run commands at a prompt, keeping the locale and profile between them"
+)
+
+const (
+	newDescription string = "list the emoji added since an emoji version or date [--since=15.0|2023-01-01]"
+)
+
+const (
+	statusDescription string = "show the presence, size, age, source version and checksum of every dataset"
+)
+
 const (
 	subcategoriesDescription string = "browse subcategorical insights"
 )
@@ -125,21 +348,65 @@ const (
 )
 
 const (
+	exitOK      int = 0
+	exitFailed  int = 1
+	exitMissing int = 2
+	exitNetwork int = 3
+	exitStorage int = 4
+	exitUsage   int = 1
+)
+
+const (
+	errorApply         string = "cannot apply the patch of \"%s\"; encountered error \"%s\""
+	errorAs            string = "cannot print emoji as \"%s\"; expected json or ndjson"
+	errorBuildPackage  string = "cannot build \"%s\"; encountered error \"%s\""
+	errorCategory      string = "cannot build the categories \"%s\"; the unicode chart has no such category"
 	errorCannotFind    string = "cannot find dependency \"%s\". content either missing or not built"
 	errorCannotOpen    string = "cannot open \"%s\"; encountered unexpected error \"%s\""
+	errorCannotWrite   string = "cannot write \"%s\"; encountered error \"%s\""
+	errorFetchDataset  string = "cannot fetch dataset \"%s\"; encountered error \"%s\""
+	errorIntegrity     string = "built \"%s\" but found %v unresolved references"
+	errorPage          string = "cannot select the rows \"%s\"; give --limit=n, --offset=n or --range=a..b counting rows from 1"
+	errorPattern       string = "cannot match \"%s\"; encountered error \"%s\""
+	errorPinned        string = "cannot build \"%s\"; the stored unicode chart is version \"%s\" but \"%s\" was asked for. rebuild the unicode package with --unicode-version"
+	errorRefresh       string = "refresh failed; keeping the current dataset. encountered error \"%s\""
+	errorRenderSize    string = "cannot render at size \"%s\"; sizes are between %v and %v pixels"
+	errorHistory       string = "cannot record the emoji used; %s"
+	errorRejected      string = "rejected invalid row; %s"
 	errorRemovePackage string = "cannot remove \"%s\"; encountered error \"%s\""
+	errorSince         string = "cannot list emoji added since \"%s\"; give an emoji version such as 15.1 or a date such as 2023-09-12"
+	errorSkipped       string = "skipped \"%s\"; encountered error \"%s\""
+)
+
+const (
+	errorWebhook string = "cannot notify webhook; encountered error \"%s\""
 )
 
 const (
 	statusBuildPackage  string = "attempting to build \"%s\" package"
-	statusRemovePackage string = "attempting to remove \"%s\" package; deleting core packages can affect building!"
+	statusResume        string = "resuming build after \"%s\" from row %v; pass --restart to start over"
+	statusRefresh       string = "%s refreshing dataset \"%s\" from unicode.org"
+	statusVerified      string = "verified signature \"%s\""
+	statusServe         string = "serving emojipedia on \"%s\""
+	statusTiming        string = "%s took %s"
+	statusUnchecked     string = "pack has no checksum \"%s\"; installing it checked against its own contents only"
+	statusRemovePackage string = "attempting to remove \"%s\" package; removed packages are kept in the trash"
 )
 
 const (
+	successPrune         string = "success! program has removed %v unreferenced files"
+	successApply         string = "success! patched %v and removed %v emoji from \"%s\""
 	successBuildPackage  string = "success! program has built package \"%s\""
-	successRemovePackage string = "success! program has removed \"%s\"!"
+	successFetchDataset  string = "success! installed dataset \"%s\" (%v files) into \"%s\""
+	successPack          string = "success! packed %v files into \"%s\""
+	successTopics        string = "success! clustered %v topics out of %v emoji"
+	successRefresh       string = "success! dataset refreshed; next refresh at %s"
+	successRemoveEmoji   string = "success! program has removed %v emoji and their references! restore the emoji files with \"emojipedia restore-last\""
+	successRemovePackage string = "success! program has removed \"%s\"! undo with \"emojipedia restore-last\""
 )
 
 const (
-	errorChoiceNotFound string = "Uh-oh. Cannot find content \"%s\" in choice \"$ emojipedia [%s|%s] <choice>\". Please check input and try again."
+	errorAmbiguousCommand string = "ambiguous command \"%s\"; did you mean one of %s?"
+	errorChoiceNotFound   string = "Uh-oh. Cannot find content \"%s\" in choice \"$ emojipedia [%s|%s] <choice>\". Please check input and try again."
+	errorUnknownCommand   string = "unknown command \"%s\"; see the options above"
 )
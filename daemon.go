@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/daemon"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+)
+
+// ask answers a query from the daemon when one is listening, returning false so the caller reads the
+// dataset itself when none is.
+func ask(command string, args ...string) ([]*emoji.Emoji, bool) {
+	response, err := daemon.Ask(daemon.Path(), &daemon.Request{
+		Args:    args,
+		Command: command,
+		Locale:  language})
+	if err != nil {
+		return nil, false
+	}
+	return response.Emoji, true
+}
+
+// lookup is what commands looking emoji up by name or ID need of an Emojipedia.
+type lookup interface {
+	Get(key string) (*emoji.Emoji, bool)
+}
+
+// warm returns an Emojipedia of the emoji the daemon found for the arguments when one is listening.
+// Otherwise the emoji are read from the dataset as they are looked up, or with the Emojipedia of the locale
+// when one is set, as translated names are only indexed by reading every emoji.
+func warm(command string, arguments *arguments.Arguments) lookup {
+	args := []string{}
+	arguments.Each(func(_ int, argument string) {
+		if strings.HasPrefix(argument, "--") == false {
+			args = append(args, argument)
+		}
+	})
+	if found, ok := ask(command, args...); ok {
+		return emojipedia.NewEmojipedia(found...)
+	}
+	if len(language) == 0 {
+		if lazy, err := emojipedia.OpenLazy(); err == nil {
+			return lazy
+		}
+	}
+	return emojipedia.GetLocale(language)
+}
+
+// stamp returns the modification times of the dataset manifest, which changes whenever a package is built,
+// of the emoji folder, which changes whenever an emoji is written or removed, and of the user data,
+// which changes whenever favorites or tags are changed.
+func stamp() string {
+	stamps := []string{}
+	for _, path := range []string{directory.Manifest, directory.Emoji, directory.User} {
+		if info, err := os.Stat(path); err == nil {
+			stamps = append(stamps, info.ModTime().String())
+		}
+	}
+	return strings.Join(stamps, " ")
+}
+
+func daemonMain(arguments *arguments.Arguments) {
+	path := arguments.Get(0)
+	if len(path) == 0 {
+		path = daemon.Path()
+	}
+	listener, err := daemon.Listen(path)
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, path, err), err)
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		listener.Close()
+	}()
+	defer signal.Stop(signals)
+	fmt.Println(fmt.Sprintf(statusServe, path))
+	daemon.Serve(listener, emojipedia.OpenLocale, stamp)
+}
@@ -0,0 +1,162 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+)
+
+const (
+	// Get asks for the emoji named by each argument.
+	Get string = "get"
+	// Search asks for the emoji found for the arguments, best matches first.
+	Search string = "search"
+)
+
+const (
+	socket string = "daemon.sock"
+)
+
+var (
+	// Timeout is how long a client waits for the daemon to accept a connection and answer it.
+	Timeout = 2 * time.Second
+)
+
+// Path returns the socket the daemon of the active profile listens on.
+func Path() string {
+	return filepath.Join(directory.Root, socket)
+}
+
+// Request is a query sent to the daemon as one line of json.
+type Request struct {
+	Args    []string `json:"args"`
+	Command string   `json:"command"`
+	Locale  string   `json:"locale,omitempty"`
+}
+
+// Response is the answer of the daemon to a Request, sent as one line of json.
+type Response struct {
+	Emoji []*emoji.Emoji `json:"emoji"`
+	Error string         `json:"error,omitempty"`
+}
+
+// Ask sends the Request to the daemon listening on the socket and returns its Response.
+// An error is returned when no daemon is listening, so callers can fall back to reading the dataset themselves.
+func Ask(path string, request *Request) (*Response, error) {
+	conn, err := net.DialTimeout("unix", path, Timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(Timeout))
+	err = json.NewEncoder(conn).Encode(request)
+	if err != nil {
+		return nil, err
+	}
+	response := &Response{}
+	err = json.NewDecoder(conn).Decode(response)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Error) != 0 {
+		return nil, fmt.Errorf("daemon: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Listen removes a socket left behind by a daemon that is no longer running and listens on the socket.
+// An error is returned if another daemon is listening on it.
+func Listen(path string) (net.Listener, error) {
+	if conn, err := net.DialTimeout("unix", path, Timeout); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("daemon: already listening on %s", path)
+	}
+	os.Remove(path)
+	return net.Listen("unix", path)
+}
+
+// Serve answers the Requests of every connection the listener accepts until it is closed. The Emojipedia of
+// each locale is opened once with the argument function and kept in memory until the stamp function
+// (such as the modification time of the dataset manifest) reports that the dataset was rebuilt.
+func Serve(listener net.Listener, open func(locale string) (*emojipedia.Emojipedia, error), stamp func() string) error {
+	var (
+		cache = map[string]*emojipedia.Emojipedia{}
+		last  = stamp()
+		mutex = &sync.Mutex{}
+	)
+	get := func(locale string) (*emojipedia.Emojipedia, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if current := stamp(); current != last {
+			cache, last = map[string]*emojipedia.Emojipedia{}, current
+		}
+		if e, ok := cache[locale]; ok {
+			return e, nil
+		}
+		e, err := open(locale)
+		if err != nil {
+			return nil, err
+		}
+		cache[locale] = e
+		return e, nil
+	}
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go answer(conn, get)
+	}
+}
+
+// answer replies to each Request read from the connection until it is closed.
+func answer(conn net.Conn, get func(locale string) (*emojipedia.Emojipedia, error)) {
+	defer conn.Close()
+	var (
+		encoder = json.NewEncoder(conn)
+		scanner = bufio.NewScanner(conn)
+	)
+	for scanner.Scan() {
+		request := &Request{}
+		if err := json.Unmarshal(scanner.Bytes(), request); err != nil {
+			encoder.Encode(&Response{Error: err.Error()})
+			continue
+		}
+		e, err := get(request.Locale)
+		if err != nil {
+			encoder.Encode(&Response{Error: err.Error()})
+			continue
+		}
+		encoder.Encode(respond(e, request))
+	}
+}
+
+// respond answers the Request from the Emojipedia.
+func respond(e *emojipedia.Emojipedia, request *Request) *Response {
+	response := &Response{Emoji: []*emoji.Emoji{}}
+	switch request.Command {
+	case Get:
+		for _, arg := range request.Args {
+			if x, ok := e.Get(arg); ok {
+				response.Emoji = append(response.Emoji, x)
+			}
+		}
+	case Search:
+		e.Search(strings.Join(request.Args, " ")).Each(func(_ int, i interface{}) {
+			response.Emoji = append(response.Emoji, i.(*emoji.Emoji))
+		})
+	default:
+		response.Error = fmt.Sprintf("unknown command \"%s\"", request.Command)
+	}
+	return response
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/jsonpatch"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/webhook"
+)
+
+func diffOpen(profile string) *emojipedia.Emojipedia {
+	directory.Use(profile)
+	e, err := emojipedia.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, fmt.Sprintf("profile %s", profile), err), err)
+	}
+	return e
+}
+
+func diffMain(arguments *arguments.Arguments) {
+	_, patching := arguments.Flag("patch")
+	if len(arguments.Get(0)) == 0 {
+		failWith(usage, fmt.Sprintf(errorCannotFind, "profile"), ErrMissingArgument)
+	}
+	var (
+		from = diffOpen(arguments.Get(0))
+		to   = diffOpen(arguments.Get(1))
+	)
+	if patching {
+		update, err := patchOf(from, to)
+		if err == nil {
+			update.From, update.To = arguments.Get(0), arguments.Get(1)
+			var content []byte
+			if content, err = store.Marshal(update); err == nil {
+				fmt.Println(string(content))
+			}
+		}
+		if err != nil {
+			fail(fmt.Sprintf(errorCannotWrite, "patch", err), err)
+		}
+		return
+	}
+	fmt.Fprintln(writer, "Change\t|ID\t|Name")
+	for _, change := range diffOf(from, to) {
+		fmt.Fprintln(writer, change)
+	}
+	writer.Flush()
+}
+
+// diffOf lists the emoji removed (-), changed (~) and added (+) between two datasets as sorted table rows.
+func diffOf(from, to *emojipedia.Emojipedia) []string {
+	var (
+		changes = []string{}
+		summary = summarize(from, to)
+	)
+	for _, change := range summary.Removed {
+		changes = append(changes, fmt.Sprintf("-\t|%s\t|%s", change.ID, change.Name))
+	}
+	for _, change := range summary.Modified {
+		changes = append(changes, fmt.Sprintf("~\t|%s\t|%s", change.ID, change.Name))
+	}
+	for _, change := range summary.Added {
+		changes = append(changes, fmt.Sprintf("+\t|%s\t|%s", change.ID, change.Name))
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+// patchOf builds the JSON Patch of each emoji file that differs between two datasets. Files of removed emoji are
+// marked as removed, added emoji are created whole and renamed emoji are removed and added under their new name.
+func patchOf(from, to *emojipedia.Emojipedia) (*jsonpatch.Update, error) {
+	var (
+		changes = from.Diff(to)
+		update  = &jsonpatch.Update{Files: []*jsonpatch.File{}}
+	)
+	file := func(e *emoji.Emoji) *jsonpatch.File {
+		key := e.Name
+		if store.ByID {
+			key = e.ID
+		}
+		return &jsonpatch.File{Key: key, Path: filepath.ToSlash(filepath.Join(filepath.Base(directory.Emoji), filepath.Base(store.Path(directory.Emoji, key))))}
+	}
+	patch := func(a, b *emoji.Emoji) error {
+		var (
+			f       = file(b)
+			content []byte
+		)
+		if a != nil {
+			content, _ = json.Marshal(a)
+		}
+		next, err := json.Marshal(b)
+		if err != nil {
+			return err
+		}
+		if f.Patch, err = jsonpatch.Diff(content, next); err == nil && len(f.Patch) != 0 {
+			update.Files = append(update.Files, f)
+		}
+		return err
+	}
+	removed := func(e *emoji.Emoji) {
+		f := file(e)
+		f.Removed = true
+		update.Files = append(update.Files, f)
+	}
+	for _, e := range changes.Removed {
+		removed(e)
+	}
+	for _, m := range changes.Modified {
+		if a, b := file(m.From), file(m.To); a.Key != b.Key {
+			removed(m.From)
+			if err := patch(nil, m.To); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := patch(m.From, m.To); err != nil {
+			return nil, err
+		}
+	}
+	for _, e := range changes.Added {
+		if err := patch(nil, e); err != nil {
+			return nil, err
+		}
+	}
+	return update, nil
+}
+
+// summarize collects the emoji added, modified and removed between two datasets.
+func summarize(from, to *emojipedia.Emojipedia) *webhook.Summary {
+	summary := &webhook.Summary{
+		Added:    []webhook.Change{},
+		Modified: []webhook.Change{},
+		Profile:  directory.Profile,
+		Removed:  []webhook.Change{},
+		Time:     time.Now().UTC()}
+	changes := from.Diff(to)
+	for _, e := range changes.Removed {
+		summary.Removed = append(summary.Removed, webhook.Change{ID: e.ID, Name: e.Name})
+	}
+	for _, m := range changes.Modified {
+		switch {
+		case m.From.Name != m.To.Name:
+			summary.Modified = append(summary.Modified, webhook.Change{ID: m.From.ID, Name: fmt.Sprintf("%s -> %s", m.From.Name, m.To.Name)})
+		case tracked(m.Changes):
+			summary.Modified = append(summary.Modified, webhook.Change{ID: m.From.ID, Name: m.From.Name})
+		}
+	}
+	for _, e := range changes.Added {
+		summary.Added = append(summary.Added, webhook.Change{ID: e.ID, Name: e.Name})
+	}
+	return summary
+}
+
+// tracked checks whether one of the changed fields is not filled in lazily. Descriptions, sentiment, provenance and
+// locale names are added after the emoji are built, so changes to them alone are not reported as modifications.
+func tracked(changes []*emoji.Change) bool {
+	for _, change := range changes {
+		switch change.Field {
+		case "description", "names", "provenance", "sentiment":
+		default:
+			return true
+		}
+	}
+	return false
+}
@@ -2,28 +2,185 @@ package directory
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
 	"path/filepath"
 	"runtime"
+	"strings"
 )
 
 const (
 	category    string = "category"
 	emoji       string = "emoji"
+	emojidata   string = "emojidata"
+	images      string = "images"
 	keywords    string = "keywords"
+	renders     string = "renders"
+	schema      string = "schema"
 	subcategory string = "subcategory"
 	unicode     string = "unicode"
 )
 
+const (
+	checkpoint string = "checkpoint.json"
+	history    string = "history.json"
+	index      string = "index.bin"
+	manifest   string = "manifest.json"
+	name       string = "emojipedia"
+	profiles   string = "profiles"
+	slugs      string = "slugs.json"
+	topics     string = "topics.json"
+	user       string = "user.json"
+	weights    string = "weights.json"
+)
+
+// GOOS and Getenv are the operating system and environment the storage folder is resolved with.
+// Every lookup of the storage folder goes through them, so it can be resolved for any operating system.
+var (
+	GOOS   = runtime.GOOS
+	Getenv = os.Getenv
+)
+
+// The source folder is only looked up to find datasets built beside the source code by earlier versions.
+// Every other location is resolved from the storage folder, never from the working directory.
 var (
 	_, file, _, _ = runtime.Caller(0)
 	rootpath      = filepath.Dir(filepath.Dir(file))
-	storagepath   = filepath.Join(rootpath, fmt.Sprintf(".%s", "emojipedia"))
+	legacypath    = filepath.Join(rootpath, fmt.Sprintf(".%s", name))
+	storagepath   = Storage(GOOS, Getenv)
 )
 
 var (
 	Category    = filepath.Join(storagepath, category)
+	Checkpoint  = filepath.Join(storagepath, checkpoint)
 	Emoji       = filepath.Join(storagepath, emoji)
+	Emojidata   = filepath.Join(storagepath, emojidata)
+	History     = filepath.Join(storagepath, history)
+	Images      = filepath.Join(storagepath, images)
+	Index       = filepath.Join(storagepath, index)
 	Keywords    = filepath.Join(storagepath, keywords)
+	Manifest    = filepath.Join(storagepath, manifest)
+	Profile     = ""
+	Renders     = filepath.Join(storagepath, renders)
+	Root        = storagepath
+	Schema      = filepath.Join(storagepath, schema)
+	Slugs       = filepath.Join(storagepath, slugs)
 	Subcategory = filepath.Join(storagepath, subcategory)
+	Topics      = filepath.Join(storagepath, topics)
 	Unicode     = filepath.Join(storagepath, unicode)
+	User        = filepath.Join(storagepath, user)
+	Weights     = filepath.Join(storagepath, weights)
 )
+
+// Use switches every dataset folder to the named profile, stored in its own subdirectory of the storage folder.
+// An empty profile name switches back to the default dataset.
+func Use(profile string) {
+	Profile = profile
+	if len(profile) != 0 {
+		At(filepath.Join(storagepath, profiles, profile))
+		return
+	}
+	At(storagepath)
+}
+
+// At points every dataset folder at the argument root folder, such as a staging copy of the active profile.
+func At(root string) {
+	Root = root
+	Category = filepath.Join(Root, category)
+	Checkpoint = filepath.Join(Root, checkpoint)
+	Emoji = filepath.Join(Root, emoji)
+	Emojidata = filepath.Join(Root, emojidata)
+	History = filepath.Join(Root, history)
+	Images = filepath.Join(Root, images)
+	Index = filepath.Join(Root, index)
+	Keywords = filepath.Join(Root, keywords)
+	Manifest = filepath.Join(Root, manifest)
+	Renders = filepath.Join(Root, renders)
+	Schema = filepath.Join(Root, schema)
+	Slugs = filepath.Join(Root, slugs)
+	Subcategory = filepath.Join(Root, subcategory)
+	Topics = filepath.Join(Root, topics)
+	Unicode = filepath.Join(Root, unicode)
+	User = filepath.Join(Root, user)
+	Weights = filepath.Join(Root, weights)
+}
+
+// Profiles returns the names of the profiles that have been created in the storage folder.
+func Profiles() ([]string, error) {
+	files, err := ioutil.ReadDir(filepath.Join(storagepath, profiles))
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, file := range files {
+		if file.IsDir() {
+			names = append(names, file.Name())
+		}
+	}
+	return names, nil
+}
+
+// Storage resolves the root folder datasets are stored in for the argument operating system.
+// EMOJIPEDIA_HOME takes precedence, followed by a dataset already built beside the source code.
+// Otherwise the platform's per-user data folder is used: %APPDATA% on Windows,
+// ~/Library/Application Support on macOS and $XDG_DATA_HOME (or ~/.local/share) elsewhere.
+// Paths are joined with the separator of the operating system, whichever separators the variables were given with.
+func Storage(goos string, getenv func(key string) string) string {
+	if home := getenv("EMOJIPEDIA_HOME"); len(home) != 0 {
+		return join(goos, home)
+	}
+	if info, err := os.Stat(legacypath); err == nil && info.IsDir() {
+		return legacypath
+	}
+	home := getenv("HOME")
+	switch goos {
+	case "windows":
+		if appdata := getenv("APPDATA"); len(appdata) != 0 {
+			return join(goos, appdata, name)
+		}
+		home = getenv("USERPROFILE")
+		if len(home) != 0 {
+			return join(goos, home, "AppData", "Roaming", name)
+		}
+	case "darwin":
+		if len(home) != 0 {
+			return join(goos, home, "Library", "Application Support", name)
+		}
+	default:
+		// XDG_DATA_HOME is ignored unless absolute, as the specification requires.
+		if data := getenv("XDG_DATA_HOME"); strings.HasPrefix(data, "/") {
+			return join(goos, data, name)
+		}
+		if len(home) != 0 {
+			return join(goos, home, ".local", "share", name)
+		}
+	}
+	return legacypath
+}
+
+// join joins and cleans the elements of a path with the separator of the operating system.
+// Windows accepts both separators, so either is read as one and the result uses backslashes,
+// keeping the two leading separators of a network share.
+func join(goos string, elements ...string) string {
+	if goos != "windows" {
+		return path.Join(elements...)
+	}
+	for i, element := range elements {
+		elements[i] = strings.Replace(element, "\\", "/", -1)
+	}
+	joined := path.Join(elements...)
+	if strings.HasPrefix(elements[0], "//") {
+		joined = "/" + joined
+	}
+	return strings.Replace(joined, "/", "\\", -1)
+}
+
+// Locale returns the folder holding the translated names, descriptions and keywords of a locale ("fr", "pt-BR").
+// Everything that does not depend on the locale, such as codes and categories, is kept in the shared dataset folders.
+func Locale(code string) string {
+	return filepath.Join(Root, code)
+}
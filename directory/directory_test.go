@@ -0,0 +1,39 @@
+package directory
+
+import "testing"
+
+func TestStorage(t *testing.T) {
+	tests := []struct {
+		goos string
+		env  map[string]string
+		want string
+	}{
+		{"linux", map[string]string{"HOME": "/home/ada"}, "/home/ada/.local/share/emojipedia"},
+		{"linux", map[string]string{"HOME": "/home/ada", "XDG_DATA_HOME": "/data/"}, "/data/emojipedia"},
+		{"linux", map[string]string{"HOME": "/home/ada", "XDG_DATA_HOME": "data"}, "/home/ada/.local/share/emojipedia"},
+		{"linux", map[string]string{"HOME": "/home/ada", "EMOJIPEDIA_HOME": "/srv//emoji/"}, "/srv/emoji"},
+		{"darwin", map[string]string{"HOME": "/Users/ada"}, "/Users/ada/Library/Application Support/emojipedia"},
+		{"darwin", map[string]string{"HOME": "/Users/ada", "XDG_DATA_HOME": "/data"}, "/Users/ada/Library/Application Support/emojipedia"},
+		{"darwin", map[string]string{"EMOJIPEDIA_HOME": "/Volumes/emoji"}, "/Volumes/emoji"},
+		{"windows", map[string]string{"APPDATA": `C:\Users\ada\AppData\Roaming`}, `C:\Users\ada\AppData\Roaming\emojipedia`},
+		{"windows", map[string]string{"APPDATA": `C:/Users/ada/AppData/Roaming/`}, `C:\Users\ada\AppData\Roaming\emojipedia`},
+		{"windows", map[string]string{"USERPROFILE": `C:\Users\ada`}, `C:\Users\ada\AppData\Roaming\emojipedia`},
+		{"windows", map[string]string{"APPDATA": `C:\Roaming`, "EMOJIPEDIA_HOME": `D:/emoji\data`}, `D:\emoji\data`},
+		{"windows", map[string]string{"EMOJIPEDIA_HOME": `\\server\share\emoji`}, `\\server\share\emoji`}}
+	for _, test := range tests {
+		getenv := func(key string) string {
+			return test.env[key]
+		}
+		if got := Storage(test.goos, getenv); got != test.want {
+			t.Errorf("Storage(%q, %v) = %q, want %q", test.goos, test.env, got, test.want)
+		}
+	}
+}
+
+func TestStorageFallback(t *testing.T) {
+	for _, goos := range []string{"darwin", "linux", "windows"} {
+		if got := Storage(goos, func(string) string { return "" }); got != legacypath {
+			t.Errorf("Storage(%q) without a home folder = %q, want %q", goos, got, legacypath)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/discord"
+	"github.com/gellel/emojipedia/stdin"
+)
+
+func discordMain(arguments *arguments.Arguments) {
+	switch command(arguments, REGISTER) {
+	case R, REGISTER:
+		if err := discord.Register(); err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, "discord", err), err)
+		}
+		fmt.Println("registered the /emoji command. serve the interactions endpoint at " + discord.Path)
+	default:
+		var (
+			r = stdin.Arg{
+				About:   "register the /emoji slash-command using DISCORD_APPLICATION_ID and DISCORD_BOT_TOKEN",
+				Short:   R,
+				Verbose: REGISTER}
+		)
+		fmt.Fprintln(writer, "usage: emojipedia [-dc discord] [<option>]")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "installing the discord bot")
+		fmt.Fprintln(writer, r)
+		fmt.Fprintln(writer)
+		writer.Flush()
+		unknown(arguments)
+	}
+}
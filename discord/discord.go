@@ -0,0 +1,242 @@
+package discord
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/text"
+)
+
+const (
+	// API is the Discord REST API the slash-commands are registered with.
+	API string = "https://discord.com/api/v10"
+	// Path is the route Discord delivers interactions to.
+	Path string = "/discord/interactions"
+)
+
+const (
+	interactionPing    int = 1
+	interactionCommand int = 2
+)
+
+const (
+	responsePong    int = 1
+	responseMessage int = 4
+)
+
+var (
+	// Application is the Discord application ID. Defaults to the DISCORD_APPLICATION_ID environment variable.
+	Application = os.Getenv("DISCORD_APPLICATION_ID")
+	// PublicKey is the hex encoded application public key used to verify interactions. Defaults to the DISCORD_PUBLIC_KEY environment variable.
+	PublicKey = os.Getenv("DISCORD_PUBLIC_KEY")
+	// Token is the bot token used to register commands. Defaults to the DISCORD_BOT_TOKEN environment variable.
+	Token = os.Getenv("DISCORD_BOT_TOKEN")
+)
+
+var (
+	// Commands describes the /emoji command and its lookup, search and random subcommands.
+	Commands = []Command{Command{
+		Description: "look up emoji",
+		Name:        "emoji",
+		Options: []Option{
+			Option{Description: "show a single emoji", Name: "lookup", Options: []Option{term}, Type: 1},
+			Option{Description: "list emoji matching a term", Name: "search", Options: []Option{term}, Type: 1},
+			Option{Description: "show a random emoji", Name: "random", Type: 1}}}}
+	term = Option{Description: "name, keyword or emoji", Name: "term", Required: true, Type: 3}
+)
+
+// Handler answers interactions for the /emoji command, backed by the Emojipedia Search and Random methods.
+func Handler(emojipedia *emojipedia.Emojipedia) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !Verify(r.Header, body, PublicKey) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		interaction := &Interaction{}
+		if err := json.Unmarshal(body, interaction); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		response := &Response{Type: responsePong}
+		if interaction.Type == interactionCommand {
+			response = Answer(emojipedia, interaction)
+		}
+		content, err := store.Marshal(response)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content)
+	})
+}
+
+// Answer builds the message replying to an /emoji command interaction.
+func Answer(emojipedia *emojipedia.Emojipedia, interaction *Interaction) *Response {
+	var (
+		subcommand Option
+		query      string
+	)
+	if len(interaction.Data.Options) != 0 {
+		subcommand = interaction.Data.Options[0]
+	}
+	for _, option := range subcommand.Options {
+		if option.Name == "term" {
+			query = fmt.Sprintf("%v", option.Value)
+		}
+	}
+	message := &Message{}
+	switch subcommand.Name {
+	case "lookup":
+		if results := emojipedia.Search(query); results.Len() != 0 {
+			message.Embeds = []Embed{EmbedOf(results.Fetch(0).(*emoji.Emoji))}
+		}
+	case "search":
+		lines := []string{}
+		emojipedia.Search(query).Each(func(i int, value interface{}) {
+			if e := value.(*emoji.Emoji); i < 10 {
+				lines = append(lines, fmt.Sprintf("%s %s", text.Emojize(e.Unicode), e.Name))
+			}
+		})
+		message.Content = strings.Join(lines, "\n")
+	case "random":
+		if e := emojipedia.Random(); e != nil {
+			message.Embeds = []Embed{EmbedOf(e)}
+		}
+	}
+	if len(message.Content) == 0 && len(message.Embeds) == 0 {
+		message.Content = fmt.Sprintf("no emoji found for \"%s\"", query)
+	}
+	return &Response{Data: message, Type: responseMessage}
+}
+
+// EmbedOf describes an Emoji as a Discord embed, including its image when it is hosted rather than inlined.
+func EmbedOf(e *emoji.Emoji) Embed {
+	embed := Embed{
+		Description: emoji.AltText(e, ""),
+		Fields: []Field{
+			Field{Inline: true, Name: "category", Value: e.Category},
+			Field{Inline: true, Name: "subcategory", Value: e.Subcategory},
+			Field{Inline: true, Name: "codes", Value: e.Codes.Join(" ")}},
+		Title: fmt.Sprintf("%s %s", text.Emojize(e.Unicode), e.Name),
+		URL:   e.Href}
+	if strings.HasPrefix(e.Image, "http") {
+		embed.Image = &Image{URL: e.Image}
+	}
+	return embed
+}
+
+// Register creates (or replaces) the /emoji command for the application using the bot Token.
+func Register() error {
+	if len(Application) == 0 || len(Token) == 0 {
+		return fmt.Errorf("discord: DISCORD_APPLICATION_ID and DISCORD_BOT_TOKEN must be set")
+	}
+	content, err := json.Marshal(Commands)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/applications/%s/commands", API, Application), bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bot "+Token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return nil
+}
+
+// Verify checks the Ed25519 X-Signature-Ed25519 of an interaction against the hex encoded public key.
+func Verify(header http.Header, body []byte, publicKey string) bool {
+	key, err := hex.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return false
+	}
+	signature, err := hex.DecodeString(header.Get("X-Signature-Ed25519"))
+	if err != nil {
+		return false
+	}
+	message := append([]byte(header.Get("X-Signature-Timestamp")), body...)
+	return ed25519.Verify(ed25519.PublicKey(key), message, signature)
+}
+
+// Command is a Discord application command.
+type Command struct {
+	Description string   `json:"description"`
+	Name        string   `json:"name"`
+	Options     []Option `json:"options,omitempty"`
+}
+
+// Embed is a rich Discord message section.
+type Embed struct {
+	Description string  `json:"description,omitempty"`
+	Fields      []Field `json:"fields,omitempty"`
+	Image       *Image  `json:"image,omitempty"`
+	Title       string  `json:"title"`
+	URL         string  `json:"url,omitempty"`
+}
+
+// Field is a name and value pair shown in an Embed.
+type Field struct {
+	Inline bool   `json:"inline"`
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+}
+
+// Image is an image shown in an Embed.
+type Image struct {
+	URL string `json:"url"`
+}
+
+// Interaction is the payload Discord delivers when a user invokes a command.
+type Interaction struct {
+	Data struct {
+		Name    string   `json:"name"`
+		Options []Option `json:"options"`
+	} `json:"data"`
+	Type int `json:"type"`
+}
+
+// Message is the content of an interaction Response.
+type Message struct {
+	Content string  `json:"content,omitempty"`
+	Embeds  []Embed `json:"embeds,omitempty"`
+}
+
+// Option is a command option, both as registered and as received with an Interaction.
+type Option struct {
+	Description string      `json:"description,omitempty"`
+	Name        string      `json:"name"`
+	Options     []Option    `json:"options,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Type        int         `json:"type"`
+	Value       interface{} `json:"value,omitempty"`
+}
+
+// Response replies to an Interaction.
+type Response struct {
+	Data *Message `json:"data,omitempty"`
+	Type int      `json:"type"`
+}
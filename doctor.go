@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emojidata"
+	"github.com/gellel/emojipedia/manifest"
+	"github.com/gellel/emojipedia/pkg"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/webhook"
+)
+
+const (
+	doctorFail string = "fail"
+	doctorOK   string = "ok"
+	doctorWarn string = "warn"
+)
+
+type diagnosis struct {
+	check  string
+	detail string
+	status string
+}
+
+func doctorStorage() []diagnosis {
+	diagnoses := []diagnosis{diagnosis{"storage", directory.Root, doctorOK}}
+	if home := directory.Getenv("EMOJIPEDIA_HOME"); len(home) != 0 {
+		if info, err := os.Stat(home); err == nil && !info.IsDir() {
+			return append(diagnoses, diagnosis{"EMOJIPEDIA_HOME", fmt.Sprintf("%s is a file; point EMOJIPEDIA_HOME at a folder", home), doctorFail})
+		}
+	}
+	if store.ReadOnly {
+		return append(diagnoses, diagnosis{"writable", "--read-only is set; builds and removals are refused", doctorWarn})
+	}
+	if err := os.MkdirAll(directory.Root, os.ModePerm); err != nil {
+		return append(diagnoses, diagnosis{"writable", fmt.Sprintf("cannot create %s: %s; set EMOJIPEDIA_HOME to a writable folder", directory.Root, err), doctorFail})
+	}
+	file, err := ioutil.TempFile(directory.Root, ".doctor")
+	if err != nil {
+		return append(diagnoses, diagnosis{"writable", fmt.Sprintf("cannot write to %s: %s; fix its permissions or set EMOJIPEDIA_HOME", directory.Root, err), doctorFail})
+	}
+	file.Close()
+	os.Remove(file.Name())
+	return append(diagnoses, diagnosis{"writable", directory.Root, doctorOK})
+}
+
+// dataset is a package stored in the active profile and the command that builds it.
+type dataset struct {
+	name    string
+	path    string
+	command string
+}
+
+// datasets returns the packages of the active profile, in the order they are built.
+func datasets() []dataset {
+	return []dataset{
+		{UNICODE, directory.Unicode, "emojipedia -u -b"},
+		{EMOJIDATA, filepath.Join(directory.Emojidata, "emoji-data.txt"), "emojipedia -u -b"},
+		{CATEGORIES, directory.Category, "emojipedia -c -b"},
+		{SUBCATEGORIES, directory.Subcategory, "emojipedia -s -b"},
+		{KEYWORDS, directory.Keywords, "emojipedia -k -b"},
+		{EMOJIPEDIA, directory.Emoji, "emojipedia -e -b"}}
+}
+
+func doctorDatasets() []diagnosis {
+	var (
+		diagnoses = []diagnosis{}
+		now       = time.Now()
+	)
+	for _, dataset := range datasets() {
+		state := manifest.Inspect(dataset.name, dataset.path)
+		switch {
+		case state.Exists == false:
+			diagnoses = append(diagnoses, diagnosis{dataset.name, fmt.Sprintf("missing %s; run \"%s\"", dataset.path, dataset.command), doctorFail})
+		case state.Items == 0:
+			diagnoses = append(diagnoses, diagnosis{dataset.name, fmt.Sprintf("empty %s; run \"%s\"", dataset.path, dataset.command), doctorFail})
+		case state.Stale(now):
+			diagnoses = append(diagnoses, diagnosis{dataset.name, fmt.Sprintf("built %v days ago; run \"emojipedia -cu\" to check for changes", int(state.Age(now).Hours()/24)), doctorWarn})
+		default:
+			diagnoses = append(diagnoses, diagnosis{dataset.name, fmt.Sprintf("built %s with %v files", state.Built.Format("2006-01-02"), state.Items), doctorOK})
+		}
+	}
+	return diagnoses
+}
+
+func doctorNetwork() []diagnosis {
+	var (
+		probe     = &http.Client{Timeout: 10 * time.Second, Transport: client.HTTP.Transport}
+		diagnoses = []diagnosis{}
+	)
+	for _, address := range []string{pkg.Address(), emojidata.Address(), emojidata.SequenceAddress(emojidata.SequenceFiles[0]), emojidata.CharacterAddress(emojidata.NamesFile), "https://emojipedia.org/"} {
+		resp, err := probe.Head(address)
+		if err != nil {
+			diagnoses = append(diagnoses, diagnosis{"network", fmt.Sprintf("cannot reach %s: %s; check your connection or proxy", address, err), doctorFail})
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			diagnoses = append(diagnoses, diagnosis{"network", fmt.Sprintf("%s answered %s", address, resp.Status), doctorWarn})
+			continue
+		}
+		diagnoses = append(diagnoses, diagnosis{"network", address, doctorOK})
+	}
+	return diagnoses
+}
+
+func doctorConfig() []diagnosis {
+	diagnoses := []diagnosis{}
+	if webhook.Enabled() {
+		if u, err := url.Parse(webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			diagnoses = append(diagnoses, diagnosis{"webhook", fmt.Sprintf("%q is not an http(s) url; fix --webhook or EMOJIPEDIA_WEBHOOK_URL", webhook.URL), doctorFail})
+		} else if len(webhook.Secret) == 0 {
+			diagnoses = append(diagnoses, diagnosis{"webhook", "notifications are unsigned; set EMOJIPEDIA_WEBHOOK_SECRET", doctorWarn})
+		} else {
+			diagnoses = append(diagnoses, diagnosis{"webhook", webhook.URL, doctorOK})
+		}
+	}
+	if len(directory.Profile) != 0 {
+		if _, err := manifest.Open(); err != nil {
+			diagnoses = append(diagnoses, diagnosis{"profile", fmt.Sprintf("profile %q has no manifest; build it with --profile=%s", directory.Profile, directory.Profile), doctorWarn})
+		} else {
+			diagnoses = append(diagnoses, diagnosis{"profile", directory.Profile, doctorOK})
+		}
+	}
+	return diagnoses
+}
+
+func doctorMain(arguments *arguments.Arguments) {
+	var (
+		diagnoses = []diagnosis{}
+		failed    = false
+	)
+	diagnoses = append(diagnoses, doctorStorage()...)
+	diagnoses = append(diagnoses, doctorDatasets()...)
+	diagnoses = append(diagnoses, doctorConfig()...)
+	diagnoses = append(diagnoses, doctorNetwork()...)
+	fmt.Fprintln(writer, "Check\t|Status\t|Detail")
+	for _, d := range diagnoses {
+		failed = failed || d.status == doctorFail
+		fmt.Fprintln(writer, fmt.Sprintf("%s\t|%s\t|%s", strings.ToLower(d.check), d.status, d.detail))
+	}
+	writer.Flush()
+	if failed {
+		exit(exitFailed)
+	}
+}
@@ -0,0 +1,57 @@
+package dot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gellel/emojipedia/lexicon"
+	"github.com/gellel/emojipedia/slice"
+)
+
+// New instantiates a new empty Graph pointer.
+func New(name string) *Graph {
+	return &Graph{
+		edges: &lexicon.Lexicon{},
+		name:  name,
+		nodes: &lexicon.Lexicon{}}
+}
+
+// Graph is a directed Graphviz graph whose nodes and edges are deduplicated and written in sorted order.
+type Graph struct {
+	edges *lexicon.Lexicon
+	name  string
+	nodes *lexicon.Lexicon
+}
+
+// Edge method adds a directed edge between two node identifiers.
+func (pointer *Graph) Edge(from, to string) *Graph {
+	pointer.edges.Add(fmt.Sprintf("%s -> %s;", quote(from), quote(to)), true)
+	return pointer
+}
+
+// Len method returns the number of nodes in the Graph.
+func (pointer *Graph) Len() int {
+	return pointer.nodes.Len()
+}
+
+// Node method adds a node with a display label and Graphviz shape.
+func (pointer *Graph) Node(id, label, shape string) *Graph {
+	pointer.nodes.Add(id, fmt.Sprintf("%s [label=%s, shape=%s];", quote(id), quote(label), shape))
+	return pointer
+}
+
+// String method renders the Graph in the DOT language.
+func (pointer *Graph) String() string {
+	lines := slice.New(fmt.Sprintf("digraph %s {", quote(pointer.name)), "\trankdir=LR;")
+	pointer.nodes.Values().Sort().Each(func(_ int, i interface{}) {
+		lines.Append("\t" + i.(string))
+	})
+	pointer.edges.Keys().Sort().Each(func(_ int, i interface{}) {
+		lines.Append("\t" + i.(string))
+	})
+	return lines.Append("}").Join("\n")
+}
+
+func quote(s string) string {
+	return "\"" + strings.Replace(strings.Replace(s, "\\", "\\\\", -1), "\"", "\\\"", -1) + "\""
+}
@@ -2,15 +2,16 @@ package main
 
 import (
 	"fmt"
-	"net/http"
-	"regexp"
+	"sort"
+	"strconv"
 	"strings"
-
-	"github.com/PuerkitoBio/goquery"
+	"time"
 
 	"github.com/gellel/emojipedia/arguments"
 	"github.com/gellel/emojipedia/emoji"
-	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/merge"
+	"github.com/gellel/emojipedia/store"
 	"github.com/gellel/emojipedia/text"
 )
 
@@ -18,9 +19,17 @@ func emojiMain(arguments *arguments.Arguments) {
 	e, err := emoji.Open(arguments.Get(0))
 	switch err == nil {
 	case true:
-		switch strings.ToUpper(arguments.Next().Get(0)) {
+		verb := command(arguments.Next(), ANCHOR, BLOCK, CATEGORY, CODES, DESCRIPTION, EMOJI, FORMAL, HREF, ID, IMAGE, KEYWORDS, NUMBER, PROVENANCE, RELATED, SENTIMENT, SUBCATEGORY, TABLE, UNICODE)
+		// "emojipedia emoji taco --as=html" encodes the emoji without naming the emoji command.
+		as, ok := arguments.Flag("as")
+		if ok && (len(verb) == 0 || strings.HasPrefix(verb, "--")) {
+			verb = EMOJI
+		}
+		switch verb {
 		case A, ANCHOR:
 			fmt.Println(e.Anchor)
+		case BLOCK:
+			fmt.Println(e.Block)
 		case C, CATEGORY:
 			fmt.Println(e.Category)
 		case CC, CODES:
@@ -28,34 +37,93 @@ func emojiMain(arguments *arguments.Arguments) {
 				fmt.Println(i.(string))
 			})
 		case D, DESCRIPTION:
-			if len(e.Description) == 0 {
-				var (
-					resp, _     = http.Get("https://emojipedia.org/" + e.Name + "/")
-					document, _ = goquery.NewDocumentFromResponse(resp)
-					re          = regexp.MustCompile(`\r?\n`)
-					paragraphs  = &slice.Slice{}
-				)
-				document.Find("section.description > p").Each(func(_ int, selection *goquery.Selection) {
-					paragraphs.Append(re.ReplaceAllString(strings.TrimSpace(selection.Text()), " "))
-				})
-				e.Description = paragraphs.Join(" ")
-				emoji.Write(e)
+			// Describing an emoji stores the description, so a writable dataset is read again under its lock.
+			// Built emoji hold the "NIL" placeholder until they are described.
+			undescribed := func() bool { return len(e.Description) == 0 || e.Description == "NIL" }
+			writable := undescribed() && store.Writable() == nil
+			if writable {
+				lock()
+				if e, err = emoji.Open(e.Name); err != nil {
+					fail(fmt.Sprintf(errorCannotOpen, arguments.Get(0), err), err)
+				}
+			}
+			if undescribed() {
+				if description, err := emoji.Describe(e); err == nil && len(description) != 0 {
+					e.SetDescription(description).Record("description", merge.Emojipedia, time.Now())
+					if writable {
+						emoji.Write(e)
+					}
+				}
 			}
 			fmt.Println(e.Description)
 		case E, EMOJI:
-			fmt.Println(text.Emojize(e.Unicode))
+			formatted, err := e.Format(as)
+			if err != nil {
+				failWith(usage, err.Error(), err)
+			}
+			fmt.Println(formatted)
+		case FORMAL:
+			if e.FormalNames != nil {
+				fmt.Fprintln(writer, "code\t|name")
+				e.Codes.Each(func(i int, code interface{}) {
+					name, _ := e.FormalNames.Fetch(i).(string)
+					fmt.Fprintln(writer, fmt.Sprintf("%s\t|%s", code, name))
+				})
+				writer.Flush()
+			}
 		case H, HREF:
 			fmt.Println(e.Href)
+		case ID:
+			fmt.Println(e.ID)
 		case I, IMAGE:
-			fmt.Println(e.Image)
+			preferences := []string{}
+			arguments.Next().Each(func(_ int, argument string) {
+				preferences = append(preferences, argument)
+			})
+			fmt.Println(e.Art(preferences...))
 		case K, KEYWORDS:
 			e.Keywords.Sort().Each(func(_ int, i interface{}) {
 				fmt.Println(i.(string))
 			})
 		case N, NUMBER:
 			fmt.Println(e.Number)
+		case PROVENANCE:
+			fields := []string{}
+			for field := range e.Provenance {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			fmt.Fprintln(writer, "field\t|source\t|fetched")
+			for _, field := range fields {
+				for _, p := range e.Provenance[field] {
+					fmt.Fprintln(writer, fmt.Sprintf("%s\t|%s\t|%s", field, p.Source, p.Fetched.Format(time.RFC3339)))
+				}
+			}
+			writer.Flush()
+		case R, RELATED:
+			names := []string{}
+			if value, ok := arguments.Flag("metric"); ok {
+				names = strings.Split(value, ",")
+			}
+			metric, err := emoji.MetricOf(names...)
+			if err != nil {
+				failWith(usage, err.Error(), err)
+			}
+			n, err := strconv.Atoi(arguments.Next().Get(0))
+			if err != nil {
+				n = 10
+			}
+			stored().Related(e.ID, n, metric).Each(func(_ int, i interface{}) {
+				fmt.Println(i.(string))
+			})
 		case S, SUBCATEGORY:
 			fmt.Println(e.Subcategory)
+		case SENTIMENT:
+			if e.Sentiment != nil {
+				fmt.Fprintln(writer, "label\t|score\t|negative\t|neutral\t|positive\t|occurrences")
+				fmt.Fprintln(writer, fmt.Sprintf("%s\t|%.3f\t|%v\t|%v\t|%v\t|%v", e.Sentiment.Label(), e.Sentiment.Score(), e.Sentiment.Negative, e.Sentiment.Neutral, e.Sentiment.Positive, e.Sentiment.Occurrences))
+				writer.Flush()
+			}
 		case T, TABLE:
 			var (
 				character   = text.Emojize(e.Unicode)
@@ -83,6 +151,18 @@ func emojiMain(arguments *arguments.Arguments) {
 			fmt.Println(e.Unicode)
 		}
 	default:
-		fmt.Println(fmt.Sprintf(errorChoiceNotFound, arguments.Get(0), "-ee", strings.ToLower(EMOJI)))
+		if fieldError, ok := err.(*store.FieldError); ok {
+			fail(fieldError.Error(), err)
+		}
+		fail(fmt.Sprintf(errorChoiceNotFound, arguments.Get(0), "-ee", strings.ToLower(EMOJI)), err)
+	}
+}
+
+// stored returns every stored emoji, which related emoji are scored against.
+func stored() *emojipedia.Emojipedia {
+	e, err := emojipedia.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotFind, strings.ToLower(EMOJIPEDIA)), err)
 	}
+	return e
 }
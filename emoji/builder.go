@@ -0,0 +1,109 @@
+package emoji
+
+import (
+	"fmt"
+
+	"github.com/gellel/emojipedia/slice"
+)
+
+// NewBuilder instantiates a new Builder of an empty, undescribed Emoji.
+func NewBuilder() *Builder {
+	return &Builder{emoji: New().SetDescription("NIL")}
+}
+
+// Builder assembles an Emoji one named field at a time in place of the positional NewEmoji,
+// checking that the Emoji can be stored and looked up when it is built.
+type Builder struct {
+	emoji *Emoji
+}
+
+// Anchor sets the anchor of the Emoji on the unicode.org page.
+func (pointer *Builder) Anchor(anchor string) *Builder {
+	pointer.emoji.SetAnchor(anchor)
+	return pointer
+}
+
+// Build returns the Emoji, or an error if it has no name or unicode. The Emoji ID is derived from its unicode.
+func (pointer *Builder) Build() (*Emoji, error) {
+	switch {
+	case len(pointer.emoji.Name) == 0:
+		return nil, fmt.Errorf("emoji: cannot build an emoji without a name")
+	case len(pointer.emoji.Unicode) == 0:
+		return nil, fmt.Errorf("emoji: cannot build \"%s\" without its unicode", pointer.emoji.Name)
+	}
+	e := pointer.emoji.Copy()
+	e.SetID(ID(e.Unicode))
+	return e, nil
+}
+
+// Category sets the name of the category of the Emoji.
+func (pointer *Builder) Category(category string) *Builder {
+	pointer.emoji.SetCategory(category)
+	return pointer
+}
+
+// Codes sets the codepoints of the Emoji, such as "U+1F600".
+func (pointer *Builder) Codes(codes ...string) *Builder {
+	pointer.emoji.SetCodes(slice.Strings(codes...))
+	return pointer
+}
+
+// Description sets the description of the Emoji.
+func (pointer *Builder) Description(description string) *Builder {
+	pointer.emoji.SetDescription(description)
+	return pointer
+}
+
+// Href sets the address of the Emoji on the unicode.org page.
+func (pointer *Builder) Href(href string) *Builder {
+	pointer.emoji.SetHref(href)
+	return pointer
+}
+
+// Image sets the image of the Emoji.
+func (pointer *Builder) Image(image string) *Builder {
+	pointer.emoji.SetImage(image)
+	return pointer
+}
+
+// Keywords sets the keywords of the Emoji.
+func (pointer *Builder) Keywords(keywords ...string) *Builder {
+	pointer.emoji.SetKeywords(slice.Strings(keywords...))
+	return pointer
+}
+
+// Name sets the name of the Emoji.
+func (pointer *Builder) Name(name string) *Builder {
+	pointer.emoji.SetName(name)
+	return pointer
+}
+
+// Number sets the number of the Emoji in the unicode.org chart.
+func (pointer *Builder) Number(number int) *Builder {
+	pointer.emoji.SetNumber(number)
+	return pointer
+}
+
+// Position sets the row of the Emoji in the unicode.org chart.
+func (pointer *Builder) Position(position int) *Builder {
+	pointer.emoji.SetPosition(position)
+	return pointer
+}
+
+// Subcategory sets the name of the subcategory of the Emoji.
+func (pointer *Builder) Subcategory(subcategory string) *Builder {
+	pointer.emoji.SetSubcategory(subcategory)
+	return pointer
+}
+
+// Unicode sets the escaped unicode string of the Emoji, such as "\U0001f600".
+func (pointer *Builder) Unicode(unicode string) *Builder {
+	pointer.emoji.SetUnicode(unicode)
+	return pointer
+}
+
+// Variation sets whether the Emoji is written with a variation selector.
+func (pointer *Builder) Variation(variation bool) *Builder {
+	pointer.emoji.SetVariation(variation)
+	return pointer
+}
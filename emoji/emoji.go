@@ -4,15 +4,48 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf16"
 
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/gellel/emojipedia/alias"
+	"github.com/gellel/emojipedia/analyzer"
+	"github.com/gellel/emojipedia/client"
 	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emojidata"
+	"github.com/gellel/emojipedia/index"
+	"github.com/gellel/emojipedia/keyword"
+	"github.com/gellel/emojipedia/lexicon"
+	"github.com/gellel/emojipedia/sentiment"
 	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/slug"
+	"github.com/gellel/emojipedia/source"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/text"
 )
 
 var _ emoji = (*Emoji)(nil)
 
+var (
+	// keys holds the file of every stored Emoji by name and ID once locate has read the folder.
+	keys  map[string]string
+	files = sync.Mutex{}
+)
+
+// Formats are the encodings the Emoji Format method renders the Emoji character in.
+var Formats = []string{"css", "go", "html", "java", "javascript", "python", "url", "utf16"}
+
 // New instantiates a new empty Emoji pointer.
 func New() *Emoji {
 	return &Emoji{
@@ -21,6 +54,8 @@ func New() *Emoji {
 }
 
 // NewEmoji creates a new Emoji pointer, requiring all struct features as arguments.
+//
+// Deprecated: use NewBuilder, which names each field and checks the Emoji when it is built.
 func NewEmoji(anchor, category, href, image, name, subcategory, unicode string, number, position int, codes, keywords *slice.Slice) *Emoji {
 	return &Emoji{
 		Anchor:      anchor,
@@ -28,6 +63,7 @@ func NewEmoji(anchor, category, href, image, name, subcategory, unicode string,
 		Codes:       codes,
 		Description: "NIL",
 		Href:        href,
+		ID:          ID(unicode),
 		Image:       image,
 		Keywords:    keywords,
 		Name:        name,
@@ -37,6 +73,21 @@ func NewEmoji(anchor, category, href, image, name, subcategory, unicode string,
 		Unicode:     unicode}
 }
 
+// AltText returns a short human description of the Emoji suitable for screen readers.
+// The CLDR text-to-speech name for the locale (or its base language) is used when available,
+// falling back to the scraped Emoji name with its hyphens restored to spaces.
+func AltText(emoji *Emoji, locale string) string {
+	if emoji.Names != nil {
+		locale = strings.Replace(locale, "_", "-", -1)
+		for _, key := range []string{locale, strings.Split(locale, "-")[0]} {
+			if name, ok := emoji.Names.Fetch(key).(string); ok && len(name) != 0 {
+				return name
+			}
+		}
+	}
+	return strings.Replace(emoji.Name, "-", " ", -1)
+}
+
 // Get attempts to open a Category from the emojipedia/emoji folder, but panics if an error occurs.
 func Get(name string) *Emoji {
 	emoji, err := Open(name)
@@ -46,43 +97,84 @@ func Get(name string) *Emoji {
 	return emoji
 }
 
-// Open attempts to open a Emoji from the emojipedia/emoji folder.
-func Open(name string) (*Emoji, error) {
-	filepath := filepath.Join(directory.Emoji, fmt.Sprintf("%s.json", name))
-	reader, err := os.Open(filepath)
+// Describe fetches the description of the Emoji from its emojipedia.org page. The page is found by the slug
+// recorded for the Emoji, by its name and finally by its character, as resolved by slug.URLs.
+func Describe(emoji *Emoji) (string, error) {
+	var (
+		err  error
+		resp *http.Response
+	)
+	for _, address := range slug.URLs(text.Emojize(emoji.Unicode), emoji.Name) {
+		if resp, err = client.Get(address); err != nil {
+			return "", err
+		}
+		if resp.StatusCode == 200 {
+			err = nil
+			break
+		}
+		resp.Body.Close()
+		err = fmt.Errorf("%s", resp.Status)
+	}
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	content, err := ioutil.ReadAll(reader)
-	defer reader.Close()
+	defer resp.Body.Close()
+	document, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+	var (
+		paragraphs = &slice.Slice{}
+		re         = regexp.MustCompile(`\r?\n`)
+	)
+	document.Find("section.description > p").Each(func(_ int, selection *goquery.Selection) {
+		paragraphs.Append(re.ReplaceAllString(strings.TrimSpace(selection.Text()), " "))
+	})
+	return paragraphs.Join(" "), nil
+}
+
+// ID returns the stable identifier of an escaped emoji unicode string:
+// its code points as lowercase hexadecimal joined by hyphens, with variation selectors removed.
+func ID(unicode string) string {
+	codes := []string{}
+	for _, r := range emojidata.Normalize(text.Emojize(unicode)) {
+		codes = append(codes, fmt.Sprintf("%x", r))
 	}
-	emoji := &Emoji{}
-	err = json.Unmarshal(content, emoji)
+	return strings.Join(codes, "-")
+}
+
+// Open attempts to open a Emoji from the emojipedia/emoji folder by its name or ID.
+func Open(name string) (*Emoji, error) {
+	content, err := Read(name)
 	if err != nil {
 		return nil, err
 	}
-	return emoji, nil
+	return Parse(content)
 }
 
+// Parse decodes stored Emoji content. Emoji stored before IDs were introduced have their ID derived from their unicode,
+// those stored before RGI was recorded are RGI, and categories and subcategories unicode has since renamed or split
+// are given their current names.
 func Parse(content *[]byte) (*Emoji, error) {
-	category := &Emoji{}
-	err := json.Unmarshal(*content, category)
+	emoji := &Emoji{RGI: true}
+	err := store.Unmarshal(*content, emoji)
 	if err != nil {
 		return nil, err
 	}
-	return category, nil
+	if len(emoji.ID) == 0 {
+		emoji.ID = ID(emoji.Unicode)
+	}
+	emoji.Category, emoji.Subcategory = alias.Category(emoji.Category, emoji.Subcategory), alias.Subcategory(emoji.Subcategory)
+	return emoji, nil
 }
 
+// Read attempts to read the raw content of a Emoji from the emojipedia/emoji folder by its name or ID.
 func Read(name string) (*[]byte, error) {
-	filepath := filepath.Join(directory.Emoji, fmt.Sprintf("%s.json", name))
-	reader, err := os.Open(filepath)
+	filepath, err := locate(name)
 	if err != nil {
 		return nil, err
 	}
-	content, err := ioutil.ReadAll(reader)
-	defer reader.Close()
+	content, err := ioutil.ReadFile(filepath)
 	if err != nil {
 		return nil, err
 	}
@@ -91,52 +183,497 @@ func Read(name string) (*[]byte, error) {
 
 // Remove deletes the Emoji data stored in the dependencies folder.
 func Remove(name string) error {
-	return os.Remove(filepath.Join(directory.Emoji, fmt.Sprintf("%s.json", name)))
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	if err := index.Remove(); err != nil {
+		return err
+	}
+	filepath, err := locate(name)
+	if err != nil {
+		return err
+	}
+	content, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return err
+	}
+	emoji, err := Parse(&content)
+	if err != nil {
+		return err
+	}
+	err = store.Trash(filepath)
+	if err != nil {
+		return err
+	}
+	forget()
+	// The file is mapped under the key it was stored by, which is its name or its ID.
+	for _, key := range []string{emoji.Name, emoji.ID} {
+		if err := store.Forget(directory.Emoji, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// locate resolves an Emoji name or ID to its file. Files are named after whichever key they were stored by,
+// so a miss on the direct path resolves the other key with the index.Index. Without an index.Index the folder
+// is read once and the file of every name and ID is kept until an Emoji is written or removed.
+func locate(key string) (string, error) {
+	path := store.Path(directory.Emoji, key)
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) == false {
+		return path, err
+	}
+	if i, indexErr := index.Open(); indexErr == nil {
+		content, ok := i.Record(key)
+		if ok == false {
+			return "", err
+		}
+		emoji, parseErr := Parse(&content)
+		if parseErr != nil {
+			return "", err
+		}
+		for _, other := range []string{emoji.Name, emoji.ID} {
+			if candidate := store.Path(directory.Emoji, other); other != key {
+				if _, statErr := os.Stat(candidate); statErr == nil {
+					return candidate, nil
+				}
+			}
+		}
+		return "", err
+	}
+	files.Lock()
+	defer files.Unlock()
+	if keys == nil {
+		keys = scan()
+	}
+	if candidate, ok := keys[key]; ok {
+		return candidate, nil
+	}
+	return "", err
+}
+
+// scan reads the name and ID of every Emoji stored in the emojipedia/emoji folder, returning the file of each.
+func scan() map[string]string {
+	found := map[string]string{}
+	infos, err := ioutil.ReadDir(directory.Emoji)
+	if err != nil {
+		return found
+	}
+	for _, info := range infos {
+		candidate := filepath.Join(directory.Emoji, info.Name())
+		content, err := ioutil.ReadFile(candidate)
+		if err != nil {
+			continue
+		}
+		if emoji, err := Parse(&content); err == nil {
+			found[emoji.Name], found[emoji.ID] = candidate, candidate
+		}
+	}
+	return found
+}
+
+// forget discards the files found by scan, as an Emoji has been written or removed.
+func forget() {
+	files.Lock()
+	defer files.Unlock()
+	keys = nil
 }
 
 // Write stores and Emoji pointer to the dependencies folder.
 func Write(emoji *Emoji) error {
-	err := os.MkdirAll(directory.Emoji,  os.ModePerm)
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	if err := index.Remove(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(directory.Emoji, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	if store.Strict {
+		if err := emoji.Validate(); err != nil {
+			return store.Invalid(err)
+		}
+	}
+	content, err := store.Marshal(emoji)
 	if err != nil {
 		return err
 	}
-	content, err := json.Marshal(emoji)
+	key := emoji.Name
+	if store.ByID {
+		key = emoji.ID
+	}
+	filepath, err := store.Assign(directory.Emoji, key)
 	if err != nil {
 		return err
 	}
-	filepath := filepath.Join(directory.Emoji, fmt.Sprintf("%s.json", emoji.Name))
-	return ioutil.WriteFile(filepath, content,  os.ModePerm)
+	forget()
+	return store.WriteFile(filepath, content)
+}
+
+// empty checks whether a field of an Emoji holds no value. The "NIL" placeholder of undescribed emoji counts as empty.
+func empty(v reflect.Value) bool {
+	switch {
+	case v.Type() == reflect.TypeOf(&slice.Slice{}):
+		return v.IsNil() || v.Interface().(*slice.Slice).Len() == 0
+	case v.Kind() == reflect.String:
+		return len(v.String()) == 0 || v.String() == "NIL"
+	case v.Kind() == reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// members reads the emoji names of a stored subcategory without importing the subcategory package,
+// which itself resolves its members to Emoji pointers.
+func members(subcategory string) (*slice.Slice, error) {
+	var (
+		content []byte
+		err     error
+	)
+	for _, name := range alias.SubcategoryNames(subcategory) {
+		if content, err = ioutil.ReadFile(store.Path(directory.Subcategory, name)); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	s := &struct {
+		Emoji *slice.Slice `json:"emoji"`
+	}{}
+	err = json.Unmarshal(content, s)
+	if err != nil {
+		return nil, err
+	}
+	if s.Emoji == nil {
+		return &slice.Slice{}, nil
+	}
+	return s.Emoji, nil
 }
 
 type emoji interface {
+	Art(preferences ...string) string
+	BaseID() string
+	CSS() string
+	Copy() *Emoji
+	Diff(e *Emoji) []*Change
+	Equal(e *Emoji) bool
+	Format(as string) (string, error)
+	Go() string
+	HTML() string
+	JavaScript() string
+	Populated() []string
+	Python() string
+	Record(field, source string, fetched time.Time) *Emoji
+	Related(n int) *slice.Slice
+	Runes() []rune
 	SetAnchor(anchor string) *Emoji
+	SetBase(base string) *Emoji
+	SetBlock(block string) *Emoji
 	SetCategory(category string) *Emoji
 	SetCodes(codes *slice.Slice) *Emoji
 	SetDescription(description string) *Emoji
+	SetFormalNames(formalNames *slice.Slice) *Emoji
 	SetHref(href string) *Emoji
+	SetID(id string) *Emoji
 	SetImage(image string) *Emoji
 	SetKeywords(keywords *slice.Slice) *Emoji
 	SetName(name string) *Emoji
+	SetNames(names *lexicon.Lexicon) *Emoji
 	SetNumber(number int) *Emoji
 	SetPosition(position int) *Emoji
+	SetProvenance(provenance map[string][]*Provenance) *Emoji
+	SetQualified(qualified string) *Emoji
+	SetRGI(RGI bool) *Emoji
+	SetSentiment(sentiment *sentiment.Sentiment) *Emoji
+	SetShortcodes(shortcodes *slice.Slice) *Emoji
+	SetSources(sources map[string]*source.Source) *Emoji
 	SetSubcategory(subcategory string) *Emoji
 	SetUnicode(unicode string) *Emoji
+	SetVariants(variants *slice.Slice) *Emoji
+	SetVariation(variation bool) *Emoji
+	SetVersion(version string) *Emoji
+	Stamp(source string, fetched time.Time) *Emoji
+	URL() string
+	UTF16() string
+	Union(e *Emoji) *Emoji
+	Validate() error
 }
 
 // Emoji stores the contents about an emoji scraped from the unicode consortium.
 type Emoji struct {
-	Anchor      string       `json:"anchor"`
-	Category    string       `json:"category"`
-	Codes       *slice.Slice `json:"codes"`
-	Description string       `json:"description"`
-	Href        string       `json:"href"`
-	Image       string       `json:"img"`
-	Keywords    *slice.Slice `json:"keywords"`
-	Name        string       `json:"name"`
-	Number      int          `json:"number"`
-	Position    int          `json:"position"`
-	Subcategory string       `json:"subcategory"`
-	Unicode     string       `json:"unicode"`
+	Anchor      string                    `json:"anchor"`
+	Base        string                    `json:"base,omitempty"`
+	Block       string                    `json:"block,omitempty"`
+	Category    string                    `json:"category"`
+	Codes       *slice.Slice              `json:"codes"`
+	Description string                    `json:"description"`
+	FormalNames *slice.Slice              `json:"formal_names,omitempty"`
+	Href        string                    `json:"href"`
+	ID          string                    `json:"id"`
+	Image       string                    `json:"img"`
+	Keywords    *slice.Slice              `json:"keywords"`
+	Name        string                    `json:"name"`
+	Names       *lexicon.Lexicon          `json:"names,omitempty"`
+	Number      int                       `json:"number"`
+	Position    int                       `json:"position"`
+	Provenance  map[string][]*Provenance  `json:"provenance,omitempty"`
+	Qualified   string                    `json:"qualification,omitempty"`
+	RGI         bool                      `json:"rgi"`
+	Sentiment   *sentiment.Sentiment      `json:"sentiment,omitempty"`
+	Shortcodes  *slice.Slice              `json:"shortcodes,omitempty"`
+	Sources     map[string]*source.Source `json:"sources,omitempty"`
+	Subcategory string                    `json:"subcategory"`
+	Unicode     string                    `json:"unicode"`
+	Variants    *slice.Slice              `json:"variants,omitempty"`
+	Variation   bool                      `json:"variation"`
+	Version     string                    `json:"version,omitempty"`
+}
+
+// Art returns the artwork of the first preferred source (such as "openmoji" or "joypixels") that has been merged into the Emoji,
+// falling back to the unicode.org image.
+func (pointer *Emoji) Art(preferences ...string) string {
+	for _, preference := range preferences {
+		if s, ok := pointer.Sources[strings.ToLower(preference)]; ok && len(s.Art) != 0 {
+			return s.Art
+		}
+	}
+	return pointer.Image
+}
+
+// BaseID returns the ID of the emoji the Emoji is a skin tone or gender variant of: its codes without skin tone
+// modifiers, variation selectors or joined gender signs, and with a leading man or woman of a sequence made a person,
+// so "man-facepalming-medium-skin-tone" and "woman-technologist" give the IDs of "person-facepalming" and
+// "technologist". Emoji that are no variant give their own ID.
+func (pointer *Emoji) BaseID() string {
+	var (
+		codes = []string{}
+		runes = []rune(emojidata.Normalize(string(pointer.Runes())))
+	)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r >= 0x1F3FB && r <= 0x1F3FF:
+			continue
+		case r == 0x200D && i+1 < len(runes) && (runes[i+1] == 0x2640 || runes[i+1] == 0x2642):
+			i++
+			continue
+		case i == 0 && (r == 0x1F468 || r == 0x1F469) && len(runes) > 1 && runes[1] == 0x200D:
+			r = 0x1F9D1
+		}
+		codes = append(codes, fmt.Sprintf("%x", r))
+	}
+	return strings.Join(codes, "-")
+}
+
+// CSS returns the Emoji character as CSS escapes separated by the space ending each, such as \1F468 \200D \1F469.
+func (pointer *Emoji) CSS() string {
+	escapes := []string{}
+	for _, r := range pointer.Runes() {
+		escapes = append(escapes, fmt.Sprintf("\\%X", r))
+	}
+	return strings.Join(escapes, " ")
+}
+
+// Copy method returns a deep copy of the Emoji, sharing no slices, maps or pointers with it.
+func (pointer *Emoji) Copy() *Emoji {
+	e := *pointer
+	e.Codes, e.Keywords, e.Shortcodes = pointer.Codes.Copy(), pointer.Keywords.Copy(), pointer.Shortcodes.Copy()
+	e.FormalNames, e.Variants = pointer.FormalNames.Copy(), pointer.Variants.Copy()
+	if pointer.Names != nil {
+		e.Names = lexicon.New().Concatenate(pointer.Names)
+	}
+	if pointer.Provenance != nil {
+		e.Provenance = map[string][]*Provenance{}
+		for field, provenance := range pointer.Provenance {
+			for _, p := range provenance {
+				c := *p
+				e.Provenance[field] = append(e.Provenance[field], &c)
+			}
+		}
+	}
+	if pointer.Sentiment != nil {
+		sentiment := *pointer.Sentiment
+		e.Sentiment = &sentiment
+	}
+	if pointer.Sources != nil {
+		e.Sources = map[string]*source.Source{}
+		for name, s := range pointer.Sources {
+			c := *s
+			c.Skintones, c.Tags = s.Skintones.Copy(), s.Tags.Copy()
+			e.Sources[name] = &c
+		}
+	}
+	return &e
+}
+
+// Diff lists the fields, by their JSON names, whose values differ in the argument Emoji.
+// Provenance is not compared, and empty values (such as a missing description and the "NIL" placeholder) are equal.
+func (pointer *Emoji) Diff(e *Emoji) []*Change {
+	var (
+		changes = []*Change{}
+		from    = reflect.ValueOf(pointer).Elem()
+		t       = reflect.TypeOf(*pointer)
+		to      = reflect.ValueOf(e).Elem()
+	)
+	for i := 0; i < t.NumField(); i++ {
+		field := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		a, b := from.Field(i), to.Field(i)
+		if field == "provenance" || (empty(a) && empty(b)) || reflect.DeepEqual(a.Interface(), b.Interface()) {
+			continue
+		}
+		changes = append(changes, &Change{Field: field, From: a.Interface(), To: b.Interface()})
+	}
+	return changes
+}
+
+// Equal checks whether the argument Emoji holds the same values as the Emoji, as compared by Diff.
+func (pointer *Emoji) Equal(e *Emoji) bool {
+	return len(pointer.Diff(e)) == 0
+}
+
+// Format returns the Emoji character in the argument encoding: "html" entities, "url" percent-encoded UTF-8,
+// "utf16" or "java" escaped code units, "javascript" (or "js"), "python" (or "py"), "css" or "go" escapes.
+// An empty encoding returns the character itself. Every encoding is made from the Emoji codes.
+func (pointer *Emoji) Format(as string) (string, error) {
+	switch strings.ToLower(as) {
+	case "":
+		return string(pointer.Runes()), nil
+	case "css":
+		return pointer.CSS(), nil
+	case "go":
+		return pointer.Go(), nil
+	case "html":
+		return pointer.HTML(), nil
+	case "java", "utf16":
+		return pointer.UTF16(), nil
+	case "javascript", "js":
+		return pointer.JavaScript(), nil
+	case "python", "py":
+		return pointer.Python(), nil
+	case "url":
+		return pointer.URL(), nil
+	}
+	return "", fmt.Errorf("emoji: unknown format \"%s\"; expected %s", as, strings.Join(Formats, ", "))
+}
+
+// Go returns the Emoji character as the contents of an ASCII Go string literal, such as \U0001f32e.
+func (pointer *Emoji) Go() string {
+	quoted := strconv.QuoteToASCII(string(pointer.Runes()))
+	return quoted[1 : len(quoted)-1]
+}
+
+// HTML returns the Emoji character as hexadecimal HTML character references, such as &#x1F32E;.
+func (pointer *Emoji) HTML() string {
+	b := strings.Builder{}
+	for _, r := range pointer.Runes() {
+		fmt.Fprintf(&b, "&#x%X;", r)
+	}
+	return b.String()
+}
+
+// JavaScript returns the UTF-16 code units of the Emoji character as lower case JavaScript escapes, such as \ud83c\udf2e.
+func (pointer *Emoji) JavaScript() string {
+	return strings.ToLower(pointer.UTF16())
+}
+
+// Populated returns the JSON names of the Emoji fields holding a value, in declaration order.
+// Empty strings, the "NIL" placeholder of undescribed emoji and empty collections are not populated,
+// nor is the Provenance itself.
+func (pointer *Emoji) Populated() []string {
+	var (
+		fields = []string{}
+		t      = reflect.TypeOf(*pointer)
+		value  = reflect.ValueOf(pointer).Elem()
+	)
+	for i := 0; i < t.NumField(); i++ {
+		field := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if field == "provenance" || empty(value.Field(i)) {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// Python returns the Emoji character as the escapes of a Python string literal, such as \U0001F32E or \u2764.
+func (pointer *Emoji) Python() string {
+	b := strings.Builder{}
+	for _, r := range pointer.Runes() {
+		if r > 0xFFFF {
+			fmt.Fprintf(&b, "\\U%08X", r)
+		} else {
+			fmt.Fprintf(&b, "\\u%04X", r)
+		}
+	}
+	return b.String()
+}
+
+// Record adds the source the named field was taken from and the time that source was fetched to the Emoji.Provenance.
+func (pointer *Emoji) Record(field, source string, fetched time.Time) *Emoji {
+	if pointer.Provenance == nil {
+		pointer.Provenance = map[string][]*Provenance{}
+	}
+	pointer.Provenance[field] = append(pointer.Provenance[field], &Provenance{Fetched: fetched.UTC(), Source: source})
+	return pointer
+}
+
+// Related ranks other emoji by the number of keywords they share with the Emoji,
+// with membership of the same subcategory counting as one further shared keyword,
+// and returns the names of at most n of them. Ties are ordered by name.
+// Requires the keywords package to be built; the subcategory is consulted when it is available.
+//
+// Deprecated: use the Related method of the emojipedia.Emojipedia, which scores the emoji it holds by a Metric.
+func (pointer *Emoji) Related(n int) *slice.Slice {
+	scores := map[string]int{}
+	pointer.Keywords.Each(func(_ int, i interface{}) {
+		if names, err := keyword.Open(analyzer.Default.Key(i.(string))); err == nil {
+			names.Each(func(_ int, name interface{}) {
+				scores[name.(string)]++
+			})
+		}
+	})
+	if names, err := members(pointer.Subcategory); err == nil {
+		names.Each(func(_ int, name interface{}) {
+			scores[name.(string)]++
+		})
+	}
+	delete(scores, pointer.Name)
+	names := []string{}
+	for name := range scores {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if scores[names[i]] != scores[names[j]] {
+			return scores[names[i]] > scores[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	related := slice.New()
+	for i := 0; i < len(names) && i < n; i++ {
+		related.Append(names[i])
+	}
+	return related
+}
+
+// Runes returns the codepoints of the Emoji character, read from its codes (such as U+1F32E) and falling back to
+// its escaped unicode string when it has no codes.
+func (pointer *Emoji) Runes() []rune {
+	runes := []rune{}
+	if pointer.Codes != nil {
+		pointer.Codes.Each(func(_ int, i interface{}) {
+			if r, err := strconv.ParseInt(strings.TrimPrefix(strings.ToUpper(i.(string)), "U+"), 16, 32); err == nil {
+				runes = append(runes, rune(r))
+			}
+		})
+	}
+	if len(runes) == 0 {
+		runes = []rune(text.Emojize(pointer.Unicode))
+	}
+	return runes
 }
 
 // SetAnchor sets the Emoji.Anchor property.
@@ -145,6 +682,18 @@ func (pointer *Emoji) SetAnchor(anchor string) *Emoji {
 	return pointer
 }
 
+// SetBase sets the Emoji.Base property.
+func (pointer *Emoji) SetBase(base string) *Emoji {
+	pointer.Base = base
+	return pointer
+}
+
+// SetBlock sets the Emoji.Block property.
+func (pointer *Emoji) SetBlock(block string) *Emoji {
+	pointer.Block = block
+	return pointer
+}
+
 // SetCategory sets the Emoji.Category property.
 func (pointer *Emoji) SetCategory(category string) *Emoji {
 	pointer.Category = category
@@ -163,12 +712,24 @@ func (pointer *Emoji) SetDescription(description string) *Emoji {
 	return pointer
 }
 
+// SetFormalNames sets the Emoji.FormalNames property.
+func (pointer *Emoji) SetFormalNames(formalNames *slice.Slice) *Emoji {
+	pointer.FormalNames = formalNames
+	return pointer
+}
+
 // SetHref sets the Emoji.Href property.
 func (pointer *Emoji) SetHref(href string) *Emoji {
 	pointer.Href = href
 	return pointer
 }
 
+// SetID sets the Emoji.ID property.
+func (pointer *Emoji) SetID(id string) *Emoji {
+	pointer.ID = id
+	return pointer
+}
+
 // SetImage sets the Emoji.Image property.
 func (pointer *Emoji) SetImage(image string) *Emoji {
 	pointer.Image = image
@@ -187,6 +748,12 @@ func (pointer *Emoji) SetName(name string) *Emoji {
 	return pointer
 }
 
+// SetNames sets the Emoji.Names property.
+func (pointer *Emoji) SetNames(names *lexicon.Lexicon) *Emoji {
+	pointer.Names = names
+	return pointer
+}
+
 // SetNumber sets the Emoji.Number property.
 func (pointer *Emoji) SetNumber(number int) *Emoji {
 	pointer.Number = number
@@ -199,6 +766,42 @@ func (pointer *Emoji) SetPosition(position int) *Emoji {
 	return pointer
 }
 
+// SetProvenance sets the Emoji.Provenance property.
+func (pointer *Emoji) SetProvenance(provenance map[string][]*Provenance) *Emoji {
+	pointer.Provenance = provenance
+	return pointer
+}
+
+// SetQualified sets the Emoji.Qualified property.
+func (pointer *Emoji) SetQualified(qualified string) *Emoji {
+	pointer.Qualified = qualified
+	return pointer
+}
+
+// SetRGI sets the Emoji.RGI property.
+func (pointer *Emoji) SetRGI(RGI bool) *Emoji {
+	pointer.RGI = RGI
+	return pointer
+}
+
+// SetSentiment sets the Emoji.Sentiment property.
+func (pointer *Emoji) SetSentiment(sentiment *sentiment.Sentiment) *Emoji {
+	pointer.Sentiment = sentiment
+	return pointer
+}
+
+// SetShortcodes sets the Emoji.Shortcodes property.
+func (pointer *Emoji) SetShortcodes(shortcodes *slice.Slice) *Emoji {
+	pointer.Shortcodes = shortcodes
+	return pointer
+}
+
+// SetSources sets the Emoji.Sources property.
+func (pointer *Emoji) SetSources(sources map[string]*source.Source) *Emoji {
+	pointer.Sources = sources
+	return pointer
+}
+
 // SetSubcategory sets the Emoji.Subcategory property.
 func (pointer *Emoji) SetSubcategory(subcategory string) *Emoji {
 	pointer.Subcategory = subcategory
@@ -210,3 +813,131 @@ func (pointer *Emoji) SetUnicode(unicode string) *Emoji {
 	pointer.Unicode = unicode
 	return pointer
 }
+
+// SetVariants sets the Emoji.Variants property.
+func (pointer *Emoji) SetVariants(variants *slice.Slice) *Emoji {
+	pointer.Variants = variants
+	return pointer
+}
+
+// SetVariation sets the Emoji.Variation property.
+func (pointer *Emoji) SetVariation(variation bool) *Emoji {
+	pointer.Variation = variation
+	return pointer
+}
+
+// SetVersion sets the Emoji.Version property.
+func (pointer *Emoji) SetVersion(version string) *Emoji {
+	pointer.Version = version
+	return pointer
+}
+
+// Stamp replaces the Emoji.Provenance, recording every populated field as taken from the source fetched at the argument time.
+func (pointer *Emoji) Stamp(source string, fetched time.Time) *Emoji {
+	pointer.Provenance = nil
+	for _, field := range pointer.Populated() {
+		pointer.Record(field, source, fetched)
+	}
+	return pointer
+}
+
+// URL returns the UTF-8 bytes of the Emoji character percent-encoded for a URL, such as %F0%9F%8C%AE.
+func (pointer *Emoji) URL() string {
+	b := strings.Builder{}
+	for _, c := range []byte(string(pointer.Runes())) {
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+// UTF16 returns the UTF-16 code units of the Emoji character as escapes, such as \uD83C\uDF2E,
+// as used by JavaScript, JSON and Java strings.
+func (pointer *Emoji) UTF16() string {
+	b := strings.Builder{}
+	for _, u := range utf16.Encode(pointer.Runes()) {
+		fmt.Fprintf(&b, "\\u%04X", u)
+	}
+	return b.String()
+}
+
+// Union method fills the empty fields of the Emoji from a copy of the argument Emoji, adds the codes, keywords
+// and shortcodes the Emoji does not hold, and adds the names, sources and provenance of keys the Emoji does not hold.
+func (pointer *Emoji) Union(e *Emoji) *Emoji {
+	var (
+		from  = reflect.ValueOf(e.Copy()).Elem()
+		value = reflect.ValueOf(pointer).Elem()
+	)
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		switch {
+		case empty(field):
+			field.Set(from.Field(i))
+		case field.Type() == reflect.TypeOf(&slice.Slice{}):
+			field.Interface().(*slice.Slice).Union(from.Field(i).Interface().(*slice.Slice))
+		case field.Type() == reflect.TypeOf(&lexicon.Lexicon{}):
+			names := field.Interface().(*lexicon.Lexicon)
+			if other := from.Field(i).Interface().(*lexicon.Lexicon); other != nil {
+				other.Each(func(key string, value interface{}) {
+					if names.Has(key) == false {
+						names.Add(key, value)
+					}
+				})
+			}
+		case field.Kind() == reflect.Map:
+			for _, key := range from.Field(i).MapKeys() {
+				if field.MapIndex(key).IsValid() == false {
+					field.SetMapIndex(key, from.Field(i).MapIndex(key))
+				}
+			}
+		}
+	}
+	return pointer
+}
+
+// Validate checks that the Emoji can be stored and looked up: it is named, its unicode is set and matches its ID,
+// its codes are unicode codepoints such as "U+1F600", it names a category that is stored (once the categories
+// have been built) and its href and image are web addresses. Images may also be data URIs.
+func (pointer *Emoji) Validate() error {
+	invalid := func(problem string, values ...interface{}) error {
+		return fmt.Errorf("emoji: \"%s\" %s", pointer.Name, fmt.Sprintf(problem, values...))
+	}
+	switch {
+	case len(pointer.Name) == 0:
+		return fmt.Errorf("emoji: \"%s\" has no name", pointer.ID)
+	case len(pointer.Unicode) == 0:
+		return invalid("has no unicode")
+	case len(pointer.ID) != 0 && pointer.ID != ID(pointer.Unicode):
+		return invalid("has the id \"%s\" but its unicode is \"%s\"", pointer.ID, ID(pointer.Unicode))
+	case len(pointer.Category) == 0:
+		return invalid("has no category")
+	case store.Known(directory.Category, alias.CategoryNames(pointer.Category)...) == false:
+		return invalid("references the missing category \"%s\"", pointer.Category)
+	case len(pointer.Href) != 0 && text.IsURL(pointer.Href) == false:
+		return invalid("has the href \"%s\" which is not a web address", pointer.Href)
+	case len(pointer.Image) != 0 && text.IsURL(pointer.Image) == false && strings.HasPrefix(pointer.Image, "data:") == false:
+		return invalid("has an image which is neither a web address nor a data uri")
+	}
+	if pointer.Codes != nil {
+		for _, i := range *pointer.Codes {
+			code, _ := i.(string)
+			n, err := strconv.ParseUint(strings.TrimPrefix(code, "U+"), 16, 32)
+			if strings.HasPrefix(code, "U+") == false || err != nil || n > unicode.MaxRune {
+				return invalid("has the code \"%v\" which is not a unicode codepoint", i)
+			}
+		}
+	}
+	return nil
+}
+
+// Change is a field whose value differs between two Emoji.
+type Change struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// Provenance records the source a field of an Emoji was taken from and when that source was fetched.
+type Provenance struct {
+	Fetched time.Time `json:"fetched"`
+	Source  string    `json:"source"`
+}
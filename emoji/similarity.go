@@ -0,0 +1,150 @@
+package emoji
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/gellel/emojipedia/analyzer"
+)
+
+const (
+	// MetricCodepoint names the CodepointProximity Metric.
+	MetricCodepoint string = "codepoint"
+	// MetricKeywords names the KeywordJaccard Metric.
+	MetricKeywords string = "keywords"
+	// MetricSubcategory names the SameSubcategory Metric.
+	MetricSubcategory string = "subcategory"
+)
+
+var (
+	// Default is the Metric Similarity scores with when it is given none. Shared keywords weigh the most,
+	// then a shared subcategory, with codepoint proximity telling apart emoji that are otherwise as alike.
+	Default Metric = func(a, b *Emoji) float64 {
+		return (3*KeywordJaccard(a, b) + 2*SameSubcategory(a, b) + CodepointProximity(a, b)) / 6
+	}
+	// Metrics are the Metrics that can be selected by name.
+	Metrics = map[string]Metric{
+		MetricCodepoint:   CodepointProximity,
+		MetricKeywords:    KeywordJaccard,
+		MetricSubcategory: SameSubcategory}
+)
+
+// CodepointProximity scores how near the first codepoints of the Emoji are, which unicode tends to allocate
+// to related emoji side by side. Emoji starting with the same codepoint, such as skin tones of one emoji, score 1.
+func CodepointProximity(a, b *Emoji) float64 {
+	x, y := a.Runes(), b.Runes()
+	if len(x) == 0 || len(y) == 0 {
+		return 0
+	}
+	return 1 / (1 + math.Abs(float64(x[0]-y[0]))/16)
+}
+
+// KeywordJaccard scores the keywords the Emoji share against the keywords either carries. Keywords are compared
+// as the Default analyzer keys them, so "smiling" and "smile" are the same keyword.
+func KeywordJaccard(a, b *Emoji) float64 {
+	var (
+		x      = keysOf(a)
+		y      = keysOf(b)
+		shared = 0
+	)
+	for key := range x {
+		if y[key] {
+			shared++
+		}
+	}
+	union := len(x) + len(y) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// MetricOf returns the Metric averaging the Metrics of the names, such as "keywords" and "subcategory".
+// No names, or only empty names, give the Default Metric.
+func MetricOf(names ...string) (Metric, error) {
+	metrics := []Metric{}
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if len(key) == 0 {
+			continue
+		}
+		metric, ok := Metrics[key]
+		if ok == false {
+			return nil, fmt.Errorf("emoji: unknown metric \"%s\"", name)
+		}
+		metrics = append(metrics, metric)
+	}
+	if len(metrics) == 0 {
+		return Default, nil
+	}
+	return func(a, b *Emoji) float64 {
+		return Similarity(a, b, metrics...)
+	}, nil
+}
+
+// NearestN returns at most n of the candidates most similar to the Emoji, from the most to the least similar
+// and ties ordered by name. The Emoji itself and candidates with nothing in common with it are left out.
+func NearestN(e *Emoji, candidates []*Emoji, n int, metrics ...Metric) []*Emoji {
+	var (
+		nearest = []*Emoji{}
+		scores  = map[*Emoji]float64{}
+	)
+	for _, candidate := range candidates {
+		if candidate == nil || candidate.ID == e.ID || candidate.Name == e.Name {
+			continue
+		}
+		if score := Similarity(e, candidate, metrics...); score > 0 {
+			nearest, scores[candidate] = append(nearest, candidate), score
+		}
+	}
+	sort.Slice(nearest, func(i, j int) bool {
+		if scores[nearest[i]] != scores[nearest[j]] {
+			return scores[nearest[i]] > scores[nearest[j]]
+		}
+		return nearest[i].Name < nearest[j].Name
+	})
+	if n >= 0 && n < len(nearest) {
+		nearest = nearest[:n]
+	}
+	return nearest
+}
+
+// SameSubcategory scores 1 for Emoji of the same subcategory and 0 otherwise.
+func SameSubcategory(a, b *Emoji) float64 {
+	if len(a.Subcategory) != 0 && a.Subcategory == b.Subcategory {
+		return 1
+	}
+	return 0
+}
+
+// Similarity scores how alike the Emoji are from 0 to 1, averaging the Metrics or scoring with the Default Metric
+// when given none.
+func Similarity(a, b *Emoji, metrics ...Metric) float64 {
+	if len(metrics) == 0 {
+		return Default(a, b)
+	}
+	sum := 0.0
+	for _, metric := range metrics {
+		sum += metric(a, b)
+	}
+	return sum / float64(len(metrics))
+}
+
+// keysOf returns the analyzed keys of the keywords of the Emoji.
+func keysOf(e *Emoji) map[string]bool {
+	keys := map[string]bool{}
+	if e.Keywords == nil {
+		return keys
+	}
+	e.Keywords.Each(func(_ int, i interface{}) {
+		if key := analyzer.Default.Key(i.(string)); len(key) != 0 {
+			keys[key] = true
+		}
+	})
+	return keys
+}
+
+// Metric scores how alike two Emoji are, from 0 for nothing in common to 1 for the same.
+type Metric func(a, b *Emoji) float64
@@ -0,0 +1,173 @@
+package emojidata
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/directory"
+)
+
+const (
+	// BlocksFile is the Unicode Character Database file naming the block of every range of code points.
+	BlocksFile string = "Blocks.txt"
+	// NamesFile is the Unicode Character Database file giving the formal name of every code point.
+	NamesFile string = "UnicodeData.txt"
+)
+
+const (
+	characters         string = "https://www.unicode.org/Public/UCD/latest/ucd/%s"
+	versionedCharacter string = "https://www.unicode.org/Public/%s.0/ucd/%s"
+)
+
+var (
+	// CharacterFiles are the Unicode Character Database files stored beside emoji-data.txt.
+	CharacterFiles = []string{NamesFile, BlocksFile}
+)
+
+var (
+	named    sync.Once
+	database *Characters
+)
+
+// CharacterAddress returns the URL of the named Unicode Character Database file of the pinned Version.
+// Emoji versions before 11.0 were not numbered after a Unicode version, so they track the latest file.
+func CharacterAddress(file string) string {
+	if major, err := strconv.Atoi(strings.Split(Version, ".")[0]); err == nil && major >= 11 {
+		return fmt.Sprintf(versionedCharacter, Version, file)
+	}
+	return fmt.Sprintf(characters, file)
+}
+
+// HTTPCharacters requests the named Unicode Character Database file from unicode.org.
+func HTTPCharacters(file string) (*http.Response, error) {
+	return client.Fetch(CharacterAddress(file))
+}
+
+// OpenCharacters attempts to open and parse the UnicodeData.txt and Blocks.txt files from the emojipedia/emojidata folder.
+// Files that have not been stored are skipped.
+func OpenCharacters() (*Characters, error) {
+	c := &Characters{Names: map[rune]string{}}
+	for file, parse := range map[string]func(io.Reader, *Characters) error{BlocksFile: parseBlocks, NamesFile: parseNames} {
+		reader, err := os.Open(filepath.Join(directory.Emojidata, file))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		err = parse(reader, c)
+		reader.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(c.Blocks, func(i, j int) bool {
+		return c.Blocks[i].Lo < c.Blocks[j].Lo
+	})
+	return c, nil
+}
+
+// BlockOf returns the name of the Unicode block the rune belongs to, such as "Emoticons",
+// or an empty string if it is not known or Blocks.txt has not been built.
+func BlockOf(r rune) string {
+	return loadCharacters().Block(r)
+}
+
+// FormalName returns the formal Unicode character name of the rune, such as "GRINNING FACE",
+// or an empty string if it is not known or UnicodeData.txt has not been built.
+func FormalName(r rune) string {
+	return loadCharacters().Name(r)
+}
+
+// WriteCharacters stores the body of the named Unicode Character Database file HTTP response to the dependencies folder.
+func WriteCharacters(file string, resp *http.Response) error {
+	return WriteSequence(file, resp)
+}
+
+// loadCharacters opens the stored Characters once.
+func loadCharacters() *Characters {
+	named.Do(func() {
+		c, err := OpenCharacters()
+		if err != nil {
+			c = &Characters{Names: map[rune]string{}}
+		}
+		database = c
+	})
+	return database
+}
+
+// parseBlocks adds the block of each "range ; name" line of a Blocks.txt file.
+func parseBlocks(reader io.Reader, c *Characters) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i != -1 {
+			line = line[:i]
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) != 2 {
+			continue
+		}
+		lo, hi, err := parseRange(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return err
+		}
+		c.Blocks = append(c.Blocks, Block{Name: strings.TrimSpace(fields[1]), Range: Range{Lo: lo, Hi: hi}})
+	}
+	return scanner.Err()
+}
+
+// parseNames adds the name of each "code point ; name ; ..." line of a UnicodeData.txt file.
+// Controls and the ranges of unnamed ideographs and private use characters, whose names are bracketed, are skipped.
+func parseNames(reader io.Reader, c *Characters) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ";", 3)
+		if len(fields) < 2 || strings.HasPrefix(fields[1], "<") {
+			continue
+		}
+		r, err := strconv.ParseUint(fields[0], 16, 32)
+		if err != nil {
+			return err
+		}
+		c.Names[rune(r)] = fields[1]
+	}
+	return scanner.Err()
+}
+
+// Block is a named Unicode block.
+type Block struct {
+	Name string
+	Range
+}
+
+// Characters holds the formal names and blocks of the Unicode Character Database.
+type Characters struct {
+	Blocks []Block
+	Names  map[rune]string
+}
+
+// Block method returns the name of the block the rune belongs to, or an empty string if none is known.
+func (pointer *Characters) Block(r rune) string {
+	i := sort.Search(len(pointer.Blocks), func(i int) bool {
+		return pointer.Blocks[i].Hi >= r
+	})
+	if i < len(pointer.Blocks) && pointer.Blocks[i].Lo <= r {
+		return pointer.Blocks[i].Name
+	}
+	return ""
+}
+
+// Name method returns the formal name of the rune, or an empty string if none is known.
+func (pointer *Characters) Name(r rune) string {
+	return pointer.Names[r]
+}
@@ -0,0 +1,361 @@
+package emojidata
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/store"
+)
+
+const (
+	URL = "https://www.unicode.org/Public/UCD/latest/ucd/emoji/emoji-data.txt"
+)
+
+const (
+	Emoji                string = "Emoji"
+	EmojiComponent       string = "Emoji_Component"
+	EmojiModifier        string = "Emoji_Modifier"
+	EmojiModifierBase    string = "Emoji_Modifier_Base"
+	EmojiPresentation    string = "Emoji_Presentation"
+	ExtendedPictographic string = "Extended_Pictographic"
+)
+
+const (
+	// TextSelector is VARIATION SELECTOR-15, requesting text presentation of the preceding character.
+	TextSelector rune = '\uFE0E'
+	// EmojiSelector is VARIATION SELECTOR-16, requesting emoji presentation of the preceding character.
+	EmojiSelector rune = '\uFE0F'
+	// Keycap is COMBINING ENCLOSING KEYCAP, closing a keycap sequence.
+	Keycap rune = '\u20E3'
+)
+
+const (
+	derived  string = "properties.json"
+	filename string = "emoji-data.txt"
+	// emoji-data.txt moved into the Unicode Character Database with emoji version 13.0.
+	legacy string = "https://www.unicode.org/Public/emoji/%s/emoji-data.txt"
+	ucd    string = "https://www.unicode.org/Public/%s.0/ucd/emoji/emoji-data.txt"
+)
+
+var (
+	// Version pins the emoji version of emoji-data.txt (such as "15.1"). An empty Version tracks the latest file.
+	Version string
+)
+
+var (
+	codepoints *Codepoints
+	loaded     error
+	once       sync.Once
+)
+
+// Address returns the URL of the emoji-data.txt file of the pinned Version.
+func Address() string {
+	if len(Version) == 0 {
+		return URL
+	}
+	if major, err := strconv.Atoi(strings.Split(Version, ".")[0]); err == nil && major < 13 {
+		return fmt.Sprintf(legacy, Version)
+	}
+	return fmt.Sprintf(ucd, Version)
+}
+
+// HTTP requests the emoji-data.txt file from unicode.org.
+func HTTP() (*http.Response, error) {
+	return client.Fetch(Address())
+}
+
+// Open attempts to open and parse the emoji-data.txt file from the emojipedia/emojidata folder.
+func Open() (*Properties, error) {
+	reader, err := os.Open(filepath.Join(directory.Emojidata, filename))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	properties := &Properties{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i != -1 {
+			line = line[:i]
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) != 2 {
+			continue
+		}
+		lo, hi, err := parseRange(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, err
+		}
+		properties.Add(strings.TrimSpace(fields[1]), lo, hi)
+	}
+	return properties, scanner.Err()
+}
+
+// Remove deletes the emoji-data.txt file, its derived properties and the emoji sequence files stored in the dependencies folder.
+func Remove() error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	return store.Trash(directory.Emojidata)
+}
+
+// Write stores the body of the emoji-data.txt HTTP response to the dependencies folder,
+// along with the Codepoints derived from it.
+func Write(resp *http.Response) error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(directory.Emojidata, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(filepath.Join(directory.Emojidata, filename), content, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	properties, err := Open()
+	if err != nil {
+		return err
+	}
+	content, err = store.Marshal(properties.Derive())
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(directory.Emojidata, derived), content, os.ModePerm)
+}
+
+// OpenCodepoints attempts to open the Codepoints derived from the emoji-data.txt file when it was stored.
+// Datasets stored before the Codepoints were kept derive them from the emoji-data.txt file itself.
+func OpenCodepoints() (*Codepoints, error) {
+	file := filepath.Join(directory.Emojidata, derived)
+	content, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		properties, err := Open()
+		if err != nil {
+			return nil, err
+		}
+		codepoints := properties.Derive()
+		return &codepoints, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	codepoints := &Codepoints{}
+	if err = store.Decode(file, content, codepoints); err != nil {
+		return nil, err
+	}
+	return codepoints, nil
+}
+
+// IsEmoji checks whether the rune carries the Emoji property.
+func IsEmoji(r rune) bool {
+	return load().Is(Emoji, r)
+}
+
+// IsEmojiPresentation checks whether the string renders as an emoji by default,
+// either because its leading rune has the Emoji_Presentation property or
+// because it is an Emoji rune explicitly followed by the emoji variation selector.
+func IsEmojiPresentation(s string) bool {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return false
+	}
+	p := load()
+	if len(runes) > 1 {
+		switch runes[1] {
+		case TextSelector:
+			return false
+		case EmojiSelector:
+			return p.Is(Emoji, runes[0])
+		}
+	}
+	return p.Is(EmojiPresentation, runes[0])
+}
+
+// IsExtendedPictographic checks whether the rune carries the Extended_Pictographic property,
+// the property that lets a rune follow a ZWJ within a single emoji.
+func IsExtendedPictographic(r rune) bool {
+	return load().Is(ExtendedPictographic, r)
+}
+
+// IsModifierBase checks whether the rune can be followed by a skin tone modifier.
+func IsModifierBase(r rune) bool {
+	return load().Is(EmojiModifierBase, r)
+}
+
+// IsComponent checks whether the rune is used as part of an emoji sequence
+// (keycap bases, regional indicators, skin tones, hair styles, ZWJ and tags).
+func IsComponent(r rune) bool {
+	return load().Is(EmojiComponent, r)
+}
+
+// RequiresVariation checks whether the rune defaults to text presentation
+// and so needs VS16 appended to be displayed as an emoji.
+func RequiresVariation(r rune) bool {
+	p := load()
+	return p.Is(Emoji, r) && p.Is(EmojiPresentation, r) == false
+}
+
+// Normalize strips every variation selector from the string, giving a canonical form for comparisons and lookups.
+func Normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == TextSelector || r == EmojiSelector {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// Presentation rewrites the string for emoji presentation, removing VS15
+// and appending VS16 after every rune that would otherwise render as text.
+// Keycap bases only receive VS16 when they begin a keycap sequence and
+// runes followed by a skin tone modifier are left untouched.
+func Presentation(s string) string {
+	var (
+		b     = strings.Builder{}
+		p     = load()
+		runes = []rune(Normalize(s))
+	)
+	for i, r := range runes {
+		var next rune
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+		b.WriteRune(r)
+		if RequiresVariation(r) == false {
+			continue
+		}
+		if p.Is(EmojiComponent, r) && next != Keycap {
+			continue
+		}
+		if p.Is(EmojiModifier, next) {
+			continue
+		}
+		b.WriteRune(EmojiSelector)
+	}
+	return b.String()
+}
+
+// Reset discards the loaded properties, sequences, qualifications, versions and characters so the next predicate reads the stored files again.
+func Reset() {
+	once, codepoints, loaded = sync.Once{}, nil, nil
+	named, database = sync.Once{}, nil
+	sequenced, sequences = sync.Once{}, nil
+	qualified, qualifications = sync.Once{}, nil
+	dated, versions = sync.Once{}, nil
+}
+
+// Available returns the error the stored Codepoints could not be opened with, wrapping store.ErrMissingUnicodeFile
+// when emoji-data.txt has not been built, in which case the predicates report false for every rune.
+func Available() error {
+	load()
+	return loaded
+}
+
+// load opens the stored Codepoints once. Predicates report false for every rune if the file has not been built.
+func load() *Codepoints {
+	once.Do(func() {
+		c, err := OpenCodepoints()
+		if err != nil {
+			c, loaded = &Codepoints{}, store.Missing(err, store.ErrMissingUnicodeFile)
+		}
+		codepoints = c
+	})
+	return codepoints
+}
+
+func parseRange(s string) (rune, rune, error) {
+	bounds := strings.SplitN(s, "..", 2)
+	lo, err := strconv.ParseUint(bounds[0], 16, 32)
+	if err != nil {
+		return 0, 0, err
+	}
+	hi := lo
+	if len(bounds) == 2 {
+		hi, err = strconv.ParseUint(bounds[1], 16, 32)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return rune(lo), rune(hi), nil
+}
+
+// Range is an inclusive span of code points sharing a property.
+type Range struct {
+	Lo rune
+	Hi rune
+}
+
+// Properties maps a Unicode emoji property name to the code point ranges that carry it.
+type Properties map[string][]Range
+
+// Add method appends a code point range to the named property.
+func (pointer *Properties) Add(property string, lo, hi rune) *Properties {
+	(*pointer)[property] = append((*pointer)[property], Range{Lo: lo, Hi: hi})
+	return pointer
+}
+
+// Derive method returns the Codepoints of every rune within a range of the Properties
+// the predicates are answered from. Other properties are dropped.
+func (pointer *Properties) Derive() Codepoints {
+	codepoints := Codepoints{}
+	for property, ranges := range *pointer {
+		bit, ok := bits[property]
+		if ok == false {
+			continue
+		}
+		for _, x := range ranges {
+			for r := x.Lo; r <= x.Hi; r++ {
+				codepoints[r] = codepoints[r] | bit
+			}
+		}
+	}
+	return codepoints
+}
+
+// Is method checks whether the rune falls within any range of the named property.
+func (pointer *Properties) Is(property string, r rune) bool {
+	for _, x := range (*pointer)[property] {
+		if r >= x.Lo && r <= x.Hi {
+			return true
+		}
+	}
+	return false
+}
+
+// Property is a set of the emoji properties carried by a rune, one bit for each.
+type Property uint8
+
+var (
+	bits = map[string]Property{
+		Emoji:                1 << 0,
+		EmojiComponent:       1 << 1,
+		EmojiModifier:        1 << 2,
+		EmojiModifierBase:    1 << 3,
+		EmojiPresentation:    1 << 4,
+		ExtendedPictographic: 1 << 5}
+)
+
+// Codepoints maps each rune carrying an emoji property to its Property, so predicates are answered with one lookup
+// rather than a search of the ranges of the Properties.
+type Codepoints map[rune]Property
+
+// Is method checks whether the rune carries the named property.
+func (pointer *Codepoints) Is(property string, r rune) bool {
+	bit, ok := bits[property]
+	return ok && (*pointer)[r]&bit != 0
+}
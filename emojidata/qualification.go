@@ -0,0 +1,123 @@
+package emojidata
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gellel/emojipedia/directory"
+)
+
+const (
+	// QualificationFile is the UTS #51 file giving the qualification status of every emoji sequence.
+	QualificationFile string = "emoji-test.txt"
+)
+
+const (
+	// Component is the status of the skin tones and hair styles that only appear within other emoji.
+	Component string = "component"
+	// FullyQualified is the status of the sequences recommended for general interchange.
+	FullyQualified string = "fully-qualified"
+	// MinimallyQualified is the status of sequences missing some, but not the first, of their variation selectors.
+	MinimallyQualified string = "minimally-qualified"
+	// Unqualified is the status of sequences missing the variation selector of their first character.
+	Unqualified string = "unqualified"
+)
+
+var (
+	// Files are the UTS #51 files stored beside emoji-data.txt.
+	Files = append(append([]string{}, SequenceFiles...), QualificationFile)
+)
+
+var (
+	qualifications *Qualifications
+	qualified      sync.Once
+)
+
+// OpenQualifications attempts to open and parse the emoji-test.txt file from the emojipedia/emojidata folder.
+func OpenQualifications() (*Qualifications, error) {
+	qualifications := &Qualifications{}
+	return qualifications, openTest(func(sequence, status, _ string) {
+		qualifications.Add(sequence, status)
+	})
+}
+
+// Qualify returns the qualification status of the sequence, such as FullyQualified,
+// or an empty string if the sequence is not listed or emoji-test.txt has not been built.
+func Qualify(s string) string {
+	status, _ := loadQualifications().Get(s)
+	return status
+}
+
+// loadQualifications opens the stored Qualifications once.
+func loadQualifications() *Qualifications {
+	qualified.Do(func() {
+		q, err := OpenQualifications()
+		if err != nil {
+			q = &Qualifications{}
+		}
+		qualifications = q
+	})
+	return qualifications
+}
+
+// openTest calls the argument function with the sequence, qualification status and emoji version of each
+// "code points ; status # emoji version name" line of the stored emoji-test.txt file.
+func openTest(f func(sequence, status, version string)) error {
+	reader, err := os.Open(filepath.Join(directory.Emojidata, QualificationFile))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return parseTest(reader, f)
+}
+
+// parseTest reads the lines of an emoji-test.txt file for openTest.
+func parseTest(reader io.Reader, f func(sequence, status, version string)) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		var (
+			line    = scanner.Text()
+			version string
+		)
+		if i := strings.Index(line, "#"); i != -1 {
+			if comment := strings.Fields(line[i+1:]); len(comment) > 1 && strings.HasPrefix(comment[1], "E") {
+				version = strings.TrimPrefix(comment[1], "E")
+			}
+			line = line[:i]
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) != 2 {
+			continue
+		}
+		runes := []rune{}
+		for _, code := range strings.Fields(fields[0]) {
+			r, err := strconv.ParseUint(code, 16, 32)
+			if err != nil {
+				return err
+			}
+			runes = append(runes, rune(r))
+		}
+		f(string(runes), strings.TrimSpace(fields[1]), version)
+	}
+	return scanner.Err()
+}
+
+// Qualifications maps each emoji sequence listed by emoji-test.txt to its qualification status.
+type Qualifications map[string]string
+
+// Add method records the qualification status of the sequence.
+func (pointer *Qualifications) Add(sequence, status string) *Qualifications {
+	(*pointer)[sequence] = status
+	return pointer
+}
+
+// Get method returns the qualification status of the sequence and a boolean indicating if it was found.
+func (pointer *Qualifications) Get(sequence string) (string, bool) {
+	status, ok := (*pointer)[sequence]
+	return status, ok
+}
@@ -0,0 +1,184 @@
+package emojidata
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/store"
+)
+
+const (
+	// BasicEmoji is the type of the single code points (and their VS16 forms) recommended for general interchange.
+	BasicEmoji string = "Basic_Emoji"
+	// KeycapSequence is the type of the keycap sequences recommended for general interchange.
+	KeycapSequence string = "Emoji_Keycap_Sequence"
+	// FlagSequence is the type of the regional indicator flags recommended for general interchange.
+	FlagSequence string = "RGI_Emoji_Flag_Sequence"
+	// ModifierSequence is the type of the skin tone sequences recommended for general interchange.
+	ModifierSequence string = "RGI_Emoji_Modifier_Sequence"
+	// TagSequence is the type of the tag sequences (subdivision flags) recommended for general interchange.
+	TagSequence string = "RGI_Emoji_Tag_Sequence"
+	// ZWJSequence is the type of the ZWJ sequences recommended for general interchange.
+	ZWJSequence string = "RGI_Emoji_ZWJ_Sequence"
+)
+
+const (
+	latest    string = "https://www.unicode.org/Public/emoji/latest/%s"
+	versioned string = "https://www.unicode.org/Public/emoji/%s/%s"
+)
+
+var (
+	// SequenceFiles are the UTS #51 files listing the emoji sequences recommended for general interchange (RGI).
+	SequenceFiles = []string{"emoji-sequences.txt", "emoji-zwj-sequences.txt"}
+)
+
+var (
+	sequenced sync.Once
+	sequences *Sequences
+)
+
+// SequenceAddress returns the URL of the named sequence file of the pinned Version.
+func SequenceAddress(file string) string {
+	if len(Version) == 0 {
+		return fmt.Sprintf(latest, file)
+	}
+	return fmt.Sprintf(versioned, Version, file)
+}
+
+// HTTPSequence requests the named sequence file from unicode.org.
+func HTTPSequence(file string) (*http.Response, error) {
+	return client.Fetch(SequenceAddress(file))
+}
+
+// OpenSequences attempts to open and parse the sequence files stored in the emojipedia/emojidata folder.
+// Files that have not been stored are skipped.
+func OpenSequences() (*Sequences, error) {
+	sequences := &Sequences{}
+	for _, file := range SequenceFiles {
+		reader, err := os.Open(filepath.Join(directory.Emojidata, file))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		err = parseSequences(reader, sequences)
+		reader.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sequences, nil
+}
+
+// WriteSequence stores the body of the named sequence file HTTP response to the dependencies folder.
+func WriteSequence(file string, resp *http.Response) error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(directory.Emojidata, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(directory.Emojidata, file), content, os.ModePerm)
+}
+
+// IsRGI checks whether the string is an emoji sequence recommended for general interchange, as written or
+// once qualified for emoji presentation. Sequences that are not RGI, such as ZWJ sequences of arbitrary
+// emoji, are usually displayed as their parts. Every string is reported as RGI if no sequence file has been
+// stored, so callers keep treating sequences as whole emoji until it is.
+func IsRGI(s string) bool {
+	p := loadSequences()
+	if len(*p) == 0 {
+		return true
+	}
+	return p.Has(s) || p.Has(Presentation(s))
+}
+
+// loadSequences opens the stored Sequences once.
+func loadSequences() *Sequences {
+	sequenced.Do(func() {
+		s, err := OpenSequences()
+		if err != nil {
+			s = &Sequences{}
+		}
+		sequences = s
+	})
+	return sequences
+}
+
+// parseSequences adds the sequences of each "code points ; type ; description" line of a sequence file.
+// Ranges of single code points are expanded into a sequence for each.
+func parseSequences(reader io.Reader, sequences *Sequences) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i != -1 {
+			line = line[:i]
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) < 2 {
+			continue
+		}
+		var (
+			codes = strings.TrimSpace(fields[0])
+			kind  = strings.TrimSpace(fields[1])
+		)
+		if strings.Contains(codes, "..") {
+			lo, hi, err := parseRange(codes)
+			if err != nil {
+				return err
+			}
+			for r := lo; r <= hi; r++ {
+				sequences.Add(string(r), kind)
+			}
+			continue
+		}
+		runes := []rune{}
+		for _, code := range strings.Fields(codes) {
+			r, err := strconv.ParseUint(code, 16, 32)
+			if err != nil {
+				return err
+			}
+			runes = append(runes, rune(r))
+		}
+		sequences.Add(string(runes), kind)
+	}
+	return scanner.Err()
+}
+
+// Sequences maps each emoji sequence recommended for general interchange to its type, such as ZWJSequence.
+type Sequences map[string]string
+
+// Add method records the sequence under its type.
+func (pointer *Sequences) Add(sequence, kind string) *Sequences {
+	(*pointer)[sequence] = kind
+	return pointer
+}
+
+// Get method returns the type of the sequence and a boolean indicating if it was found.
+func (pointer *Sequences) Get(sequence string) (string, bool) {
+	kind, ok := (*pointer)[sequence]
+	return kind, ok
+}
+
+// Has method checks that the sequence is recommended for general interchange.
+func (pointer *Sequences) Has(sequence string) bool {
+	_, ok := (*pointer)[sequence]
+	return ok
+}
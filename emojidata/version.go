@@ -0,0 +1,132 @@
+package emojidata
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// Releases are the dates each emoji version was released on, from the Unicode versions that first encoded
+	// emoji (E0.6 and E0.7) to the latest emoji version.
+	Releases = map[string]time.Time{
+		"0.6":  date(2010, 10, 11),
+		"0.7":  date(2014, 6, 16),
+		"1.0":  date(2015, 6, 9),
+		"2.0":  date(2015, 11, 12),
+		"3.0":  date(2016, 6, 3),
+		"4.0":  date(2016, 11, 22),
+		"5.0":  date(2017, 6, 20),
+		"11.0": date(2018, 5, 21),
+		"12.0": date(2019, 3, 5),
+		"12.1": date(2019, 10, 21),
+		"13.0": date(2020, 3, 10),
+		"13.1": date(2020, 9, 15),
+		"14.0": date(2021, 9, 14),
+		"15.0": date(2022, 9, 13),
+		"15.1": date(2023, 9, 12),
+		"16.0": date(2024, 9, 10),
+		"17.0": date(2025, 9, 9)}
+)
+
+var (
+	// encoded are the emoji versions of the emoji first encoded by each Unicode version before emoji versions
+	// followed the Unicode version, from 11.0.
+	encoded = map[string]string{
+		"6.0":  "0.6",
+		"6.1":  "0.6",
+		"7.0":  "0.7",
+		"8.0":  "1.0",
+		"9.0":  "3.0",
+		"10.0": "5.0"}
+)
+
+var (
+	dated    sync.Once
+	versions *Versions
+)
+
+// Added returns the emoji version (such as "15.1") that introduced the sequence,
+// or an empty string if the sequence is not listed or emoji-test.txt has not been built.
+func Added(s string) string {
+	dated.Do(func() {
+		v, err := OpenVersions()
+		if err != nil {
+			v = &Versions{}
+		}
+		versions = v
+	})
+	version, _ := versions.Get(s)
+	return version
+}
+
+// Encoded returns the emoji version of the emoji first encoded by the Unicode version (such as "0.7" for "7.0"),
+// as sources such as gemoji record the Unicode version of an emoji rather than its emoji version.
+func Encoded(unicode string) string {
+	if version, ok := encoded[unicode]; ok {
+		return version
+	}
+	return unicode
+}
+
+// Compare returns -1, 0 or 1 as the emoji version a is older than, the same as or newer than the emoji version b,
+// comparing each dotted number in turn so that "15.1" is newer than "5.0".
+func Compare(a, b string) int {
+	var (
+		x = strings.Split(a, ".")
+		y = strings.Split(b, ".")
+	)
+	for i := 0; i < len(x) || i < len(y); i++ {
+		var m, n int
+		if i < len(x) {
+			m, _ = strconv.Atoi(x[i])
+		}
+		if i < len(y) {
+			n, _ = strconv.Atoi(y[i])
+		}
+		switch {
+		case m < n:
+			return -1
+		case m > n:
+			return 1
+		}
+	}
+	return 0
+}
+
+// OpenVersions attempts to open the emoji versions of the sequences listed by the stored emoji-test.txt file.
+func OpenVersions() (*Versions, error) {
+	versions := &Versions{}
+	return versions, openTest(func(sequence, _, version string) {
+		if len(version) != 0 {
+			versions.Add(sequence, version)
+		}
+	})
+}
+
+// Released returns the date the emoji version was released on and a boolean indicating if it is known.
+func Released(version string) (time.Time, bool) {
+	released, ok := Releases[version]
+	return released, ok
+}
+
+// date returns midnight UTC of the day.
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Versions maps each emoji sequence listed by emoji-test.txt to the emoji version that introduced it.
+type Versions map[string]string
+
+// Add method records the emoji version of the sequence.
+func (pointer *Versions) Add(sequence, version string) *Versions {
+	(*pointer)[sequence] = version
+	return pointer
+}
+
+// Get method returns the emoji version of the sequence and a boolean indicating if it was found.
+func (pointer *Versions) Get(sequence string) (string, bool) {
+	version, ok := (*pointer)[sequence]
+	return version, ok
+}
@@ -1,23 +1,80 @@
 package main
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/daemon"
+	"github.com/gellel/emojipedia/emoji"
 	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/history"
+	"github.com/gellel/emojipedia/pattern"
+	"github.com/gellel/emojipedia/sentiment"
 	"github.com/gellel/emojipedia/slice"
 	"github.com/gellel/emojipedia/stdin"
+	"github.com/gellel/emojipedia/store"
 	"github.com/gellel/emojipedia/text"
 )
 
 func emojipediaGet(arguments *arguments.Arguments) {
+	// "--stdin" reads one name per line after the names given, so scripts resolve many emoji in one invocation.
+	if _, ok := arguments.Flag("stdin"); ok {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			if name := strings.TrimSpace(scanner.Text()); len(name) != 0 {
+				arguments.Append(name)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, "stdin", err), err)
+		}
+	}
+	as, _ := arguments.Flag("as")
+	as = strings.ToLower(as)
+	if as != "" && as != "json" && as != "ndjson" {
+		failWith(usage, fmt.Sprintf(errorAs, as), ErrFormat)
+	}
 	var (
-		emojipedia = emojipedia.Get()
+		emojipedia = warm(daemon.Get, arguments)
+		found      = []*emoji.Emoji{}
+		unknown    = []string{}
+		used       = []string{}
 	)
-	fmt.Fprintln(writer, "\t|Name\t|Number\t|Category\t|Subcategory\t|Keywords")
 	arguments.Each(func(_ int, argument string) {
 		if emoji, ok := emojipedia.Get(argument); ok {
+			found = append(found, emoji)
+			used = append(used, emoji.ID)
+		} else if strings.HasPrefix(argument, "--") == false {
+			unknown = append(unknown, argument)
+		}
+	})
+	switch as {
+	case "json":
+		content, err := store.Marshal(found)
+		if err != nil {
+			fail(fmt.Sprintf(errorCannotWrite, "stdout", err), err)
+		}
+		fmt.Println(string(content))
+	case "ndjson":
+		// Every emoji is one line of json, so --indent does not apply.
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		for _, emoji := range found {
+			if err := encoder.Encode(emoji); err != nil {
+				fail(fmt.Sprintf(errorCannotWrite, "stdout", err), err)
+			}
+		}
+	default:
+		// Related emoji are scored against every stored emoji, which are read once for the whole table.
+		all := stored()
+		fmt.Fprintln(writer, "\t|Name\t|Number\t|Category\t|Subcategory\t|Keywords\t|Related")
+		for _, emoji := range found {
 			var (
 				character   = text.Emojize(emoji.Unicode)
 				name        = emoji.Name
@@ -25,41 +82,107 @@ func emojipediaGet(arguments *arguments.Arguments) {
 				category    = emoji.Category
 				subcategory = emoji.Subcategory
 				keywords    = emoji.Keywords.Sort().Join(" ")
-				output      = fmt.Sprintf("%v\t|%v\t|%v\t|%v\t|%v\t|%v", character, name, number, category, subcategory, keywords)
+				related     = all.Related(emoji.ID, 5).Join(" ")
+				output      = fmt.Sprintf("%v\t|%v\t|%v\t|%v\t|%v\t|%v\t|%v", character, name, number, category, subcategory, keywords, related)
 			)
 			fmt.Fprintln(writer, output)
 		}
-	})
-	writer.Flush()
+		writer.Flush()
+	}
+	if err := history.Record(used...); err != nil {
+		fmt.Fprintln(os.Stderr, fmt.Sprintf(errorHistory, err))
+	}
+	if len(as) != 0 {
+		notFoundStderr(unknown, E, EMOJIPEDIA)
+	}
+	notFound(unknown, E, EMOJIPEDIA)
 }
 
 func emojipediaKeys(arguments *arguments.Arguments) {
 	var (
 		emojipedia = emojipedia.Get()
+		n          = 0
+		patterns   = patternsOf(arguments)
 	)
 	fmt.Fprintln(writer, "N\t|Name")
-	emojipedia.Keys().Sort().Each(func(i int, x interface{}) {
-		fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v", i, x.(string)))
+	emojipedia.Keys().Sort().Each(func(_ int, x interface{}) {
+		if pattern.AnyEmoji(patterns, emojipedia.Fetch(x.(string))) {
+			fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v", n, x.(string)))
+			n++
+		}
 	})
 	writer.Flush()
 }
 
-func emojipediaList(arguments *arguments.Arguments) {
+// emojipediaRemove removes the emoji matching the patterns of the arguments from the emojipedia and from the categories,
+// subcategories, keywords and weights referencing them, or the whole emojipedia when given no patterns and no --regex.
+// The dataset lock is taken before the emojipedia is read, as emojipedia remove always writes.
+func emojipediaRemove(arguments *arguments.Arguments) {
+	_, regex := arguments.Flag("regex")
+	patterns := patternsOf(arguments)
+	switch {
+	case len(patterns) == 0 && regex:
+		failWith(usage, fmt.Sprintf(errorPattern, "", "give the expressions of the emoji to remove"), ErrMissingArgument)
+	case len(patterns) == 0:
+		remove(EMOJIPEDIA, emojipedia.Remove)
+		return
+	}
 	var (
 		emojipedia = emojipedia.Get()
+		removed    = []*emoji.Emoji{}
 	)
-	fmt.Fprintln(writer, "Name\t|Number\t|Category\t|Subcategory\t|Keywords")
+	emojipedia.Keys().Sort().Each(func(_ int, x interface{}) {
+		if e := emojipedia.Fetch(x.(string)); pattern.AnyEmoji(patterns, e) {
+			removed = append(removed, e)
+		}
+	})
+	if err := relink(removed, nil); err != nil {
+		fail(fmt.Sprintf(errorRemovePackage, strings.ToLower(EMOJIPEDIA), err), err)
+	}
+	for _, e := range removed {
+		key := e.Name
+		if store.ByID {
+			key = e.ID
+		}
+		if err := emoji.Remove(key); err != nil {
+			fail(fmt.Sprintf(errorRemovePackage, e.Name, err), err)
+		}
+		fmt.Println(e.Name)
+	}
+	fmt.Println(fmt.Sprintf(successRemoveEmoji, len(removed)))
+}
+
+func emojipediaList(arguments *arguments.Arguments) {
+	var (
+		emojipedia  = emojipedia.Get()
+		_, rgi      = arguments.Flag("rgi")
+		_, variants = arguments.Flag("variants")
+	)
+	row := func(emoji *emoji.Emoji, name string) {
+		count := 0
+		if emoji.Variants != nil {
+			count = emoji.Variants.Len()
+		}
+		fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v\t|%v\t|%v\t|%v\t|%v", name, emoji.Number, emoji.Category, emoji.Subcategory, emoji.Keywords.Len(), count))
+	}
+	fmt.Fprintln(writer, "Name\t|Number\t|Category\t|Subcategory\t|Keywords\t|Variants")
 	emojipedia.Keys().Sort().Each(func(_ int, i interface{}) {
-		var (
-			emoji       = emojipedia.Fetch(i.(string))
-			name        = emoji.Name
-			number      = emoji.Number
-			category    = emoji.Category
-			subcategory = emoji.Subcategory
-			keywords    = emoji.Keywords.Len()
-			output      = fmt.Sprintf("%v\t|%v\t|%v\t|%v\t|%v", name, number, category, subcategory, keywords)
-		)
-		fmt.Fprintln(writer, output)
+		emoji := emojipedia.Fetch(i.(string))
+		if emojipedia.Has(emoji.Base) && len(emoji.Base) != 0 {
+			return
+		}
+		if rgi && emoji.RGI == false {
+			return
+		}
+		row(emoji, emoji.Name)
+		if variants == false || emoji.Variants == nil {
+			return
+		}
+		emoji.Variants.Each(func(_ int, i interface{}) {
+			if variant, ok := emojipedia.Get(i.(string)); ok && (rgi == false || variant.RGI) {
+				row(variant, "  "+variant.Name)
+			}
+		})
 	})
 	writer.Flush()
 }
@@ -73,10 +196,51 @@ func emojipediaNumber(arguments *arguments.Arguments) {
 	writer.Flush()
 }
 
+func emojipediaSentiment(arguments *arguments.Arguments) {
+	path := arguments.Get(0)
+	if path == "" {
+		path = sentiment.URL
+	}
+	if err := store.Writable(); err != nil {
+		fail(fmt.Sprintf(errorBuildPackage, strings.ToLower(SENTIMENT), err), err)
+	}
+	lock()
+	var (
+		emojipedia = emojipedia.Get()
+		fetched    = time.Now()
+		n          int
+	)
+	fmt.Println(fmt.Sprintf(statusBuildPackage, strings.ToLower(SENTIMENT)))
+	lexicon, err := sentiment.Open(path)
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, path, err), err)
+	}
+	lexicon.Each(func(character string, i interface{}) {
+		if e, ok := emojipedia.Glyph(character); ok {
+			e.SetSentiment(i.(*sentiment.Sentiment)).Record("sentiment", sentiment.Name, fetched)
+			if err := emoji.Write(e); err == nil {
+				n++
+			}
+		}
+	})
+	fmt.Println(fmt.Sprintf("tagged %v emoji with sentiment", n))
+}
+
 func emojipediaMain(arguments *arguments.Arguments) {
-	switch strings.ToUpper(arguments.Get(0)) {
+	switch command(arguments, BUILD, GET, KEYS, LIST, NUMBER, REMOVE, SENTIMENT, VALIDATE) {
 	case B, BUILD:
-		build(EMOJIPEDIA, emojipedia.Make)
+		switch {
+		case sourced(GEMOJI) && sourced(UNICODE) == false:
+			buildGemoji()
+		case parser == STREAM:
+			buildStream(EMOJIPEDIA, emojipedia.Stream)
+		default:
+			build(EMOJIPEDIA, func(document *goquery.Document) {
+				if err := emojipedia.Make(document); err != nil {
+					fail(fmt.Sprintf(errorBuildPackage, EMOJIPEDIA, err), err)
+				}
+			})
+		}
 	case G, GET:
 		emojipediaGet(arguments.Next())
 	case K, KEYS:
@@ -86,7 +250,11 @@ func emojipediaMain(arguments *arguments.Arguments) {
 	case N, NUMBER:
 		emojipediaNumber(arguments.Next())
 	case R, REMOVE:
-		remove(EMOJIPEDIA, emojipedia.Remove)
+		emojipediaRemove(arguments.Next())
+	case S, SENTIMENT:
+		emojipediaSentiment(arguments.Next())
+	case V, VALIDATE:
+		validateCLDR(strings.ToUpper(arguments.Next().Get(0)) == PREFER)
 	default:
 		var (
 			b = stdin.Arg{
@@ -94,15 +262,20 @@ func emojipediaMain(arguments *arguments.Arguments) {
 				Short:   B,
 				Verbose: BUILD}
 			g = stdin.Arg{
-				About:   "get one or more emoji",
+				About:   "get one or more emoji, reading one name per line with --stdin and printing them --as=json|ndjson",
+				Args:    "<emoji> [...<emoji>] [--stdin] [--as=json|ndjson]",
+				Example: "emojipedia emojipedia get grinning-face cat-face --as=json",
 				Short:   G,
 				Verbose: GET}
 			k = stdin.Arg{
-				About:   "show available emoji choices",
+				About:   "show available emoji choices, or those whose name, keywords or shortcodes match a glob [--regex]",
+				Args:    "[pattern] [...<pattern>]",
+				Example: "emojipedia emojipedia keys 'heart*'",
 				Short:   K,
 				Verbose: KEYS}
 			l = stdin.Arg{
-				About:   "iterate and show the available emoji information",
+				About:   "iterate and show the available emoji information, one entry per base emoji",
+				Example: "emojipedia emojipedia list --variants --rgi",
 				Short:   L,
 				Verbose: LIST}
 			n = stdin.Arg{
@@ -110,23 +283,35 @@ func emojipediaMain(arguments *arguments.Arguments) {
 				Short:   N,
 				Verbose: NUMBER}
 			r = stdin.Arg{
-				About:   "remove the emojipedia (all)",
+				About:   "remove the emojipedia (all), or the emoji matching a glob [--regex]",
+				Args:    "[pattern] [...<pattern>]",
+				Example: "emojipedia emojipedia remove 'flag-*'",
 				Short:   R,
 				Verbose: REMOVE}
+			s = stdin.Arg{
+				About:   "tag emoji with an emoji sentiment ranking csv (file or url)",
+				Short:   S,
+				Verbose: SENTIMENT}
+			v = stdin.Arg{
+				About:   "compare emoji names with their cldr short names [prefer to rename them]",
+				Short:   V,
+				Verbose: VALIDATE}
 		)
 		fmt.Fprintln(writer, "usage: emojipedia [-e emojipedia] [<option>] [--flags]")
 		fmt.Fprintln(writer)
 		fmt.Fprintln(writer, "installing emojipedia")
 		fmt.Fprintln(writer, b)
+		fmt.Fprintln(writer, s)
 		fmt.Fprintln(writer)
 		fmt.Fprintln(writer, "removing emojipedia")
 		fmt.Fprintln(writer, r)
 		fmt.Fprintln(writer)
 		fmt.Fprintln(writer, "options that support flags")
-		slice.New(g, k, l, n).Each(func(_ int, i interface{}) {
+		slice.New(g, k, l, n, v).Each(func(_ int, i interface{}) {
 			fmt.Fprintln(writer, i.(stdin.Arg))
 		})
 		fmt.Fprintln(writer)
 		writer.Flush()
+		unknown(arguments)
 	}
 }
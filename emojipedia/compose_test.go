@@ -0,0 +1,29 @@
+package emojipedia
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestComposeKeycaps checks that every keycap of emoji-test.txt is stored with its fully-qualified codes,
+// whether the chart lists the sequence with or without VS16.
+func TestComposeKeycaps(t *testing.T) {
+	for _, base := range "#*0123456789" {
+		var (
+			code = fmt.Sprintf("%04X", base)
+			want = "U+" + code + " U+FE0F U+20E3"
+		)
+		for _, codes := range [][]string{{"U+" + code, "U+FE0F", "U+20E3"}, {"U+" + code, "U+20E3"}} {
+			e := compose(&fields{codes: codes, names: []string{"keycap: " + string(base)}})
+			if e == nil {
+				t.Fatalf("compose(%v) = nil", codes)
+			}
+			if got := e.Codes.Join(" "); got != want {
+				t.Errorf("compose(%v).Codes = %q, want %q", codes, got, want)
+			}
+			if e.Variation == false {
+				t.Errorf("compose(%v).Variation = false", codes)
+			}
+		}
+	}
+}
@@ -1,30 +1,126 @@
 package emojipedia
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
-	"os"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/gellel/emojipedia/analyzer"
+	"github.com/gellel/emojipedia/conflict"
 	"github.com/gellel/emojipedia/directory"
 	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojidata"
+	"github.com/gellel/emojipedia/history"
+	"github.com/gellel/emojipedia/index"
+	"github.com/gellel/emojipedia/keycap"
 	"github.com/gellel/emojipedia/lexicon"
+	"github.com/gellel/emojipedia/locale"
 	"github.com/gellel/emojipedia/pkg"
+	"github.com/gellel/emojipedia/segment"
+	"github.com/gellel/emojipedia/sentiment"
 	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/store"
 	"github.com/gellel/emojipedia/text"
+	"github.com/gellel/emojipedia/user"
+	"github.com/gellel/emojipedia/weights"
 )
 
 var _ emojipedia = (*Emojipedia)(nil)
 
+const (
+	// exact is the score of an Emoji matched by its ID, name or character, above any sum of term weights.
+	exact = math.MaxFloat64
+	// partial is the score of an Emoji whose name merely contains the search term, below any term weight.
+	partial = math.SmallestNonzeroFloat64
+	// favored multiplies the score of an Emoji that is a user.User favorite, short of an exact match.
+	favored = 2
+	// writers is the number of emoji files Store writes at once.
+	writers = 16
+)
+
+const (
+	// Batch is the number of Emoji Resume stores between calls to its progress function.
+	Batch = 100
+)
+
+var (
+	// Stamp returns a value that changes whenever the dataset does. When it is set, Open and OpenLocale keep the
+	// Emojipedia of each profile and locale in memory until it changes, so a long running process such as the shell
+	// reads the dataset once rather than for every command.
+	Stamp func() string
+)
+
+var (
+	kept = &cache{opened: map[string]*Emojipedia{}}
+)
+
+// cache holds the Emojipedia Open and OpenLocale keep while Stamp returns the same value.
+type cache struct {
+	mutex  sync.Mutex
+	opened map[string]*Emojipedia
+	stamp  string
+}
+
+// get method returns the Emojipedia kept for the key, opening and keeping it with the argument function when
+// none is kept or the dataset changed since it was. Without a Stamp every call opens the Emojipedia.
+func (pointer *cache) get(key string, open func() (*Emojipedia, error)) (*Emojipedia, error) {
+	if Stamp == nil {
+		return open()
+	}
+	stamp := Stamp()
+	pointer.mutex.Lock()
+	if stamp != pointer.stamp {
+		pointer.opened, pointer.stamp = map[string]*Emojipedia{}, stamp
+	}
+	emojipedia, ok := pointer.opened[key]
+	pointer.mutex.Unlock()
+	if ok {
+		return emojipedia, nil
+	}
+	emojipedia, err := open()
+	if err != nil {
+		return nil, err
+	}
+	pointer.mutex.Lock()
+	pointer.opened[key] = emojipedia
+	pointer.mutex.Unlock()
+	return emojipedia, nil
+}
+
+// Errors is returned by Store with the error of each Emoji that failed to be written, by emoji name.
+type Errors map[string]error
+
+// Error method lists every Emoji that failed to be written and why, by name.
+func (failures Errors) Error() string {
+	names := []string{}
+	for name := range failures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		names[i] = fmt.Sprintf("%s (%s)", name, failures[name])
+	}
+	return fmt.Sprintf("cannot write %v emoji: %s", len(names), strings.Join(names, ", "))
+}
+
 // New instantiates a new empty Emojipedia pointer.
 func New() *Emojipedia {
-	return &Emojipedia{&lexicon.Lexicon{}}
+	return &Emojipedia{lexicon: &lexicon.Lexicon{}, names: &lexicon.Lexicon{}}
 }
 
 // NewEmojipedia creates a new Emojipedia pointer, accepting zero or more emoji.Emoji pointers as arguments.
 func NewEmojipedia(emoji ...*emoji.Emoji) *Emojipedia {
-	emojipedia := &Emojipedia{&lexicon.Lexicon{}}
+	emojipedia := New()
 	for _, emoji := range emoji {
 		emojipedia.Add(emoji)
 	}
@@ -40,6 +136,15 @@ func Get() *Emojipedia {
 	return emojipedia
 }
 
+// GetLocale attempts to open all Emoji data translated for a locale, but panics if an error occurs.
+func GetLocale(code string) *Emojipedia {
+	emojipedia, err := OpenLocale(code)
+	if err != nil {
+		panic(err)
+	}
+	return emojipedia
+}
+
 // Lexicon returns the internal lexicon.Lexicon pointer to be consumed by a shared function.
 func Lexicon() (*lexicon.Lexicon, error) {
 	emojipedia, err := Open()
@@ -49,84 +154,94 @@ func Lexicon() (*lexicon.Lexicon, error) {
 	return emojipedia.lexicon, nil
 }
 
-// Make builds Emoji dependencies from HTML scraped from unicode.org.
-func Make(document *goquery.Document) {
-	var category, subcategory string
-	document.Find("tr").Each(func(i int, selection *goquery.Selection) {
-		var (
-			anchor   string
-			codes    = &slice.Slice{}
-			image    string
-			keywords = &slice.Slice{}
-			name     string
-			number   int
-			unicodes string
-		)
-		selection.Find("th.bighead a").Each(func(j int, s *goquery.Selection) {
-			category = text.Normalize(s.Text())
-		})
-		selection.Find("th.mediumhead a").Each(func(j int, s *goquery.Selection) {
-			subcategory = text.Normalize(s.Text())
-		})
-		selection.Find("td.rchars").Each(func(j int, s *goquery.Selection) {
-			number, _ = strconv.Atoi(strings.TrimSpace(s.Text()))
-		})
-		selection.Find("td.code").Each(func(j int, s *goquery.Selection) {
-			for _, substring := range strings.Split(s.Text(), " ") {
-				codes.Append(strings.TrimSpace(substring))
-			}
-		})
-		selection.Find("td.andr img").Each(func(j int, s *goquery.Selection) {
-			image, _ = s.Attr("src")
-		})
-		selection.Find("td.andr a").Each(func(j int, s *goquery.Selection) {
-			anchor, _ = s.Attr("href")
-		})
-		selection.Find("td.name").First().Each(func(j int, s *goquery.Selection) {
-			name = text.Normalize(s.Text())
-		})
-		selection.Find("td.name").Last().Each(func(j int, s *goquery.Selection) {
-			for _, substring := range strings.Split(s.Text(), "|") {
-				keywords.Append(text.Normalize(substring))
-			}
-		})
-		if len(name) == 0 {
-			return
+// Make builds Emoji dependencies from HTML scraped from unicode.org. Rows are parsed across a pool of workers
+// and stored with Store, so the stored files are identical to those of a serial build.
+// Only the Emoji of the pkg.Categories are written.
+func Make(document *goquery.Document) error {
+	return Store(selected(document, -1))
+}
+
+// Resume builds the Emoji dependencies of the rows after the argument row index in batches of Batch Emoji,
+// calling progress with the row index of the last Emoji of each batch once it is stored, so that an interrupted
+// build can resume where it stopped. Resuming stops at the first batch that fails to be stored.
+func Resume(document *goquery.Document, row int, progress func(row int)) error {
+	emoji := selected(document, row)
+	for i := 0; i < len(emoji); i += Batch {
+		batch := emoji[i:int(math.Min(float64(i+Batch), float64(len(emoji))))]
+		if err := Store(batch); err != nil {
+			return err
 		}
-		if substrings := strings.Split(anchor, "#"); len(substrings) > 1 {
-			anchor = "#" + substrings[1]
-		} else {
-			anchor = "#"
+		if progress != nil {
+			progress(batch[len(batch)-1].Position)
 		}
-		codes.Each(func(_ int, i interface{}) {
-			code := i.(string)
-			replacement := "000"
-			if len(code) == 6 {
-				replacement = "0000"
+	}
+	return nil
+}
+
+// Store writes the emoji.Emoji across a pool of writers, returning Errors naming every emoji.Emoji that failed to be written.
+// Emoji that are not written because they are invalid in store.Strict mode are not failures.
+// Emoji whose file names collide are written first and in order, so they are named as a serial build would name them.
+func Store(values []*emoji.Emoji) error {
+	var (
+		failures = Errors{}
+		jobs     = make(chan *emoji.Emoji)
+		mutex    sync.Mutex
+		stems    = map[string]int{}
+		wg       sync.WaitGroup
+	)
+	write := func(e *emoji.Emoji) {
+		// Emoji rejected in strict mode are reported by the store and do not fail the others.
+		if err := emoji.Write(e); err != nil && errors.Is(err, store.ErrInvalid) == false {
+			mutex.Lock()
+			failures[e.Name] = err
+			mutex.Unlock()
+		}
+	}
+	for _, e := range values {
+		stems[stem(e)]++
+	}
+	for _, e := range values {
+		if stems[stem(e)] > 1 {
+			write(e)
+		}
+	}
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range jobs {
+				write(e)
 			}
-			unicodes = unicodes + strings.Replace(code, "+", replacement, 1)
-		})
-		unicodes = strings.Replace(strings.ToLower(unicodes), "u", "\\U", -1)
-		emoji.Write(&emoji.Emoji{
-			Anchor:      anchor,
-			Category:    category,
-			Codes:       codes,
-			Href:        (pkg.URL + anchor),
-			Image:       image,
-			Keywords:    keywords,
-			Name:        name,
-			Number:      number,
-			Position:    i,
-			Subcategory: subcategory,
-			Unicode:     unicodes})
-	})
+		}()
+	}
+	for _, e := range values {
+		if stems[stem(e)] == 1 {
+			jobs <- e
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if len(failures) != 0 {
+		return failures
+	}
+	return nil
+}
+
+// Parse reads every Emoji from HTML scraped from unicode.org into a new Emojipedia without storing anything.
+func Parse(document *goquery.Document) *Emojipedia {
+	return NewEmojipedia(rowsOf(document)...)
 }
 
 // Open attempts to open all Emoji data from the emojipedia/emoji folder.
 func Open() (*Emojipedia, error) {
+	return kept.get(directory.Root, open)
+}
+
+// open reads all Emoji data from the emojipedia/emoji folder.
+func open() (*Emojipedia, error) {
 	files, err := ioutil.ReadDir(directory.Emoji)
 	if err != nil {
-		return nil, err
+		return nil, store.Missing(err, store.ErrMissingEncyclopedia)
 	}
 	emojipedia := New()
 	for _, file := range files {
@@ -140,35 +255,123 @@ func Open() (*Emojipedia, error) {
 	return emojipedia, nil
 }
 
+// OpenLocale attempts to open all Emoji data from the emojipedia/emoji folder with the names, descriptions
+// and keywords translated for a locale in storage/<locale>/. Emoji are indexed by their translated names;
+// anything that is not translated keeps the shared value. An empty locale opens the shared Emoji data alone.
+func OpenLocale(code string) (*Emojipedia, error) {
+	if len(code) == 0 {
+		return Open()
+	}
+	return kept.get(directory.Root+"@"+code, func() (*Emojipedia, error) {
+		emojipedia, err := Open()
+		if err != nil {
+			return nil, err
+		}
+		l, err := locale.Open(code)
+		if err != nil {
+			return nil, err
+		}
+		translated := New()
+		translated.locale = code
+		// The locale is applied to copies, so the shared Emojipedia kept by Open keeps its names.
+		emojipedia.Each(func(_ string, e *emoji.Emoji) {
+			translated.Add(l.Apply(e.Copy()))
+		})
+		return translated, nil
+	})
+}
+
 // Remove deletes all Emoji data stored in the dependencies folder.
 func Remove() error {
-	return os.Remove(directory.Emoji)
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	if err := index.Remove(); err != nil {
+		return err
+	}
+	return store.Trash(directory.Emoji)
 }
 
 type emojipedia interface {
 	Add(emoji *emoji.Emoji) *Emojipedia
+	Diff(emojipedia *Emojipedia) *Changes
 	Each(f func(key string, emoji *emoji.Emoji)) *Emojipedia
+	Every(f func(e *emoji.Emoji) bool) bool
 	Fetch(key string) *emoji.Emoji
+	Find(f func(e *emoji.Emoji) bool) (*emoji.Emoji, bool)
 	Get(key string) (*emoji.Emoji, bool)
+	Glyph(character string) (*emoji.Emoji, bool)
 	Has(key string) bool
+	IDs() *slice.Slice
 	Keys() *slice.Slice
 	Len() int
+	Map(f func(e *emoji.Emoji) interface{}) *slice.Slice
+	Merge(emojipedia *Emojipedia, strategy conflict.Strategy) *Emojipedia
+	Random() *emoji.Emoji
+	Reduce(f func(accumulator interface{}, e *emoji.Emoji) interface{}, initial interface{}) interface{}
 	Remove(key string) bool
+	Replace(s string, f func(character string, emoji *emoji.Emoji) string) string
+	Search(term string) *slice.Slice
+	SentimentOf(text string) *sentiment.Sentiment
+	Some(f func(e *emoji.Emoji) bool) bool
 	Values() *slice.Slice
 }
 
 // Emojipedia is a map-like struct with methods used to perform traversal and retrieval of emoji.Emoji pointers.
+// Emoji are held by their stable emoji.Emoji.ID with their names kept as an index,
+// so every method accepting a key resolves either an ID or a name.
 type Emojipedia struct {
+	glyphs  *lexicon.Lexicon
 	lexicon *lexicon.Lexicon
+	locale  string
+	// mutex guards the glyphs, user and weights built on first use, as an Emojipedia is read by the concurrent
+	// requests of the daemon, the server and escape.Handler. Adding and removing Emoji is not safe for concurrent use.
+	mutex   sync.Mutex
+	names   *lexicon.Lexicon
+	user    *user.User
+	weights *weights.Weights
 }
 
-// Add method adds one emoji.Emoji to the Emojipedia using the emoji.Emoji.Name as the key reference.
-func (pointer *Emojipedia) Add(emoji *emoji.Emoji) *Emojipedia {
-	pointer.lexicon.Add(emoji.Name, emoji)
+// Add method adds one emoji.Emoji to the Emojipedia using the emoji.Emoji.ID as the key reference and indexes its name.
+func (pointer *Emojipedia) Add(e *emoji.Emoji) *Emojipedia {
+	if len(e.ID) == 0 {
+		e.ID = emoji.ID(e.Unicode)
+	}
+	pointer.lexicon.Add(e.ID, e)
+	pointer.names.Add(e.Name, e.ID)
+	pointer.mutex.Lock()
+	pointer.glyphs, pointer.weights = nil, nil
+	pointer.mutex.Unlock()
 	return pointer
 }
 
-// Each method executes a provided function once for each emoji.Emoji pointer.
+// Diff method compares the Emojipedia with the argument Emojipedia, matching emoji by ID,
+// and returns the emoji only the argument holds as added, those only the Emojipedia holds as removed
+// and those whose fields differ, as compared by emoji.Emoji.Diff, as modified.
+func (pointer *Emojipedia) Diff(emojipedia *Emojipedia) *Changes {
+	changes := &Changes{Added: []*emoji.Emoji{}, Modified: []*Modification{}, Removed: []*emoji.Emoji{}}
+	pointer.Each(func(ID string, from *emoji.Emoji) {
+		to, ok := emojipedia.lexicon.Get(ID)
+		if ok == false {
+			changes.Removed = append(changes.Removed, from)
+			return
+		}
+		if c := from.Diff(to.(*emoji.Emoji)); len(c) != 0 {
+			changes.Modified = append(changes.Modified, &Modification{Changes: c, From: from, To: to.(*emoji.Emoji)})
+		}
+	})
+	emojipedia.Each(func(ID string, to *emoji.Emoji) {
+		if pointer.lexicon.Has(ID) == false {
+			changes.Added = append(changes.Added, to)
+		}
+	})
+	sort.Slice(changes.Added, func(i, j int) bool { return changes.Added[i].ID < changes.Added[j].ID })
+	sort.Slice(changes.Modified, func(i, j int) bool { return changes.Modified[i].From.ID < changes.Modified[j].From.ID })
+	sort.Slice(changes.Removed, func(i, j int) bool { return changes.Removed[i].ID < changes.Removed[j].ID })
+	return changes
+}
+
+// Each method executes a provided function once for each emoji.Emoji pointer, passing its ID as the key.
 func (pointer *Emojipedia) Each(f func(key string, emoji *emoji.Emoji)) *Emojipedia {
 	pointer.lexicon.Each(func(key string, i interface{}) {
 		f(key, i.(*emoji.Emoji))
@@ -176,16 +379,58 @@ func (pointer *Emojipedia) Each(f func(key string, emoji *emoji.Emoji)) *Emojipe
 	return pointer
 }
 
+// Every method tests whether every emoji.Emoji pointer passes the provided function, in the same order as Each.
+// Returns true when the Emojipedia is empty.
+func (pointer *Emojipedia) Every(f func(e *emoji.Emoji) bool) bool {
+	every := true
+	pointer.Each(func(_ string, e *emoji.Emoji) {
+		every = every && f(e)
+	})
+	return every
+}
+
 // Fetch retrieves the emoji.Emoji pointer held by the argument key. Panics if key does not exist.
 func (pointer *Emojipedia) Fetch(key string) *emoji.Emoji {
 	property, _ := pointer.Get(key)
 	return property
 }
 
+// Find method returns the first emoji.Emoji pointer, in the same order as Each, that passes the provided function
+// and a boolean indicating if one was found.
+func (pointer *Emojipedia) Find(f func(e *emoji.Emoji) bool) (*emoji.Emoji, bool) {
+	var found *emoji.Emoji
+	pointer.Each(func(_ string, e *emoji.Emoji) {
+		if found == nil && f(e) {
+			found = e
+		}
+	})
+	return found, found != nil
+}
+
 // Get returns the emoji.Emoji pointer held by the argument key and a boolean indicating if it was successfully retrieved.
 // Panics if cannot convert to emoji.Emoji pointer.
 func (pointer *Emojipedia) Get(key string) (*emoji.Emoji, bool) {
-	property, ok := pointer.lexicon.Get(key)
+	property, ok := pointer.lexicon.Get(pointer.resolve(key))
+	if ok == true {
+		return property.(*emoji.Emoji), ok
+	}
+	return nil, ok
+}
+
+// Glyph returns the emoji.Emoji pointer whose character matches the argument string and a boolean indicating if it was found.
+// Variation selectors are ignored when matching.
+func (pointer *Emojipedia) Glyph(character string) (*emoji.Emoji, bool) {
+	pointer.mutex.Lock()
+	if pointer.glyphs == nil {
+		glyphs := &lexicon.Lexicon{}
+		pointer.Each(func(_ string, e *emoji.Emoji) {
+			glyphs.Add(emojidata.Normalize(text.Emojize(e.Unicode)), e)
+		})
+		pointer.glyphs = glyphs
+	}
+	glyphs := pointer.glyphs
+	pointer.mutex.Unlock()
+	property, ok := glyphs.Get(emojidata.Normalize(character))
 	if ok == true {
 		return property.(*emoji.Emoji), ok
 	}
@@ -194,16 +439,17 @@ func (pointer *Emojipedia) Get(key string) (*emoji.Emoji, bool) {
 
 // Has method checks that a given key exists in the Emojipedia.
 func (pointer *Emojipedia) Has(key string) bool {
-	return pointer.lexicon.Has(key)
+	return pointer.lexicon.Has(pointer.resolve(key))
+}
+
+// IDs method returns a slice.Slice of the stable IDs held by the Emojipedia, in the same order as we get with a normal loop.
+func (pointer *Emojipedia) IDs() *slice.Slice {
+	return pointer.lexicon.Keys()
 }
 
-// Keys method returns a slice.Slice of a given Emojipedia' own property names, in the same order as we get with a normal loop.
+// Keys method returns a slice.Slice of a given Emojipedia' emoji names, in the same order as we get with a normal loop.
 func (pointer *Emojipedia) Keys() *slice.Slice {
-	slice := slice.New()
-	pointer.lexicon.Each(func(key string, i interface{}) {
-		slice.Append(key)
-	})
-	return slice
+	return pointer.names.Keys()
 }
 
 // Len method returns the number of elements in the Emojipedia.
@@ -211,9 +457,309 @@ func (pointer *Emojipedia) Len() int {
 	return pointer.lexicon.Len()
 }
 
+// Map method executes a provided function once for each emoji.Emoji pointer and returns a slice.Slice of the returned values,
+// in the same order as Each.
+func (pointer *Emojipedia) Map(f func(e *emoji.Emoji) interface{}) *slice.Slice {
+	slice := slice.New()
+	pointer.Each(func(_ string, e *emoji.Emoji) {
+		slice.Append(f(e))
+	})
+	return slice
+}
+
+// MarshalJSON encodes the Emojipedia as a plain JSON object keyed by ID.
+func (pointer *Emojipedia) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pointer.lexicon)
+}
+
+// Merge method adds a copy of every emoji.Emoji of the argument Emojipedia to the Emojipedia.
+// Emoji held by both are resolved by the conflict.Strategy: kept, replaced by the merged copy or combined by emoji.Emoji.Union.
+func (pointer *Emojipedia) Merge(emojipedia *Emojipedia, strategy conflict.Strategy) *Emojipedia {
+	emojipedia.Each(func(ID string, e *emoji.Emoji) {
+		held, ok := pointer.Get(ID)
+		switch {
+		case ok == false:
+			pointer.Add(e.Copy())
+		case strategy == conflict.Replace:
+			pointer.Remove(ID)
+			pointer.Add(e.Copy())
+		case strategy == conflict.Union:
+			pointer.Remove(ID)
+			pointer.Add(held.Union(e))
+		}
+	})
+	return pointer
+}
+
+// NearestN method returns at most n of the emoji.Emoji most similar to the emoji.Emoji held by the argument key,
+// scored against every other emoji.Emoji of the Emojipedia by the emoji.Metric arguments or by emoji.Default.
+// Returns nothing when the key does not exist.
+func (pointer *Emojipedia) NearestN(key string, n int, metrics ...emoji.Metric) []*emoji.Emoji {
+	e, ok := pointer.Get(key)
+	if ok == false {
+		return []*emoji.Emoji{}
+	}
+	candidates := []*emoji.Emoji{}
+	pointer.Each(func(_ string, candidate *emoji.Emoji) {
+		candidates = append(candidates, candidate)
+	})
+	return emoji.NearestN(e, candidates, n, metrics...)
+}
+
+// Random method returns an Emoji chosen at random, or nil when the Emojipedia is empty.
+func (pointer *Emojipedia) Random() *emoji.Emoji {
+	if pointer.Len() == 0 {
+		return nil
+	}
+	return pointer.Values().Fetch(rand.Intn(pointer.Len())).(*emoji.Emoji)
+}
+
+// Related method returns the names of at most n of the emoji.Emoji most similar to the emoji.Emoji held by the argument key,
+// scored by the emoji.Metric arguments or by emoji.Default. Only the emoji.Emoji sharing a keyword or the subcategory
+// of the emoji.Emoji are compared. Returns nothing when the key does not exist.
+func (pointer *Emojipedia) Related(key string, n int, metrics ...emoji.Metric) *slice.Slice {
+	related := slice.New()
+	e, ok := pointer.Get(key)
+	if ok == false {
+		return related
+	}
+	candidates := []*emoji.Emoji{}
+	pointer.Each(func(_ string, candidate *emoji.Emoji) {
+		if emoji.KeywordJaccard(e, candidate) > 0 || emoji.SameSubcategory(e, candidate) > 0 {
+			candidates = append(candidates, candidate)
+		}
+	})
+	for _, candidate := range emoji.NearestN(e, candidates, n, metrics...) {
+		related.Append(candidate.Name)
+	}
+	return related
+}
+
+// Reduce method executes a provided function once for each emoji.Emoji pointer, in the same order as Each,
+// passing the value returned by the previous call (or the initial value) and returning the last value.
+func (pointer *Emojipedia) Reduce(f func(accumulator interface{}, e *emoji.Emoji) interface{}, initial interface{}) interface{} {
+	accumulator := initial
+	pointer.Each(func(_ string, e *emoji.Emoji) {
+		accumulator = f(accumulator, e)
+	})
+	return accumulator
+}
+
 // Remove method removes a entry from the Emojipedia if it exists. Returns a boolean to confirm if it succeeded.
 func (pointer *Emojipedia) Remove(key string) bool {
-	return pointer.lexicon.Remove(key)
+	e, ok := pointer.Get(key)
+	if ok == true {
+		pointer.mutex.Lock()
+		pointer.glyphs, pointer.weights = nil, nil
+		pointer.mutex.Unlock()
+		pointer.lexicon.Remove(e.ID)
+		pointer.names.Remove(e.Name)
+	}
+	return ok
+}
+
+// Replace method executes a provided function once for each emoji found in the argument string
+// and substitutes the emoji with the returned string. Text that is not an emoji is left untouched.
+func (pointer *Emojipedia) Replace(s string, f func(character string, emoji *emoji.Emoji) string) string {
+	b := strings.Builder{}
+	for _, cluster := range segment.Segment(s) {
+		if emoji, ok := pointer.Glyph(cluster.Text); ok {
+			b.WriteString(f(cluster.Text, emoji))
+		} else {
+			b.WriteString(cluster.Text)
+		}
+	}
+	return b.String()
+}
+
+// Search method returns a Slice of the Emoji matching a search term, best matches first.
+// An ID, name or pasted emoji character matches exactly. Otherwise each term of the search is matched against
+// the Emoji keywords and, at half the weight, its name; the weights.Weights of the matched terms are summed
+// so that "taco face" ranks the taco above every face. Terms are compared by their analyzer.Default form,
+// so "smiling" finds emoji with the keyword "smile". Emoji whose names merely contain the term follow,
+// and ties are broken by unicode.org order. The tags of the user.User are matched as keywords, the
+// scores of their favorites are doubled and the scores of the emoji they used often and recently,
+// as recorded by the history.History, are raised by the logarithm of their frecency. Variants that do not match
+// exactly are credited to their base, so a search lists one entry for each emoji rather than one for each skin tone.
+func (pointer *Emojipedia) Search(term string) *slice.Slice {
+	var (
+		matches = []*emoji.Emoji{}
+		query   = text.Normalize(strings.Trim(strings.TrimSpace(term), ":"))
+		terms   = analyzer.Default.Terms(query)
+		now     = time.Now().UTC()
+		recent  = pointer.recent()
+		scores  = map[string]float64{}
+		user    = pointer.personalize()
+		weights = pointer.weigh()
+	)
+	if len(query) == 0 {
+		return slice.New()
+	}
+	if e, ok := pointer.Glyph(strings.TrimSpace(term)); ok {
+		scores[e.ID] = exact
+	}
+	pointer.Each(func(ID string, e *emoji.Emoji) {
+		score := 0.0
+		if ID == query || e.Name == query {
+			score = exact
+		} else {
+			var (
+				keywords = set(append(termsOf(e), tagsOf(user, ID)...))
+				names    = set(analyzer.Default.Terms(e.Name))
+			)
+			for _, t := range terms {
+				switch {
+				case keywords[t]:
+					score += weights.Weight(t)
+				case names[t]:
+					score += weights.Weight(t) / 2
+				}
+			}
+			if score == 0 && strings.Contains(e.Name, query) {
+				score = partial
+			}
+			if user.Favorite(ID) {
+				score = score * favored
+			}
+			score = score * (1 + math.Log1p(recent.Frecency(ID, now)))
+			if base, ok := pointer.Get(e.Base); ok && len(e.Base) != 0 {
+				ID = base.ID
+			}
+		}
+		if score > scores[ID] {
+			scores[ID] = score
+		}
+	})
+	for ID := range scores {
+		if scores[ID] > 0 {
+			matches = append(matches, pointer.Fetch(ID))
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if scores[matches[i].ID] != scores[matches[j].ID] {
+			return scores[matches[i].ID] > scores[matches[j].ID]
+		}
+		return matches[i].Number < matches[j].Number
+	})
+	slice := slice.New()
+	for _, e := range matches {
+		slice.Append(e)
+	}
+	return slice
+}
+
+// personalize returns the user.User data of the active profile, or an empty user.User when it cannot be opened.
+func (pointer *Emojipedia) personalize() *user.User {
+	pointer.mutex.Lock()
+	defer pointer.mutex.Unlock()
+	if pointer.user != nil {
+		return pointer.user
+	}
+	u, err := user.Open()
+	if err != nil {
+		u = user.New()
+	}
+	pointer.user = u
+	return u
+}
+
+// recent returns the history.History of the active profile, or an empty history.History when it cannot be opened.
+// It is read again by every search as it changes with every lookup.
+func (pointer *Emojipedia) recent() *history.History {
+	h, err := history.Open()
+	if err != nil {
+		return history.New()
+	}
+	return h
+}
+
+// weigh returns the weights.Weights stored by the last keywords build or, when none are stored or the
+// Emojipedia is translated, weighs the keywords of the Emoji held by the Emojipedia.
+func (pointer *Emojipedia) weigh() *weights.Weights {
+	pointer.mutex.Lock()
+	defer pointer.mutex.Unlock()
+	if pointer.weights != nil {
+		return pointer.weights
+	}
+	w, err := weights.Open()
+	if err != nil || len(pointer.locale) != 0 {
+		w = weights.New()
+		pointer.Each(func(_ string, e *emoji.Emoji) {
+			w.Add(termsOf(e)...)
+		})
+	}
+	pointer.weights = w
+	return w
+}
+
+// set returns the argument strings as a set.
+func set(values []string) map[string]bool {
+	set := map[string]bool{}
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+// tagsOf returns the analyzer.Default terms of every tag the user.User gave the Emoji ID.
+func tagsOf(u *user.User, ID string) []string {
+	terms := []string{}
+	for _, tag := range u.Tags[ID] {
+		terms = append(terms, analyzer.Default.Terms(tag)...)
+	}
+	return terms
+}
+
+// termsOf returns the analyzer.Default terms of every keyword of the Emoji.
+func termsOf(e *emoji.Emoji) []string {
+	terms := []string{}
+	if e.Keywords != nil {
+		e.Keywords.Each(func(_ int, i interface{}) {
+			terms = append(terms, analyzer.Default.Terms(i.(string))...)
+		})
+	}
+	return terms
+}
+
+// SentimentOf method aggregates the sentiment of every emoji found in the argument text,
+// weighting each emoji by how often it was observed in the sentiment lexicon. Emoji without a stored sentiment are ignored.
+func (pointer *Emojipedia) SentimentOf(text string) *sentiment.Sentiment {
+	aggregate := sentiment.New(0, 0, 0)
+	pointer.Replace(text, func(character string, emoji *emoji.Emoji) string {
+		if emoji.Sentiment != nil {
+			aggregate.Add(emoji.Sentiment)
+		}
+		return character
+	})
+	return aggregate
+}
+
+// Some method tests whether at least one emoji.Emoji pointer passes the provided function.
+func (pointer *Emojipedia) Some(f func(e *emoji.Emoji) bool) bool {
+	_, ok := pointer.Find(f)
+	return ok
+}
+
+// UnmarshalJSON decodes a plain JSON object of emoji into the Emojipedia, replacing its contents.
+func (pointer *Emojipedia) UnmarshalJSON(content []byte) error {
+	values := map[string]*emoji.Emoji{}
+	err := json.Unmarshal(content, &values)
+	if err != nil {
+		return err
+	}
+	pointer.glyphs, pointer.lexicon, pointer.names, pointer.user, pointer.weights = nil, &lexicon.Lexicon{}, &lexicon.Lexicon{}, nil, nil
+	for _, value := range values {
+		pointer.Add(value)
+	}
+	return nil
+}
+
+// resolve returns the ID indexed by an emoji name, or the key unchanged if it is not a known name.
+func (pointer *Emojipedia) resolve(key string) string {
+	if id, ok := pointer.names.Get(key); ok {
+		return id.(string)
+	}
+	return key
 }
 
 // Values method returns a Slice of a given Emojipedia's own enumerable property values,
@@ -225,3 +771,236 @@ func (pointer *Emojipedia) Values() *slice.Slice {
 	})
 	return slice
 }
+
+// Changes lists the emoji added, modified and removed between two Emojipedia, each ordered by ID.
+type Changes struct {
+	Added    []*emoji.Emoji
+	Modified []*Modification
+	Removed  []*emoji.Emoji
+}
+
+// Len method returns the number of emoji added, modified and removed.
+func (pointer *Changes) Len() int {
+	return len(pointer.Added) + len(pointer.Modified) + len(pointer.Removed)
+}
+
+// Modification is an emoji held by both Emojipedia with the fields whose values differ.
+type Modification struct {
+	Changes []*emoji.Change
+	From    *emoji.Emoji
+	To      *emoji.Emoji
+}
+
+// selected returns the Emoji of the rows after the argument row index that belong to the pkg.Categories.
+func selected(document *goquery.Document, row int) []*emoji.Emoji {
+	emoji := []*emoji.Emoji{}
+	for _, e := range rowsOf(document) {
+		if e.Position > row && pkg.Selected(e.Category) {
+			emoji = append(emoji, e)
+		}
+	}
+	return emoji
+}
+
+// group records the skin tone and gender variants of the emoji.Emoji under their base: each variant names its base
+// as its emoji.Emoji.Base and each base lists the names of its emoji.Emoji.Variants in order. Variants whose base is
+// not among the emoji.Emoji stand alone. The emoji.Emoji are returned for chaining.
+func group(values []*emoji.Emoji) []*emoji.Emoji {
+	IDs := map[string]*emoji.Emoji{}
+	for _, e := range values {
+		IDs[e.ID] = e
+		e.Base, e.Variants = "", nil
+	}
+	for _, e := range values {
+		base, ok := IDs[e.BaseID()]
+		if ok == false || base == e {
+			continue
+		}
+		if base.Variants == nil {
+			base.Variants = &slice.Slice{}
+		}
+		e.Base = base.Name
+		base.Variants.Append(e.Name)
+	}
+	return values
+}
+
+// stem returns the case-insensitive file name the Emoji is stored under before any collision is resolved.
+func stem(e *emoji.Emoji) string {
+	if store.ByID {
+		return strings.ToLower(store.Sanitize(e.ID))
+	}
+	return strings.ToLower(store.Sanitize(e.Name))
+}
+
+// rowsOf parses the Emoji of every table row across a pool of workers, returned in document order
+// with their provenance stamped with the time the unicode.org page was fetched.
+func rowsOf(document *goquery.Document) []*emoji.Emoji {
+	var (
+		category    string
+		rows        = []*row{}
+		subcategory string
+	)
+	document.Find("tr").Each(func(i int, selection *goquery.Selection) {
+		selection.Find("th.bighead a").Each(func(j int, s *goquery.Selection) {
+			category = text.Normalize(s.Text())
+		})
+		selection.Find("th.mediumhead a").Each(func(j int, s *goquery.Selection) {
+			subcategory = text.Normalize(s.Text())
+		})
+		rows = append(rows, &row{category: category, position: i, selection: selection, subcategory: subcategory})
+	})
+	var (
+		jobs    = make(chan int)
+		results = make([]*emoji.Emoji, len(rows))
+		wg      sync.WaitGroup
+	)
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = parse(rows[i])
+			}
+		}()
+	}
+	for i := range rows {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	var (
+		emoji   = []*emoji.Emoji{}
+		fetched = pkg.Fetched()
+	)
+	for _, e := range results {
+		if e != nil {
+			emoji = append(emoji, e)
+		}
+	}
+	for _, e := range group(emoji) {
+		e.Stamp(pkg.Name, fetched)
+	}
+	return emoji
+}
+
+// parse reads the Emoji described by a single table row. Rows without an emoji name return nil.
+func parse(r *row) *emoji.Emoji {
+	f := &fields{category: r.category, position: r.position, subcategory: r.subcategory}
+	r.selection.Find("td.rchars").Each(func(j int, s *goquery.Selection) {
+		f.number, _ = strconv.Atoi(strings.TrimSpace(s.Text()))
+	})
+	r.selection.Find("td.code").Each(func(j int, s *goquery.Selection) {
+		f.codes = append(f.codes, strings.Fields(s.Text())...)
+	})
+	r.selection.Find("td.andr img").Each(func(j int, s *goquery.Selection) {
+		f.image, _ = s.Attr("src")
+	})
+	r.selection.Find("td.andr a").Each(func(j int, s *goquery.Selection) {
+		f.anchor, _ = s.Attr("href")
+	})
+	r.selection.Find("td.name").Each(func(j int, s *goquery.Selection) {
+		f.names = append(f.names, s.Text())
+	})
+	return compose(f)
+}
+
+// compose builds the Emoji described by the fields read from a table row. Rows without an emoji name return nil.
+// The Emoji is RGI when emoji-test.txt lists its sequence as fully-qualified, or when its qualification is unknown
+// as emoji-test.txt is not built or does not list it, and has the emoji version it lists.
+// The formal name of each of its code points and the block of the first are taken from UnicodeData.txt and Blocks.txt.
+func compose(f *fields) *emoji.Emoji {
+	var (
+		anchor    string
+		codes     = &slice.Slice{}
+		keywords  = &slice.Slice{}
+		name      string
+		unicodes  string
+		variation bool
+	)
+	if len(f.names) == 0 {
+		return nil
+	}
+	name = text.Normalize(f.names[0])
+	for _, substring := range strings.Split(f.names[len(f.names)-1], "|") {
+		keywords.Append(text.Normalize(substring))
+	}
+	if len(name) == 0 {
+		return nil
+	}
+	if substrings := strings.Split(f.anchor, "#"); len(substrings) > 1 {
+		anchor = "#" + substrings[1]
+	} else {
+		anchor = "#"
+	}
+	runes := []rune{}
+	for _, code := range f.codes {
+		r, _ := strconv.ParseInt(strings.TrimPrefix(code, "U+"), 16, 32)
+		runes = append(runes, rune(r))
+	}
+	if len(runes) == 1 && emojidata.RequiresVariation(runes[0]) {
+		runes, variation = append(runes, emojidata.EmojiSelector), true
+	}
+	if base, ok := keycap.BaseOf(string(runes)); ok {
+		sequence, _ := keycap.KeycapFor(base)
+		runes, variation = []rune(sequence), true
+	}
+	var (
+		block     string
+		formal    = &slice.Slice{}
+		named     bool
+		qualified = emojidata.Qualify(string(runes))
+	)
+	for i, r := range runes {
+		if i == 0 {
+			block = emojidata.BlockOf(r)
+		}
+		codes.Append(fmt.Sprintf("U+%04X", r))
+		unicodes = unicodes + fmt.Sprintf("\\U%08x", r)
+		name := emojidata.FormalName(r)
+		formal.Append(name)
+		named = named || len(name) != 0
+	}
+	if named == false {
+		formal = nil
+	}
+	return &emoji.Emoji{
+		Anchor:      anchor,
+		Block:       block,
+		Category:    f.category,
+		Codes:       codes,
+		FormalNames: formal,
+		Href:        (pkg.Address() + anchor),
+		ID:          emoji.ID(unicodes),
+		Image:       f.image,
+		Keywords:    keywords,
+		Name:        name,
+		Number:      f.number,
+		Position:    f.position,
+		Qualified:   qualified,
+		RGI:         qualified == emojidata.FullyQualified || len(qualified) == 0,
+		Subcategory: f.subcategory,
+		Unicode:     unicodes,
+		Variation:   variation,
+		Version:     emojidata.Added(string(runes))}
+}
+
+// fields are the raw values read from a unicode.org table row, independent of the parser that read them.
+type fields struct {
+	anchor      string
+	category    string
+	codes       []string
+	image       string
+	names       []string
+	number      int
+	position    int
+	subcategory string
+}
+
+// row is a unicode.org table row along with the category and subcategory headings that precede it.
+type row struct {
+	category    string
+	position    int
+	selection   *goquery.Selection
+	subcategory string
+}
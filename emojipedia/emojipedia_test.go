@@ -0,0 +1,108 @@
+package emojipedia
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/text"
+)
+
+// BenchmarkOpen reads every stored emoji. Skipped until the emojipedia package is built.
+func BenchmarkOpen(b *testing.B) {
+	if _, err := Open(); err != nil {
+		b.Skip(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Open()
+	}
+}
+
+// BenchmarkReplace extracts every stored emoji from a text holding each of them once.
+func BenchmarkReplace(b *testing.B) {
+	e, err := Open()
+	if err != nil {
+		b.Skip(err)
+	}
+	sample := &strings.Builder{}
+	e.Each(func(_ string, emoji *emoji.Emoji) {
+		sample.WriteString("some text " + text.Emojize(emoji.Unicode) + " ")
+	})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e.Replace(sample.String(), func(character string, _ *emoji.Emoji) string {
+			return character
+		})
+	}
+}
+
+// TestConcurrentLookups looks emoji up from several goroutines at once, as the daemon and the server do,
+// while the glyphs and weights are built on first use. Run with -race.
+func TestConcurrentLookups(t *testing.T) {
+	e := NewEmojipedia(
+		&emoji.Emoji{Name: "cat-face", Unicode: `\U0001F431`},
+		&emoji.Emoji{Name: "grinning-face", Unicode: `\U0001F600`})
+	done := make(chan bool)
+	for i := 0; i < 8; i++ {
+		go func() {
+			defer func() { done <- true }()
+			if _, ok := e.Glyph("\U0001F431"); ok == false {
+				t.Errorf("Glyph(%q) = false", "\U0001F431")
+			}
+			e.Search("face")
+		}()
+	}
+	for i := 0; i < 8; i++ {
+		<-done
+	}
+}
+
+// TestOpenLocaleKeepsShared opens a locale while the Emojipedia are kept, as the shell does, and checks that
+// the shared Emojipedia kept by Open is not translated with it.
+func TestOpenLocaleKeepsShared(t *testing.T) {
+	root, err := ioutil.TempDir("", "emojipedia")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	defer directory.At(directory.Root)
+	directory.At(root)
+	defer func(stamp func() string) { Stamp = stamp }(Stamp)
+	Stamp = func() string { return "" }
+	e := emoji.New()
+	e.ID, e.Name, e.Unicode = "1F431", "cat-face", `\U0001F431`
+	if err := emoji.Write(e); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(directory.Locale("fr"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(directory.Locale("fr"), "names.json"), []byte(`{"1F431":"tête de chat"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	translated, err := OpenLocale("fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := text.Normalize("tête de chat"); translated.Has(name) == false {
+		t.Errorf("OpenLocale(%q).Has(%q) = false", "fr", name)
+	}
+	shared, err := Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := shared.Get("cat-face"); ok == false {
+		t.Errorf("Open().Get(%q) = false after OpenLocale(%q)", "cat-face", "fr")
+	}
+	shared.Each(func(name string, e *emoji.Emoji) {
+		if e.Name != "cat-face" {
+			t.Errorf("Open() holds %q, want %q", e.Name, "cat-face")
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package emojipedia
+
+import (
+	"container/list"
+	"os"
+
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/index"
+	"github.com/gellel/emojipedia/store"
+)
+
+const (
+	// Cached is the number of emoji.Emoji a Lazy opened with OpenLazy keeps in memory.
+	Cached = 256
+)
+
+// NewLazy instantiates a new Lazy pointer keeping up to size emoji.Emoji in memory.
+func NewLazy(size int) *Lazy {
+	return &Lazy{entries: map[string]*list.Element{}, recent: list.New(), size: size}
+}
+
+// OpenLazy attempts to open the emojipedia/emoji folder for lookups without reading any emoji.Emoji from it.
+// Emoji are read from the index.Index of the dataset when it has been built.
+func OpenLazy() (*Lazy, error) {
+	if _, err := os.Stat(directory.Emoji); err != nil {
+		return nil, store.Missing(err, store.ErrMissingEncyclopedia)
+	}
+	lazy := NewLazy(Cached)
+	lazy.index, _ = index.Open()
+	return lazy, nil
+}
+
+// Lazy looks emoji.Emoji up by name or ID like an Emojipedia, but reads each from the index.Index or the
+// emojipedia/emoji folder only when it is first asked for. The most recently used are kept in memory and the least recently used
+// are dropped once more than its size are held, so looking up a few emoji never reads the whole folder.
+type Lazy struct {
+	entries map[string]*list.Element
+	index   *index.Index
+	recent  *list.List
+	size    int
+}
+
+// entry is an emoji.Emoji held by a Lazy under the key it was looked up by.
+type entry struct {
+	emoji *emoji.Emoji
+	key   string
+}
+
+// Get method returns the emoji.Emoji stored under the argument name or ID and a boolean indicating if it was found.
+func (pointer *Lazy) Get(key string) (*emoji.Emoji, bool) {
+	if element, ok := pointer.entries[key]; ok {
+		pointer.recent.MoveToFront(element)
+		return element.Value.(*entry).emoji, true
+	}
+	e, err := pointer.open(key)
+	if err != nil {
+		return nil, false
+	}
+	pointer.entries[key] = pointer.recent.PushFront(&entry{emoji: e, key: key})
+	for pointer.recent.Len() > pointer.size {
+		oldest := pointer.recent.Back()
+		pointer.recent.Remove(oldest)
+		delete(pointer.entries, oldest.Value.(*entry).key)
+	}
+	return e, true
+}
+
+// Has method checks that an emoji.Emoji is stored under the argument name or ID.
+func (pointer *Lazy) Has(key string) bool {
+	_, ok := pointer.Get(key)
+	return ok
+}
+
+// Len method returns the number of emoji.Emoji held in memory.
+func (pointer *Lazy) Len() int {
+	return pointer.recent.Len()
+}
+
+// open reads the emoji.Emoji of the name or ID from the index.Index, or from its file when the index.Index
+// has not been built. The index.Index is removed whenever the emoji change, so a key it misses is not stored.
+func (pointer *Lazy) open(key string) (*emoji.Emoji, error) {
+	if pointer.index == nil {
+		return emoji.Open(key)
+	}
+	content, ok := pointer.index.Record(key)
+	if ok == false {
+		return nil, os.ErrNotExist
+	}
+	return emoji.Parse(&content)
+}
@@ -0,0 +1,153 @@
+package emojipedia
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/pkg"
+	"github.com/gellel/emojipedia/text"
+	"golang.org/x/net/html"
+)
+
+// Stream builds Emoji dependencies from HTML scraped from unicode.org without loading the whole document.
+// The HTML is tokenized row by row and each Emoji is written as soon as its row ends,
+// keeping memory use flat regardless of the size of the chart. Only the Emoji of the pkg.Categories are written.
+// Variants are grouped under their base once every row is written.
+func Stream(reader io.Reader) error {
+	var (
+		anchor      bool
+		cell        string
+		content     strings.Builder
+		category    string
+		f           *fields
+		fetched     = pkg.Fetched()
+		position    = -1
+		subcategory string
+		tokenizer   = html.NewTokenizer(reader)
+	)
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if tokenizer.Err() == io.EOF {
+				return regroup()
+			}
+			return tokenizer.Err()
+		case html.TextToken:
+			if len(cell) != 0 {
+				content.Write(tokenizer.Text())
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "tr":
+				position++
+				f = &fields{position: position}
+			case "th", "td":
+				cell, anchor = token.Data+"."+class(token, "bighead", "mediumhead", "rchars", "code", "andr", "name"), false
+				content.Reset()
+			case "a":
+				anchor = true
+				if cell == "td.andr" && f != nil {
+					f.anchor = attr(token, "href")
+				}
+			case "img":
+				if cell == "td.andr" && f != nil {
+					f.image = attr(token, "src")
+				}
+			}
+		case html.EndTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "th", "td":
+				if f == nil {
+					break
+				}
+				switch cell {
+				case "th.bighead":
+					if anchor {
+						category = text.Normalize(content.String())
+					}
+				case "th.mediumhead":
+					if anchor {
+						subcategory = text.Normalize(content.String())
+					}
+				case "td.rchars":
+					f.number, _ = strconv.Atoi(strings.TrimSpace(content.String()))
+				case "td.code":
+					f.codes = append(f.codes, strings.Fields(content.String())...)
+				case "td.name":
+					f.names = append(f.names, content.String())
+				}
+				cell = ""
+			case "tr":
+				if f == nil {
+					break
+				}
+				f.category, f.subcategory = category, subcategory
+				if e := compose(f); e != nil && pkg.Selected(e.Category) {
+					e.Stamp(pkg.Name, fetched)
+					if err := emoji.Write(e); err != nil {
+						return err
+					}
+				}
+				f = nil
+			}
+		}
+	}
+}
+
+// attr returns the value of the named attribute of an HTML token.
+func attr(token html.Token, key string) string {
+	for _, attribute := range token.Attr {
+		if attribute.Key == key {
+			return attribute.Val
+		}
+	}
+	return ""
+}
+
+// class returns the first of the candidate class names carried by an HTML token.
+func class(token html.Token, candidates ...string) string {
+	classes := strings.Fields(attr(token, "class"))
+	for _, candidate := range candidates {
+		for _, c := range classes {
+			if c == candidate {
+				return candidate
+			}
+		}
+	}
+	return ""
+}
+
+// regroup groups the stored Emoji under their base, rewriting those whose emoji.Emoji.Base or emoji.Emoji.Variants changed.
+func regroup() error {
+	emojipedia, err := Open()
+	if err != nil {
+		return err
+	}
+	var (
+		before  = map[string]string{}
+		changed = []*emoji.Emoji{}
+		values  = []*emoji.Emoji{}
+	)
+	emojipedia.Each(func(ID string, e *emoji.Emoji) {
+		before[ID] = grouping(e)
+		values = append(values, e)
+	})
+	for _, e := range group(values) {
+		if before[e.ID] != grouping(e) {
+			changed = append(changed, e)
+		}
+	}
+	return Store(changed)
+}
+
+// grouping returns the base and variants of the emoji.Emoji as one comparable string.
+func grouping(e *emoji.Emoji) string {
+	if e.Variants == nil {
+		return e.Base
+	}
+	return e.Base + "|" + e.Variants.Join(",")
+}
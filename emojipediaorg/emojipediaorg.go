@@ -0,0 +1,51 @@
+package emojipediaorg
+
+import (
+	"fmt"
+
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojidata"
+	"github.com/gellel/emojipedia/slug"
+	"github.com/gellel/emojipedia/text"
+)
+
+const (
+	// Name is the source name emojipedia.org is selected and recorded by.
+	Name = "emojipedia"
+)
+
+// Open crawls the category index pages of emojipedia.org for the emoji they list, in the order they are listed.
+func Open() ([]*slug.Link, error) {
+	return slug.Crawl()
+}
+
+// New creates an emoji.Emoji from an emoji listed by emojipedia.org alone. The index pages do not group emoji
+// into the unicode.org categories and subcategories, so the emoji.Emoji is left without them and numbered by the
+// 1-based position given as the argument. Links without a character or name return an error.
+func New(link *slug.Link, number int) (*emoji.Emoji, error) {
+	var (
+		codes     = []string{}
+		name      = text.Normalize(link.Name)
+		unicode   string
+		variation bool
+	)
+	if len(name) == 0 {
+		name = text.Normalize(link.Slug)
+	}
+	for _, r := range link.Character {
+		codes = append(codes, fmt.Sprintf("U+%04X", r))
+		unicode = unicode + fmt.Sprintf("\\U%08x", r)
+		if r == emojidata.EmojiSelector {
+			variation = true
+		}
+	}
+	return emoji.NewBuilder().
+		Codes(codes...).
+		Href(slug.URL + link.Slug + "/").
+		Name(name).
+		Number(number).
+		Position(number).
+		Unicode(unicode).
+		Variation(variation).
+		Build()
+}
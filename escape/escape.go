@@ -0,0 +1,66 @@
+package escape
+
+import (
+	"net/http"
+
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/store"
+)
+
+const (
+	// Path is the route the encodings of an emoji are served from.
+	Path string = "/emoji/escape"
+)
+
+// Escapes are the encodings of an emoji character, by the emoji.Formats name of each encoding.
+type Escapes struct {
+	Character string            `json:"character"`
+	Escapes   map[string]string `json:"escapes"`
+	Name      string            `json:"name"`
+}
+
+// Handler answers GET requests such as "/emoji/escape?emoji=taco&as=css" with the Escapes of the emoji.
+// Every encoding of emoji.Formats is returned when no encoding is asked for.
+func Handler(emojipedia *emojipedia.Emojipedia) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		e, ok := emojipedia.Get(r.URL.Query().Get("emoji"))
+		if ok == false {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		formats := r.URL.Query()["as"]
+		if len(formats) == 0 {
+			formats = emoji.Formats
+		}
+		escapes, err := Of(e, formats...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		content, err := store.Marshal(escapes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content)
+	})
+}
+
+// Of returns the Escapes of the emoji.Emoji in the argument encodings.
+func Of(e *emoji.Emoji, formats ...string) (*Escapes, error) {
+	escapes := &Escapes{Character: string(e.Runes()), Escapes: map[string]string{}, Name: e.Name}
+	for _, format := range formats {
+		value, err := e.Format(format)
+		if err != nil {
+			return nil, err
+		}
+		escapes.Escapes[format] = value
+	}
+	return escapes, nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+
+	"github.com/gellel/emojipedia/store"
+)
+
+var (
+	cleanups    = []func(){}
+	interactive = false
+	locked      = false
+)
+
+// exited is what exit panics with in the shell, so a command that exits ends the command rather than the shell.
+type exited int
+
+// cleanup runs the registered cleanups (stopping profilers and releasing locks) in reverse order.
+func cleanup() {
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+	cleanups = []func(){}
+}
+
+// exit runs the registered cleanups before exiting, so profiles are complete and locks are released
+// by commands that end with os.Exit. In the shell only the command is ended.
+func exit(code int) {
+	cleanup()
+	if interactive {
+		panic(exited(code))
+	}
+	os.Exit(code)
+}
+
+// lock takes the dataset lock for the rest of the command, exiting with a message naming the holder if it cannot.
+// Commands that write several packages call lock for each; the lock is only taken once.
+// The index of the dataset is rebuilt before the lock is released if the command changed the emoji or keywords.
+func lock() {
+	if locked {
+		return
+	}
+	release, err := store.Lock()
+	if err != nil {
+		fail(err.Error(), err)
+	}
+	locked = true
+	cleanups = append(cleanups, func() {
+		reindex()
+		release()
+		locked = false
+	})
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/render"
+	"github.com/gellel/emojipedia/store"
+)
+
+// class is a kind of failure, named by a stable code and ending the command with its own exit code.
+type class struct {
+	Code string
+	Exit int
+}
+
+var (
+	failed  = class{Code: "failed", Exit: exitFailed}
+	missing = class{Code: "missing", Exit: exitMissing}
+	network = class{Code: "network", Exit: exitNetwork}
+	storage = class{Code: "storage", Exit: exitStorage}
+	usage   = class{Code: "usage", Exit: exitUsage}
+)
+
+var (
+	// ErrAmbiguousCommand is the cause of a command given by a prefix of several commands.
+	ErrAmbiguousCommand = errors.New("ambiguous command")
+	// ErrFormat is the cause of output asked for in a format the command cannot print.
+	ErrFormat = errors.New("unsupported output format")
+	// ErrMissingArgument is the cause of a command run without an argument it requires.
+	ErrMissingArgument = errors.New("missing argument")
+	// ErrNotFound is the cause of a command given emoji, categories or other names that are not stored.
+	ErrNotFound = errors.New("not found")
+	// ErrPinned is the cause of a build asking for a different unicode version than the stored chart.
+	ErrPinned = errors.New("unicode version differs from the stored chart")
+	// ErrUnknownCategory is the cause of a build restricted with --category to categories the chart does not have.
+	ErrUnknownCategory = errors.New("unknown category")
+	// ErrUnknownCommand is the cause of a dispatcher given a command it does not know.
+	ErrUnknownCommand = errors.New("unknown command")
+	// ErrUnknownFlag is the cause of a command given flags it does not know.
+	ErrUnknownFlag = errors.New("unknown flag")
+)
+
+// causes are the sentinel errors the class and hint of a failure are known for, checked in order.
+var causes = []struct {
+	class class
+	err   error
+	hint  string
+}{
+	{missing, store.ErrMissingUnicodeFile, "download it with \"emojipedia unicode build\""},
+	{missing, store.ErrMissingEncyclopedia, "build it with \"emojipedia emojipedia build\""},
+	{missing, store.ErrMissingCategories, "build them with \"emojipedia categories build\""},
+	{missing, store.ErrMissingSubcategories, "build them with \"emojipedia subcategories build\""},
+	{missing, store.ErrMissingKeywords, "build them with \"emojipedia keywords build\""},
+	{storage, store.ErrCorruptJSON, "rebuild the package or run \"emojipedia repair\""},
+	{storage, store.ErrLocked, "wait for the other run to finish or retry with --wait=5m"},
+	{storage, store.ErrReadOnly, "run without --read-only to modify the dataset"},
+	{missing, render.ErrNoRasterizer, "install resvg or rsvg-convert, or render png artwork with --art=joypixels"},
+	{network, client.ErrDisallowed, "the robots.txt of the host does not allow the request"},
+	{usage, ErrAmbiguousCommand, "give more of the command name"},
+	{usage, ErrFormat, ""},
+	{usage, ErrMissingArgument, ""},
+	{missing, ErrNotFound, "list the available names with the keys command"},
+	{missing, ErrPinned, "rebuild the unicode package with --unicode-version"},
+	{usage, ErrUnknownCategory, "name categories as \"emojipedia categories keys\" lists them, such as smileys-and-emotion"},
+	{usage, ErrUnknownCommand, ""},
+	{usage, ErrUnknownFlag, "run \"emojipedia flag\" to list the flags"},
+}
+
+// failure is a failed command as written to stderr with --error-format=json.
+type failure struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	File    string `json:"file,omitempty"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// classify returns the class of the error and a hint on how to recover from it.
+// Errors of no known class are failed.
+func classify(err error) (class, string) {
+	for _, cause := range causes {
+		if errors.Is(err, cause.err) {
+			return cause.class, cause.hint
+		}
+	}
+	var (
+		netError  net.Error
+		pathError *os.PathError
+		urlError  *url.Error
+	)
+	switch {
+	case os.IsNotExist(err) || errors.Is(err, os.ErrNotExist):
+		return missing, ""
+	case errors.As(err, &urlError) || errors.As(err, &netError):
+		return network, "check the connection, or send requests through --proxy"
+	case errors.As(err, &pathError):
+		return storage, ""
+	}
+	return failed, ""
+}
+
+// fileOf returns the file the error happened on, if it names one.
+func fileOf(err error) string {
+	var (
+		fieldError *store.FieldError
+		pathError  *os.PathError
+	)
+	switch {
+	case errors.As(err, &fieldError):
+		return fieldError.File
+	case errors.As(err, &pathError):
+		return pathError.Path
+	}
+	return ""
+}
+
+// fail reports the message of a command that failed with the error and exits with the exit code of the class of the error.
+func fail(message string, err error) {
+	c, hint := classify(err)
+	report(c, message, hint, err)
+	exit(c.Exit)
+}
+
+// failWith reports the message of a command that failed with the argument class and exits with its exit code.
+func failWith(c class, message string, err error) {
+	_, hint := classify(err)
+	report(c, message, hint, err)
+	exit(c.Exit)
+}
+
+// notFound fails with the missing exit code when a get command could not find some of its arguments,
+// once it has printed those it found.
+func notFound(unknown []string, short string, verbose string) {
+	if len(unknown) == 0 {
+		return
+	}
+	failWith(missing, fmt.Sprintf(errorChoiceNotFound, strings.Join(unknown, " "), strings.ToLower(short), strings.ToLower(verbose)), ErrNotFound)
+}
+
+// notFoundStderr is notFound for a get command printing what it found as json, which is read from stdout,
+// so the arguments it could not find are reported on stderr.
+func notFoundStderr(unknown []string, short string, verbose string) {
+	if len(unknown) == 0 {
+		return
+	}
+	_, hint := classify(ErrNotFound)
+	reportOn(os.Stderr, missing, fmt.Sprintf(errorChoiceNotFound, strings.Join(unknown, " "), strings.ToLower(short), strings.ToLower(verbose)), hint, ErrNotFound)
+	exit(missing.Exit)
+}
+
+// recovered reports the error a package Get panicked with as a failure, so a command opening a package
+// that is missing or corrupt exits with the code of its class rather than a stack trace.
+func recovered() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if err, ok := r.(error); ok {
+		fail(err.Error(), err)
+	}
+	panic(r)
+}
+
+// report prints the message of a failure, or writes it to stderr as a json failure with --error-format=json.
+func report(c class, message string, hint string, err error) {
+	reportOn(os.Stdout, c, message, hint, err)
+}
+
+// reportOn writes the message of a failure to the writer, or to stderr as a json failure with --error-format=json.
+func reportOn(writer io.Writer, c class, message string, hint string, err error) {
+	if errorFormat != JSON {
+		fmt.Fprintln(writer, message)
+		return
+	}
+	content, _ := json.Marshal(failure{
+		Code:    c.Code,
+		File:    fileOf(err),
+		Hint:    hint,
+		Message: message})
+	fmt.Fprintln(os.Stderr, string(content))
+}
+
+// unknown fails with the usage exit code when a dispatcher was given a command it does not know,
+// once it has printed its help. Dispatchers given no command, or only flags, printed their help as asked.
+func unknown(arguments *arguments.Arguments) {
+	command := arguments.Get(0)
+	if len(command) == 0 || strings.HasPrefix(command, "--") {
+		return
+	}
+	failWith(usage, fmt.Sprintf(errorUnknownCommand, command), ErrUnknownCommand)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/stdin"
+	"github.com/gellel/emojipedia/text"
+	"github.com/gellel/emojipedia/user"
+)
+
+// personalize changes the user.User data of the active profile with the argument function and stores it.
+func personalize(f func(u *user.User)) {
+	lock()
+	u, err := user.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "user", err), err)
+	}
+	f(u)
+	if err := user.Write(u); err != nil {
+		fail(fmt.Sprintf(errorCannotWrite, "user", err), err)
+	}
+}
+
+// favChange favors or unfavors each emoji named by the arguments.
+func favChange(arguments *arguments.Arguments, f func(u *user.User, ID string) bool) {
+	var (
+		emojipedia = emojipedia.GetLocale(language)
+		unknown    = []string{}
+	)
+	personalize(func(u *user.User) {
+		arguments.Each(func(_ int, argument string) {
+			if e, ok := emojipedia.Get(argument); ok {
+				if f(u, e.ID) {
+					fmt.Println(fmt.Sprintf("%s %s", text.Emojize(e.Unicode), e.Name))
+				}
+			} else if strings.HasPrefix(argument, "--") == false {
+				unknown = append(unknown, argument)
+			}
+		})
+	})
+	notFound(unknown, FV, FAV)
+}
+
+func favList(arguments *arguments.Arguments) {
+	var (
+		emojipedia = emojipedia.GetLocale(language)
+	)
+	u, err := user.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "user", err), err)
+	}
+	fmt.Fprintln(writer, "\t|Name\t|Category\t|Tags")
+	for _, ID := range u.Favorites {
+		if e, ok := emojipedia.Get(ID); ok {
+			fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v\t|%v\t|%v", text.Emojize(e.Unicode), e.Name, e.Category, strings.Join(u.Tags[ID], " ")))
+		}
+	}
+	writer.Flush()
+}
+
+func favMain(arguments *arguments.Arguments) {
+	switch command(arguments, ADD, LIST, REMOVE) {
+	case A, ADD:
+		favChange(arguments.Next(), (*user.User).Favor)
+	case L, LIST:
+		favList(arguments.Next())
+	case R, REMOVE:
+		favChange(arguments.Next(), (*user.User).Unfavor)
+	default:
+		var (
+			a = stdin.Arg{
+				About:   "add one or more emoji to the favorites",
+				Args:    "<emoji> [...<emoji>]",
+				Example: "emojipedia fav add grinning-face",
+				Short:   A,
+				Verbose: ADD}
+			l = stdin.Arg{
+				About:   "show the favorite emoji and their tags",
+				Short:   L,
+				Verbose: LIST}
+			r = stdin.Arg{
+				About:   "remove one or more emoji from the favorites",
+				Args:    "<emoji> [...<emoji>]",
+				Example: "emojipedia fav remove grinning-face",
+				Short:   R,
+				Verbose: REMOVE}
+		)
+		fmt.Fprintln(writer, "usage: emojipedia [-fv fav] [<option>] [--flags]")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "favorites are kept apart from the dataset and ranked first by search")
+		slice.New(a, l, r).Each(func(_ int, i interface{}) {
+			fmt.Fprintln(writer, i.(stdin.Arg))
+		})
+		fmt.Fprintln(writer)
+		writer.Flush()
+		unknown(arguments)
+	}
+}
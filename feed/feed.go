@@ -0,0 +1,165 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojidata"
+	"github.com/gellel/emojipedia/text"
+)
+
+const (
+	// Link is the page the feeds link to, listing the emoji of each emoji version.
+	Link = "https://unicode.org/emoji/charts/emoji-versions.html"
+	// Title is the title of the feeds.
+	Title = "New emoji"
+)
+
+const (
+	namespace string = "http://www.w3.org/2005/Atom"
+	tag       string = "tag:github.com,2019:gellel/emojipedia/"
+)
+
+// Atom writes an Atom feed with an entry for each emoji.Emoji, dated by the release of its emoji version.
+func Atom(writer io.Writer, emoji []*emoji.Emoji) error {
+	latest := updated(emoji)
+	feed := atomFeed{
+		ID:      tag + "new",
+		Link:    atomLink{Href: Link},
+		Title:   Title,
+		Updated: latest.Format(time.RFC3339),
+		XMLNS:   namespace}
+	for _, e := range emoji {
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:      tag + e.ID,
+			Link:    atomLink{Href: e.Href},
+			Summary: summary(e),
+			Title:   title(e),
+			Updated: released(e, latest).Format(time.RFC3339)})
+	}
+	return write(writer, feed)
+}
+
+// RSS writes an RSS 2.0 feed with an item for each emoji.Emoji, dated by the release of its emoji version.
+func RSS(writer io.Writer, emoji []*emoji.Emoji) error {
+	latest := updated(emoji)
+	feed := rssFeed{
+		Channel: rssChannel{
+			Description: "Emoji added to the unicode.org emoji charts",
+			LastBuild:   latest.Format(time.RFC1123Z),
+			Link:        Link,
+			Title:       Title},
+		Version: "2.0"}
+	for _, e := range emoji {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Description: summary(e),
+			GUID:        rssGUID{Permalink: false, Value: tag + e.ID},
+			Link:        e.Href,
+			Published:   released(e, latest).Format(time.RFC1123Z),
+			Title:       title(e)})
+	}
+	return write(writer, feed)
+}
+
+// released returns the release date of the emoji version of the emoji.Emoji, or the argument time when the emoji.Emoji
+// has no version or its release is not known.
+func released(e *emoji.Emoji, unknown time.Time) time.Time {
+	date, ok := emojidata.Released(e.Version)
+	if ok == false {
+		return unknown
+	}
+	return date
+}
+
+// summary describes when and where the emoji.Emoji was added.
+func summary(e *emoji.Emoji) string {
+	return fmt.Sprintf("%s was added in Emoji %s to %s.", strings.Replace(e.Name, "-", " ", -1), e.Version, strings.Trim(e.Category+" / "+e.Subcategory, " /"))
+}
+
+// title returns the emoji.Emoji character followed by its name.
+func title(e *emoji.Emoji) string {
+	return fmt.Sprintf("%s %s", text.Emojize(e.Unicode), strings.Replace(e.Name, "-", " ", -1))
+}
+
+// updated returns the latest release date of the emoji versions of the emoji.Emoji, or now when none is known.
+func updated(emoji []*emoji.Emoji) time.Time {
+	latest := time.Time{}
+	for _, e := range emoji {
+		if date := released(e, latest); date.After(latest) {
+			latest = date
+		}
+	}
+	if latest.IsZero() {
+		return time.Now().UTC()
+	}
+	return latest
+}
+
+// write writes the feed as indented XML after the XML declaration.
+func write(writer io.Writer, feed interface{}) error {
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, "\n")
+	return err
+}
+
+// The fields of the feed elements are declared in the order the elements are conventionally written in.
+
+type atomEntry struct {
+	ID      string   `xml:"id"`
+	Title   string   `xml:"title"`
+	Link    atomLink `xml:"link"`
+	Updated string   `xml:"updated"`
+	Summary string   `xml:"summary"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Link    atomLink    `xml:"link"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	LastBuild   string    `xml:"lastBuildDate"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssGUID struct {
+	Permalink bool   `xml:"isPermaLink,attr"`
+	Value     string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	GUID        rssGUID `xml:"guid"`
+	Published   string  `xml:"pubDate"`
+}
@@ -0,0 +1,97 @@
+package fileinfo
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	kilobyte float64 = 1024
+	megabyte float64 = kilobyte * 1024
+	gigabyte float64 = megabyte * 1024
+)
+
+// New attempts to describe the file or folder at the path. Folders are described by every file within them:
+// their total size, the latest modification time and a checksum of their relative paths and contents,
+// so two folders holding the same files share a checksum.
+func New(path string) (*FileInfo, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		fileinfo = &FileInfo{Modified: info.ModTime().UTC(), Name: filepath.Base(path), Path: path}
+		hash     = sha256.New()
+	)
+	err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relative, _ := filepath.Rel(path, file)
+		fmt.Fprintf(hash, "%s\x00", filepath.ToSlash(relative))
+		content, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer content.Close()
+		if _, err = io.Copy(hash, content); err != nil {
+			return err
+		}
+		fileinfo.Files++
+		fileinfo.Size += info.Size()
+		if info.ModTime().After(fileinfo.Modified) {
+			fileinfo.Modified = info.ModTime().UTC()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	fileinfo.Checksum = fmt.Sprintf("%x", hash.Sum(nil))
+	return fileinfo, nil
+}
+
+// FileInfo describes a stored file or folder: its name, where it is, how many files it holds, their size in bytes,
+// when they were last modified and the SHA-256 checksum of their contents.
+type FileInfo struct {
+	Checksum string    `json:"checksum"`
+	Files    int       `json:"files"`
+	Modified time.Time `json:"modified"`
+	Name     string    `json:"name"`
+	Path     string    `json:"path"`
+	Size     int64     `json:"size"`
+}
+
+// Age method returns how long before the argument time the FileInfo was last modified.
+func (pointer *FileInfo) Age(now time.Time) time.Duration {
+	return now.Sub(pointer.Modified)
+}
+
+// Format method returns the size of the FileInfo in the largest unit it fills, with one decimal place,
+// so "1.4 MB" rather than "1 MB" or "1468006 B".
+func (pointer *FileInfo) Format() string {
+	size := float64(pointer.Size)
+	switch {
+	case size >= gigabyte:
+		return fmt.Sprintf("%.1f GB", size/gigabyte)
+	case size >= megabyte:
+		return fmt.Sprintf("%.1f MB", size/megabyte)
+	case size >= kilobyte:
+		return fmt.Sprintf("%.1f KB", size/kilobyte)
+	}
+	return fmt.Sprintf("%d B", pointer.Size)
+}
+
+// Kilobytes method returns the size of the FileInfo in kilobytes.
+func (pointer *FileInfo) Kilobytes() float64 {
+	return float64(pointer.Size) / kilobyte
+}
+
+// Megabytes method returns the size of the FileInfo in megabytes.
+func (pointer *FileInfo) Megabytes() float64 {
+	return float64(pointer.Size) / megabyte
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/flag"
+)
+
+func flagMain(arguments *arguments.Arguments) {
+	if arguments.Get(0) == "" {
+		fmt.Fprintln(writer, "usage: emojipedia [-f flag] <iso-code|flag> [...<iso-code|flag>]")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "converts ISO 3166 country codes (nz) and subdivision codes (gb-sct) into flag emoji and back")
+		fmt.Fprintln(writer)
+		writer.Flush()
+		return
+	}
+	unknown := []string{}
+	arguments.Each(func(_ int, argument string) {
+		if emoji, ok := flag.FlagFor(argument); ok {
+			fmt.Println(emoji)
+		} else if iso, ok := flag.CountryOf(argument); ok {
+			fmt.Println(strings.ToLower(iso))
+		} else if strings.HasPrefix(argument, "--") == false {
+			unknown = append(unknown, argument)
+		}
+	})
+	if len(unknown) != 0 {
+		failWith(usage, fmt.Sprintf(errorChoiceNotFound, strings.Join(unknown, " "), strings.ToLower(F), strings.ToLower(FLAG)), ErrUnknownFlag)
+	}
+}
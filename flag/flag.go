@@ -0,0 +1,103 @@
+package flag
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// Black is WAVING BLACK FLAG, the base of every subdivision tag sequence.
+	Black rune = '\U0001F3F4'
+	// Cancel is CANCEL TAG, terminating a subdivision tag sequence.
+	Cancel rune = '\U000E007F'
+)
+
+const (
+	regional rune = '\U0001F1E6'
+	tag      rune = '\U000E0000'
+)
+
+// FlagFor converts an ISO 3166-1 alpha-2 country code ("NZ") into its regional indicator flag,
+// or an ISO 3166-2 subdivision code ("GB-SCT") into its tag sequence flag.
+// Returns false if the code is not well formed.
+func FlagFor(iso string) (string, bool) {
+	iso = strings.ToUpper(strings.TrimSpace(iso))
+	if country, subdivision := split(iso); len(subdivision) != 0 {
+		if isCountry(country) == false || isSubdivision(subdivision) == false {
+			return "", false
+		}
+		runes := []rune{Black}
+		for _, r := range strings.ToLower(country + subdivision) {
+			runes = append(runes, tag+r)
+		}
+		return string(append(runes, Cancel)), true
+	}
+	if isCountry(iso) == false {
+		return "", false
+	}
+	runes := []rune{}
+	for _, r := range iso {
+		runes = append(runes, regional+(r-'A'))
+	}
+	return string(runes), true
+}
+
+// CountryOf converts a regional indicator or tag sequence flag back into its ISO code.
+// Returns false if the string is not a flag.
+func CountryOf(flag string) (string, bool) {
+	runes := []rune(flag)
+	switch {
+	case len(runes) == 2 && isRegional(runes[0]) && isRegional(runes[1]):
+		return string([]rune{'A' + (runes[0] - regional), 'A' + (runes[1] - regional)}), true
+	case len(runes) > 4 && runes[0] == Black && runes[len(runes)-1] == Cancel:
+		code := []rune{}
+		for _, r := range runes[1 : len(runes)-1] {
+			if r <= tag || r >= Cancel {
+				return "", false
+			}
+			code = append(code, unicode.ToUpper(r-tag))
+		}
+		iso := string(code[:2]) + "-" + string(code[2:])
+		if _, ok := FlagFor(iso); ok == false {
+			return "", false
+		}
+		return iso, true
+	}
+	return "", false
+}
+
+func isCountry(s string) bool {
+	if len(s) != 2 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isRegional(r rune) bool {
+	return r >= regional && r < regional+26
+}
+
+func isSubdivision(s string) bool {
+	if len(s) == 0 || len(s) > 3 {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func split(iso string) (string, string) {
+	substrings := strings.SplitN(iso, "-", 2)
+	if len(substrings) == 1 {
+		return iso, ""
+	}
+	return substrings[0], substrings[1]
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/gemoji"
+	"github.com/gellel/emojipedia/merge"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/webhook"
+)
+
+// buildGemoji builds the emoji from github/gemoji alone, without requiring the unicode.org package.
+func buildGemoji() {
+	fmt.Println(fmt.Sprintf(statusBuildPackage, EMOJIPEDIA))
+	if err := store.Writable(); err != nil {
+		fail(fmt.Sprintf(errorBuildPackage, EMOJIPEDIA, err), err)
+	}
+	lock()
+	if webhook.Enabled() {
+		previous, _ = emojipedia.Open()
+	}
+	entries, err := gemoji.Open(gemoji.URL)
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, gemoji.URL, err), err)
+	}
+	fetched := time.Now()
+	for i, g := range entries {
+		e, err := g.New(i + 1)
+		if err != nil {
+			fmt.Println(fmt.Sprintf(errorSkipped, g.Emoji, err))
+			continue
+		}
+		if err := emoji.Write(e.Stamp(gemoji.Name, fetched)); err != nil && errors.Is(err, store.ErrInvalid) == false {
+			fail(fmt.Sprintf(errorBuildPackage, EMOJIPEDIA, err), err)
+		}
+	}
+	postbuild(EMOJIPEDIA)
+}
+
+// mergeGemoji merges the github/gemoji emoji into the stored unicode.org emoji by the configured precedence.
+// Descriptions already fetched from emojipedia.org into the previous dataset are carried over.
+func mergeGemoji() {
+	entries, err := gemoji.Open(gemoji.URL)
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, gemoji.URL, err), err)
+	}
+	current, err := emojipedia.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, EMOJIPEDIA, err), err)
+	}
+	var (
+		fetched    = time.Now()
+		supplement = emojipedia.New()
+	)
+	for i, g := range entries {
+		if e, err := g.New(i + 1); err == nil {
+			supplement.Add(e.Stamp(gemoji.Name, fetched))
+		}
+	}
+	sources := map[string]*emojipedia.Emojipedia{merge.Unicode: current, merge.Gemoji: supplement}
+	if previous != nil {
+		sources[merge.Emojipedia] = previous
+	}
+	merged := merge.All(precedence, sources)
+	merged.Each(func(_ string, e *emoji.Emoji) {
+		if err := emoji.Write(e); err != nil {
+			fail(fmt.Sprintf(errorBuildPackage, EMOJIPEDIA, err), err)
+		}
+	})
+	fmt.Println(fmt.Sprintf("merged gemoji into %v emoji", merged.Len()))
+}
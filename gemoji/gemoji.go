@@ -0,0 +1,126 @@
+package gemoji
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojidata"
+	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/text"
+)
+
+const (
+	// Name is the source name gemoji is selected and recorded by.
+	Name = "gemoji"
+	// URL is the emoji database published by github/gemoji.
+	URL = "https://raw.githubusercontent.com/github/gemoji/master/db/emoji.json"
+)
+
+// Open attempts to read a gemoji emoji.json from a local file or, if the argument is a URL, over HTTP.
+func Open(path string) ([]*Gemoji, error) {
+	reader, err := client.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return Parse(reader)
+}
+
+// Parse reads a gemoji formatted emoji.json into Gemoji pointers, in the order they are listed.
+func Parse(reader io.Reader) ([]*Gemoji, error) {
+	gemoji := []*Gemoji{}
+	err := json.NewDecoder(reader).Decode(&gemoji)
+	if err != nil {
+		return nil, err
+	}
+	return gemoji, nil
+}
+
+// Gemoji is an entry of the github/gemoji database.
+type Gemoji struct {
+	Aliases        []string `json:"aliases"`
+	Category       string   `json:"category"`
+	Description    string   `json:"description"`
+	Emoji          string   `json:"emoji"`
+	IOSVersion     string   `json:"ios_version"`
+	Tags           []string `json:"tags"`
+	UnicodeVersion string   `json:"unicode_version"`
+}
+
+// ID method returns the stable emoji.ID of the Gemoji character.
+func (pointer *Gemoji) ID() string {
+	return emoji.ID(pointer.unicode())
+}
+
+// Merge method adds the Gemoji aliases to the emoji.Emoji shortcodes and its tags to the emoji.Emoji keywords,
+// skipping any the emoji.Emoji already holds, and gives an emoji.Emoji without an emoji version the Gemoji's.
+func (pointer *Gemoji) Merge(e *emoji.Emoji) *emoji.Emoji {
+	if len(e.Version) == 0 {
+		e.Version = emojidata.Encoded(pointer.UnicodeVersion)
+	}
+	if e.Shortcodes == nil {
+		e.Shortcodes = &slice.Slice{}
+	}
+	if e.Keywords == nil {
+		e.Keywords = &slice.Slice{}
+	}
+	union(e.Shortcodes, pointer.Aliases, func(s string) string { return s })
+	union(e.Keywords, pointer.Tags, text.Normalize)
+	return e
+}
+
+// New method creates an emoji.Emoji from the Gemoji alone. gemoji does not group emoji into subcategories
+// or number them as unicode.org does, so the number is the 1-based position given as the argument.
+// Entries without a description or character cannot be named and return an error.
+func (pointer *Gemoji) New(number int) (*emoji.Emoji, error) {
+	var (
+		codes     = []string{}
+		variation = false
+	)
+	for _, r := range pointer.Emoji {
+		codes = append(codes, fmt.Sprintf("U+%04X", r))
+		if r == emojidata.EmojiSelector {
+			variation = true
+		}
+	}
+	e, err := emoji.NewBuilder().
+		Category(text.Normalize(pointer.Category)).
+		Codes(codes...).
+		Name(text.Normalize(pointer.Description)).
+		Number(number).
+		Position(number).
+		Unicode(pointer.unicode()).
+		Variation(variation).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return pointer.Merge(e), nil
+}
+
+// unicode returns the Gemoji character as an escaped emoji unicode string.
+func (pointer *Gemoji) unicode() string {
+	unicode := ""
+	for _, r := range pointer.Emoji {
+		unicode = unicode + fmt.Sprintf("\\U%08x", r)
+	}
+	return unicode
+}
+
+// union appends every value not already held by the slice.Slice, after transforming it.
+func union(s *slice.Slice, values []string, f func(s string) string) {
+	held := map[string]bool{}
+	s.Each(func(_ int, i interface{}) {
+		held[i.(string)] = true
+	})
+	for _, value := range values {
+		value = f(value)
+		if len(value) != 0 && held[value] == false {
+			held[value] = true
+			s.Append(value)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gellel/emojipedia/anki"
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/categories"
+	"github.com/gellel/emojipedia/category"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/dot"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/feed"
+	"github.com/gellel/emojipedia/manifest"
+	"github.com/gellel/emojipedia/schema"
+	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/snippet"
+	"github.com/gellel/emojipedia/stdin"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/subcategory"
+	"github.com/gellel/emojipedia/text"
+	"github.com/gellel/emojipedia/user"
+)
+
+func genDot(arguments *arguments.Arguments) {
+	var (
+		categories = categories.Get()
+		filter     = map[string]bool{}
+		graph      = dot.New("emojipedia")
+		keywords   = false
+	)
+	arguments.Each(func(_ int, argument string) {
+		switch strings.ToUpper(argument) {
+		case K, KEYWORDS:
+			keywords = true
+		default:
+			filter[argument] = true
+		}
+	})
+	categories.Each(func(c *category.Category) {
+		if len(filter) != 0 && filter[c.Name] == false {
+			return
+		}
+		graph.Node("category:"+c.Name, c.Name, "box")
+		for _, s := range c.SubcategoryRefs() {
+			graph.Node("subcategory:"+s.Name, s.Name, "ellipse")
+			graph.Edge("category:"+c.Name, "subcategory:"+s.Name)
+			for _, e := range s.EmojiRefs() {
+				graph.Node("emoji:"+e.Name, fmt.Sprintf("%s %s", text.Emojize(e.Unicode), e.Name), "plaintext")
+				graph.Edge("subcategory:"+s.Name, "emoji:"+e.Name)
+				if keywords == false {
+					continue
+				}
+				e.Keywords.Each(func(_ int, i interface{}) {
+					graph.Node("keyword:"+i.(string), i.(string), "note")
+					graph.Edge("emoji:"+e.Name, "keyword:"+i.(string))
+				})
+			}
+		}
+	})
+	fmt.Println(graph)
+}
+
+// genEmoji returns the emoji to generate a file for, in unicode.org order. Only the emoji of the categories given as
+// arguments are returned when there are any, only the favorites with --favorites and only the emoji recommended for
+// general interchange with --rgi, leaving out minimally-qualified and unqualified sequences.
+func genEmoji(arguments *arguments.Arguments) []*emoji.Emoji {
+	var (
+		emojipedia = emojipedia.GetLocale(language)
+		filter     = map[string]bool{}
+		values     = []*emoji.Emoji{}
+	)
+	_, favorites := arguments.Flag("favorites")
+	_, rgi := arguments.Flag("rgi")
+	u, err := user.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "user", err), err)
+	}
+	arguments.Each(func(_ int, argument string) {
+		if strings.HasPrefix(argument, "--") == false {
+			filter[text.Normalize(argument)] = true
+		}
+	})
+	emojipedia.Each(func(ID string, e *emoji.Emoji) {
+		if len(filter) != 0 && filter[e.Category] == false {
+			return
+		}
+		if favorites && u.Favorite(ID) == false {
+			return
+		}
+		if rgi && e.RGI == false {
+			return
+		}
+		values = append(values, e)
+	})
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Number < values[j].Number
+	})
+	return values
+}
+
+// genSnippets writes the snippet.Snippets of the emoji to stdout in the format of the argument function.
+func genSnippets(arguments *arguments.Arguments, write func(writer io.Writer, snippets []*snippet.Snippet) error) {
+	snippets := []*snippet.Snippet{}
+	for _, e := range genEmoji(arguments) {
+		snippets = append(snippets, snippet.New(e)...)
+	}
+	if err := write(os.Stdout, snippets); err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "stdout", err), err)
+	}
+}
+
+func genSchema(arguments *arguments.Arguments) {
+	folder := arguments.Get(0)
+	if len(folder) == 0 || strings.HasPrefix(folder, "--") {
+		if err := store.Writable(); err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, directory.Schema, err), err)
+		}
+		folder = directory.Schema
+	}
+	if err := os.MkdirAll(folder, os.ModePerm); err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, folder, err), err)
+	}
+	schemas := map[string]schema.Schema{
+		"category":    schema.Of("Category", category.Category{}),
+		"emoji":       schema.Of("Emoji", emoji.Emoji{}),
+		"keywords":    schema.Of("Keywords", slice.Slice{}),
+		"manifest":    schema.Of("Manifest", manifest.Manifest{}),
+		"subcategory": schema.Of("Subcategory", subcategory.Subcategory{})}
+	for name, s := range schemas {
+		content, err := store.Marshal(s)
+		if err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, name, err), err)
+		}
+		filename := filepath.Join(folder, name+".schema.json")
+		if err := ioutil.WriteFile(filename, content, os.ModePerm); err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, filename, err), err)
+		}
+		fmt.Println(filename)
+	}
+}
+
+func genMain(arguments *arguments.Arguments) {
+	switch command(arguments, ALFRED, ANKI, ATOM, DOT, ESPANSO, RAYCAST, RSS, SCHEMA, VSCODE) {
+	case A, ALFRED:
+		genSnippets(arguments.Next(), snippet.Alfred)
+	case AK, ANKI:
+		if err := anki.Write(os.Stdout, genEmoji(arguments.Next())); err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, "stdout", err), err)
+		}
+	case AT, ATOM:
+		if err := feed.Atom(os.Stdout, added(arguments.Next())); err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, "stdout", err), err)
+		}
+	case D, DOT:
+		genDot(arguments.Next())
+	case E, ESPANSO:
+		genSnippets(arguments.Next(), snippet.Espanso)
+	case R, RAYCAST:
+		genSnippets(arguments.Next(), snippet.Raycast)
+	case RS, RSS:
+		if err := feed.RSS(os.Stdout, added(arguments.Next())); err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, "stdout", err), err)
+		}
+	case SC, SCHEMA:
+		genSchema(arguments.Next())
+	case V, VSCODE:
+		if err := snippet.VSCode(os.Stdout, genEmoji(arguments.Next())); err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, "stdout", err), err)
+		}
+	default:
+		var (
+			a = stdin.Arg{
+				About:   "alfred snippet collection expanding shortcodes such as :taco: to their emoji, to save as a .alfredsnippets file [categories...] [--favorites] [--rgi]",
+				Example: "emojipedia gen alfred > emoji.alfredsnippets",
+				Short:   A,
+				Verbose: ALFRED}
+			ak = stdin.Arg{
+				About:   "anki notes with the emoji on the front and its name, keywords and description on the back [categories...] [--favorites] [--rgi]",
+				Example: "emojipedia gen anki smileys-and-emotion > emoji.txt",
+				Short:   AK,
+				Verbose: ANKI}
+			at = stdin.Arg{
+				About:   "atom feed of the emoji added since an emoji version or date, newest first [--since=15.0|2023-01-01]",
+				Example: "emojipedia gen atom --since=15.0 > emoji.atom",
+				Short:   AT,
+				Verbose: ATOM}
+			d = stdin.Arg{
+				About:   "graphviz graph of categories, subcategories and emoji [categories...] [-k keywords]",
+				Short:   D,
+				Verbose: DOT}
+			e = stdin.Arg{
+				About:   "espanso match file expanding shortcodes such as :taco: to their emoji [categories...] [--favorites] [--rgi]",
+				Example: "emojipedia gen espanso food-drink --favorites > emoji.yml",
+				Short:   E,
+				Verbose: ESPANSO}
+			r = stdin.Arg{
+				About:   "raycast snippets json expanding shortcodes such as :taco: to their emoji [categories...] [--favorites] [--rgi]",
+				Example: "emojipedia gen raycast > emoji.json",
+				Short:   R,
+				Verbose: RAYCAST}
+			rs = stdin.Arg{
+				About:   "rss feed of the emoji added since an emoji version or date, newest first [--since=15.0|2023-01-01]",
+				Example: "emojipedia gen rss --since=2023-01-01 > emoji.rss",
+				Short:   RS,
+				Verbose: RSS}
+			sc = stdin.Arg{
+				About:   "json schema files for the stored emoji, category, subcategory, keywords and manifest [folder], beside the dataset by default",
+				Short:   SC,
+				Verbose: SCHEMA}
+			v = stdin.Arg{
+				About:   "vs code snippets completing shortcodes and keywords to their emoji, to save as a .code-snippets file [categories...] [--favorites] [--rgi]",
+				Example: "emojipedia gen vscode > emoji.code-snippets",
+				Short:   V,
+				Verbose: VSCODE}
+		)
+		fmt.Fprintln(writer, "usage: emojipedia [-g gen] [<format>] [<options>]")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "formats")
+		slice.New(a, ak, at, d, e, r, rs, sc, v).Each(func(_ int, i interface{}) {
+			fmt.Fprintln(writer, i.(stdin.Arg))
+		})
+		fmt.Fprintln(writer)
+		writer.Flush()
+		unknown(arguments)
+	}
+}
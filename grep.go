@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/history"
+	"github.com/gellel/emojipedia/segment"
+	"github.com/gellel/emojipedia/text"
+)
+
+// grepTargets resolves a query to the IDs of the emoji it names. An exact name, ID, shortcode or pasted emoji
+// selects that emoji alone; otherwise every emoji found by keyword or partial name is selected.
+func grepTargets(emojipedia *emojipedia.Emojipedia, query string) map[string]bool {
+	var (
+		name    = text.Normalize(strings.Trim(query, ":"))
+		targets = map[string]bool{}
+	)
+	if e, ok := emojipedia.Get(name); ok {
+		targets[e.ID] = true
+		return targets
+	}
+	if e, ok := grepGlyph(emojipedia, query); ok {
+		targets[e.ID] = true
+		return targets
+	}
+	emojipedia.Search(name).Each(func(_ int, i interface{}) {
+		targets[i.(*emoji.Emoji).ID] = true
+	})
+	return targets
+}
+
+// grepGlyph finds the emoji for a character, ignoring any skin tone modifiers it carries.
+func grepGlyph(emojipedia *emojipedia.Emojipedia, character string) (*emoji.Emoji, bool) {
+	if e, ok := emojipedia.Glyph(character); ok {
+		return e, ok
+	}
+	return emojipedia.Glyph(strings.Map(func(r rune) rune {
+		if r >= 0x1F3FB && r <= 0x1F3FF {
+			return -1
+		}
+		return r
+	}, character))
+}
+
+// grepReader prints the lines of the reader that contain one of the target emoji, returning the IDs of the target emoji
+// found on the printed lines.
+func grepReader(emojipedia *emojipedia.Emojipedia, targets map[string]bool, reader io.Reader, prefix string) ([]string, error) {
+	var (
+		found   = []string{}
+		scanner = bufio.NewScanner(reader)
+		seen    = map[string]bool{}
+	)
+	for scanner.Scan() {
+		var (
+			line    = scanner.Text()
+			printed = false
+		)
+		for _, cluster := range segment.Segment(line) {
+			e, ok := grepGlyph(emojipedia, cluster.Text)
+			if ok == false || targets[e.ID] == false {
+				continue
+			}
+			if printed == false {
+				fmt.Println(prefix + line)
+				printed = true
+			}
+			if seen[e.ID] == false {
+				seen[e.ID] = true
+				found = append(found, e.ID)
+			}
+		}
+	}
+	return found, scanner.Err()
+}
+
+func grepMain(arguments *arguments.Arguments) {
+	query := arguments.Get(0)
+	if len(query) == 0 {
+		failWith(usage, fmt.Sprintf(errorCannotFind, "query"), ErrMissingArgument)
+	}
+	var (
+		emojipedia = emojipedia.GetLocale(language)
+		files      = []string{}
+		seen       = map[string]bool{}
+		targets    = grepTargets(emojipedia, query)
+		used       = []string{}
+	)
+	if len(targets) == 0 {
+		failWith(usage, fmt.Sprintf(errorChoiceNotFound, query, strings.ToLower(GR), strings.ToLower(GREP)), ErrNotFound)
+	}
+	arguments.Next().Each(func(_ int, argument string) {
+		files = append(files, argument)
+	})
+	if len(files) == 0 {
+		found, err := grepReader(emojipedia, targets, os.Stdin, "")
+		if err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, "stdin", err), err)
+		}
+		used = found
+	}
+	for _, name := range files {
+		file, err := os.Open(name)
+		if err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, name, err), err)
+		}
+		prefix := ""
+		if len(files) > 1 {
+			prefix = name + ":"
+		}
+		found, err := grepReader(emojipedia, targets, file, prefix)
+		file.Close()
+		if err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, name, err), err)
+		}
+		for _, ID := range found {
+			if seen[ID] == false {
+				seen[ID] = true
+				used = append(used, ID)
+			}
+		}
+	}
+	if len(used) == 0 {
+		exit(exitFailed)
+	}
+	// Only the emoji found on the printed lines are recorded as used.
+	if err := history.Record(used...); err != nil {
+		fmt.Fprintln(os.Stderr, fmt.Sprintf(errorHistory, err))
+	}
+}
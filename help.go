@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/stdin"
+)
+
+// globals are the flags every command reads.
+var globals = []string{
+	"by-id", "ca-bundle", "category", "client-cert", "client-key", "concurrency", "cpuprofile", "delay", "error-format",
+	"header", "indent", "insecure", "limit", "locale", "memprofile", "no-pager", "offset", "parser", "precedence", "profile",
+	"proxy", "range", "read-only", "source", "strict", "timing", "trace", "unicode-version", "user-agent", "wait", "webhook",
+	"webhook-secret"}
+
+// usages describe every command of the program: what it does, the flags it reads besides the globals
+// and the packages its options read, which must be built before they can run.
+var usages = []stdin.Arg{
+	{About: altDescription, Requires: packages(EMOJIPEDIA), Short: A, Verbose: ALT},
+	{About: annotateDescription, Args: "[template]", Requires: packages(EMOJIPEDIA), Short: AN, Verbose: ANNOTATE},
+	{About: applyDescription, Args: "<file|->", Requires: packages(EMOJIPEDIA), Short: AP, Verbose: APPLY},
+	{About: benchDescription, Args: "[keyword]", Requires: packages(EMOJIPEDIA, KEYWORDS), Short: BB, Verbose: BENCH},
+	{About: buildDescription, Flags: []string{"restart"}, Short: B, Verbose: BUILD},
+	{About: categoriesDescription, Flags: []string{"regex"}, Requires: packages(CATEGORIES), Short: C, Verbose: CATEGORIES},
+	{About: categoryDescription, Args: "<category>", Requires: packages(CATEGORIES), Short: CC, Verbose: CATEGORY},
+	{About: checkUpstreamDescription, Requires: packages(EMOJIPEDIA), Short: CU, Verbose: CHECKUPSTREAM},
+	{About: daemonDescription, Args: "[path]", Requires: packages(EMOJIPEDIA), Short: DM, Verbose: DAEMON},
+	{About: diffDescription, Args: "<profile> [profile]", Flags: []string{"patch"}, Requires: packages(EMOJIPEDIA), Short: D, Verbose: DIFF},
+	{About: discordDescription, Short: DC, Verbose: DISCORD},
+	{About: doctorDescription, Short: DR, Verbose: DOCTOR},
+	{About: emojiDescription, Args: "<emoji>", Flags: []string{"as", "metric"}, Requires: packages(EMOJIPEDIA), Short: EE, Verbose: EMOJI},
+	{About: emojipediaDescription, Flags: []string{"as", "regex", "rgi", "stdin", "variants"}, Requires: packages(EMOJIPEDIA), Short: E, Verbose: EMOJIPEDIA},
+	{About: favDescription, Requires: packages(EMOJIPEDIA), Short: FV, Verbose: FAV},
+	{About: fetchDatasetDescription, Args: "<url|file|version>", Flags: []string{"verify"}, Short: FD, Verbose: FETCHDATASET},
+	{About: flagDescription, Args: "<iso-code|flag>", Short: F, Verbose: FLAG},
+	{About: genDescription, Flags: []string{"favorites", "rgi"}, Requires: packages(EMOJIPEDIA), Short: G, Verbose: GEN},
+	{About: grepDescription, Args: "<name|keyword|emoji> [files...]", Requires: packages(EMOJIPEDIA), Short: GR, Verbose: GREP},
+	{About: helpDescription, Args: "[command]", Flags: []string{"as"}, Short: H, Verbose: HELP},
+	{About: historyDescription, Requires: packages(EMOJIPEDIA), Short: HI, Verbose: HISTORY},
+	{About: imagesDescription, Short: IM, Verbose: IMAGES},
+	{About: keywordsDescription, Requires: packages(KEYWORDS), Short: K, Verbose: KEYWORDS},
+	{About: newDescription, Flags: []string{"since"}, Requires: packages(EMOJIPEDIA), Short: NW, Verbose: NEW},
+	{About: packDescription, Args: "[file]", Flags: []string{"sign", "version"}, Requires: packages(EMOJIPEDIA), Short: PK, Verbose: PACK},
+	{About: profilesDescription, Short: PP, Verbose: PROFILES},
+	{About: renderDescription, Args: "<emoji> [sizes...]", Flags: []string{"art", "out"}, Requires: packages(EMOJIPEDIA), Short: RN, Verbose: RENDER},
+	{About: repairDescription, Short: RP, Verbose: REPAIR},
+	{About: restoreDescription, Short: RL, Verbose: RESTORELAST},
+	{About: serveDescription, Args: "[address]", Requires: packages(EMOJIPEDIA), Short: SV, Verbose: SERVE},
+	{About: shellDescription, Short: SH, Verbose: SHELL},
+	{About: statusDescription, Short: ST, Verbose: STATUS},
+	{About: subcategoriesDescription, Flags: []string{"regex"}, Requires: packages(SUBCATEGORIES), Short: S, Verbose: SUBCATEGORIES},
+	{About: subcategoryDescription, Args: "<subcategory>", Requires: packages(SUBCATEGORIES), Short: SS, Verbose: SUBCATEGORY},
+	{About: tagDescription, Requires: packages(EMOJIPEDIA), Short: TG, Verbose: TAG},
+	{About: topicsDescription, Requires: packages(EMOJIPEDIA, KEYWORDS), Short: TP, Verbose: TOPICS},
+	{About: treeDescription, Args: "[depth] [categories...]", Requires: packages(CATEGORIES, SUBCATEGORIES), Short: T, Verbose: TREE},
+	{About: unicodeDescription, Short: U, Verbose: UNICODE},
+	{About: watchDescription, Args: "[interval]", Short: W, Verbose: WATCH}}
+
+// packages returns the names of the packages as they are built and listed.
+func packages(names ...string) []string {
+	for i, name := range names {
+		names[i] = strings.ToLower(name)
+	}
+	return names
+}
+
+// helpMain prints the usage of every command, or of the named commands, with the flags they read and the packages
+// they need. With --as=json they are printed as one json object holding the global flags and the commands.
+func helpMain(arguments *arguments.Arguments) {
+	var (
+		commands = []stdin.Arg{}
+		unknown  = []string{}
+	)
+	arguments.Each(func(_ int, argument string) {
+		if strings.HasPrefix(argument, "--") {
+			return
+		}
+		for _, arg := range usages {
+			if strings.EqualFold(argument, arg.Verbose) || strings.EqualFold(argument, arg.Short) || strings.EqualFold("-"+argument, arg.Short) {
+				commands = append(commands, arg)
+				return
+			}
+		}
+		unknown = append(unknown, argument)
+	})
+	if len(unknown) != 0 {
+		failWith(usage, fmt.Sprintf(errorUnknownCommand, strings.Join(unknown, " ")), ErrUnknownCommand)
+	}
+	if len(commands) == 0 {
+		commands = usages
+	}
+	as, _ := arguments.Flag("as")
+	switch strings.ToLower(as) {
+	case "":
+		fmt.Fprintln(writer, "usage: emojipedia [-h help] [command...] [--as=json]")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "commands, the flags they read and the packages they need built")
+		for _, arg := range commands {
+			fmt.Fprintln(writer, arg)
+		}
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "flags read by every command")
+		fmt.Fprintln(writer, fmt.Sprintf("  --%s", strings.Join(globals, " --")))
+		fmt.Fprintln(writer)
+		writer.Flush()
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(struct {
+			Commands []stdin.Arg `json:"commands"`
+			Flags    []string    `json:"flags"`
+		}{commands, globals})
+		if err != nil {
+			fail(err.Error(), err)
+		}
+	default:
+		failWith(usage, fmt.Sprintf(errorAs, as), ErrFormat)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/history"
+	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/stdin"
+	"github.com/gellel/emojipedia/text"
+)
+
+func historyList(arguments *arguments.Arguments) {
+	var (
+		emojipedia = emojipedia.GetLocale(language)
+		now        = time.Now().UTC()
+	)
+	h, err := history.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "history", err), err)
+	}
+	fmt.Fprintln(writer, "N\t|\t|Name\t|Uses\t|Last used\t|Frecency")
+	for i, ID := range h.IDs(now) {
+		if e, ok := emojipedia.Get(ID); ok {
+			use := h.Uses[ID]
+			fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v\t|%v\t|%v\t|%v\t|%.2f", i, text.Emojize(e.Unicode), e.Name, use.Count, use.Last.Local().Format("2006-01-02 15:04"), h.Frecency(ID, now)))
+		}
+	}
+	writer.Flush()
+}
+
+func historyMain(arguments *arguments.Arguments) {
+	switch command(arguments, CLEAR, LIST) {
+	case C, CLEAR:
+		remove(HISTORY, history.Remove)
+	case L, LIST:
+		historyList(arguments.Next())
+	default:
+		var (
+			c = stdin.Arg{
+				About:   "forget the emoji used so far",
+				Short:   C,
+				Verbose: CLEAR}
+			l = stdin.Arg{
+				About:   "show the emoji used, most often and recently used first",
+				Short:   L,
+				Verbose: LIST}
+		)
+		fmt.Fprintln(writer, "usage: emojipedia [-hi history] [<option>] [--flags]")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "emoji returned by get and grep are remembered and ranked first by search and completion")
+		slice.New(c, l).Each(func(_ int, i interface{}) {
+			fmt.Fprintln(writer, i.(stdin.Arg))
+		})
+		fmt.Fprintln(writer)
+		writer.Flush()
+		unknown(arguments)
+	}
+}
@@ -0,0 +1,147 @@
+package history
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/store"
+)
+
+const (
+	// HalfLife is how long it takes a use of an emoji to count for half as much in its Frecency.
+	HalfLife = 7 * 24 * time.Hour
+	// wait is how long Record waits, beyond store.Wait, for another run to finish recording its lookups.
+	wait = 5 * time.Second
+)
+
+// New instantiates a new empty History pointer.
+func New() *History {
+	return &History{Uses: map[string]*Use{}}
+}
+
+// Open attempts to open the History of the active profile. A profile without a History opens an empty History.
+func Open() (*History, error) {
+	content, err := ioutil.ReadFile(directory.History)
+	if os.IsNotExist(err) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	history := New()
+	err = store.Decode(directory.History, content, history)
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// Record opens the History of the active profile, records the use of the emoji IDs and stores it, holding the lock
+// of the History file so concurrent lookups do not lose each other's uses. Nothing is recorded while the dataset is read-only.
+func Record(IDs ...string) error {
+	if len(IDs) == 0 || store.Writable() != nil {
+		return nil
+	}
+	release, err := store.LockFile(directory.History, store.Wait+wait)
+	if err != nil {
+		return err
+	}
+	defer release()
+	history, err := Open()
+	if err != nil {
+		return err
+	}
+	history.Add(time.Now().UTC(), IDs...)
+	return Write(history)
+}
+
+// Remove deletes the History stored in the active profile.
+func Remove() error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	return store.Trash(directory.History)
+}
+
+// Write stores the History in the active profile.
+func Write(history *History) error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(filepath.Dir(directory.History), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	content, err := store.Marshal(history)
+	if err != nil {
+		return err
+	}
+	return store.WriteFile(directory.History, content)
+}
+
+// History counts the uses of emoji returned by lookups, by emoji ID, so that those used often
+// and recently can be ranked first.
+type History struct {
+	Uses map[string]*Use `json:"uses"`
+}
+
+// Use is how often an emoji was used, when it was last used and its frecency at that time.
+type Use struct {
+	Count    int       `json:"count"`
+	Frecency float64   `json:"frecency"`
+	Last     time.Time `json:"last"`
+}
+
+// Add method records one use of each emoji ID at the argument time.
+func (pointer *History) Add(at time.Time, IDs ...string) *History {
+	for _, ID := range IDs {
+		use, ok := pointer.Uses[ID]
+		if ok == false {
+			use = &Use{}
+			pointer.Uses[ID] = use
+		}
+		use.Frecency = decay(use.Frecency, at.Sub(use.Last)) + 1
+		use.Count++
+		use.Last = at
+	}
+	return pointer
+}
+
+// Frecency method returns the uses of the emoji ID at the argument time, each halved for every HalfLife
+// since it was made. Emoji that were never used have no frecency.
+func (pointer *History) Frecency(ID string, at time.Time) float64 {
+	use, ok := pointer.Uses[ID]
+	if ok == false {
+		return 0
+	}
+	return decay(use.Frecency, at.Sub(use.Last))
+}
+
+// IDs method returns the used emoji IDs, by highest frecency at the argument time first.
+func (pointer *History) IDs(at time.Time) []string {
+	IDs := []string{}
+	for ID := range pointer.Uses {
+		IDs = append(IDs, ID)
+	}
+	sort.Slice(IDs, func(i, j int) bool {
+		a, b := pointer.Frecency(IDs[i], at), pointer.Frecency(IDs[j], at)
+		if a != b {
+			return a > b
+		}
+		return IDs[i] < IDs[j]
+	})
+	return IDs
+}
+
+// decay returns the frecency left of the argument frecency once the duration has passed.
+func decay(frecency float64, d time.Duration) float64 {
+	if d <= 0 || frecency == 0 {
+		return frecency
+	}
+	return frecency * math.Pow(0.5, float64(d)/float64(HalfLife))
+}
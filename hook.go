@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/store"
+)
+
+// hook runs before a command with the name of the command and its arguments. It returns a function
+// to run once the command has finished, including when it exits, or nil when there is nothing to finish.
+type hook func(name string, arguments *arguments.Arguments) func()
+
+var (
+	hooks = []hook{}
+	// writes are the commands that always write to the dataset and take its lock before they run.
+	writes = map[string]bool{
+		APPLY:        true,
+		BUILD:        true,
+		FETCHDATASET: true,
+		REPAIR:       true,
+		RESTORELAST:  true}
+	// removes are the commands whose remove command writes to the dataset, which takes its lock before it runs too.
+	removes = map[string]bool{
+		CATEGORIES:    true,
+		EMOJIPEDIA:    true,
+		KEYWORDS:      true,
+		SUBCATEGORIES: true,
+		TOPICS:        true,
+		UNICODE:       true}
+)
+
+// use adds hooks to run around every command, in the order they are added.
+// The functions they return run in the reverse order.
+func use(h ...hook) {
+	hooks = append(hooks, h...)
+}
+
+// run runs the command with its arguments once every hook has run.
+func run(name string, f func(arguments *arguments.Arguments), arguments *arguments.Arguments) {
+	for _, h := range hooks {
+		if finish := h(name, arguments); finish != nil {
+			cleanups = append(cleanups, finish)
+		}
+	}
+	f(arguments)
+}
+
+// locking takes the dataset lock for the commands that always write to the dataset and for the remove commands
+// of the packages, refusing them while the dataset is read-only.
+func locking(name string, arguments *arguments.Arguments) func() {
+	switch {
+	case writes[name]:
+		if err := store.Writable(); err != nil {
+			fail(fmt.Sprintf(errorBuildPackage, strings.ToLower(name), err), err)
+		}
+	case removes[name] && removal(arguments):
+		if err := store.Writable(); err != nil {
+			fail(fmt.Sprintf(errorRemovePackage, strings.ToLower(name), err), err)
+		}
+	default:
+		return nil
+	}
+	lock()
+	return nil
+}
+
+// removal checks whether the arguments of a command name its remove command.
+func removal(arguments *arguments.Arguments) bool {
+	switch command(arguments, REMOVE) {
+	case R, REMOVE:
+		return true
+	}
+	return false
+}
+
+// indexing rebuilds the index of the dataset once a command that changed the emoji or keywords without
+// taking the dataset lock has finished. Commands holding the lock rebuild it before releasing the lock.
+func indexing(name string, _ *arguments.Arguments) func() {
+	return reindex
+}
+
+// paging selects the rows of the table a command prints with --limit, --offset and --range=a..b, and pipes the table
+// of a list through $PAGER when it is printed to a terminal, unless --no-pager is set.
+func paging(name string, arguments *arguments.Arguments) func() {
+	offset, limit := 0, -1
+	for _, flag := range []string{"limit", "offset", "range"} {
+		value, ok := arguments.Flag(flag)
+		if ok == false {
+			continue
+		}
+		var err error
+		switch flag {
+		case "limit":
+			limit, err = strconv.Atoi(value)
+		case "offset":
+			offset, err = strconv.Atoi(value)
+		case "range":
+			offset, limit, err = rangeOf(value)
+		}
+		if err != nil || offset < 0 {
+			failWith(usage, fmt.Sprintf(errorPage, value), err)
+		}
+	}
+	writer.Page(offset, limit)
+	_, off := arguments.Flag("no-pager")
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if off || interactive || len(pager) == 0 || terminal() == false {
+		return nil
+	}
+	switch strings.ToUpper(arguments.Get(0)) {
+	case K, KEYS, L, LIST:
+	default:
+		return nil
+	}
+	cmd := exec.Command(pager[0], pager[1:]...)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	input, err := cmd.StdinPipe()
+	if err != nil || cmd.Start() != nil {
+		return nil
+	}
+	writer.SetOutput(input)
+	return func() {
+		input.Close()
+		cmd.Wait()
+		writer.SetOutput(os.Stdout)
+	}
+}
+
+// rangeOf reads the rows "a..b" counted from 1 as the offset and limit of a page. Either end may be left out.
+func rangeOf(value string) (int, int, error) {
+	bounds := strings.SplitN(value, "..", 2)
+	if len(bounds) != 2 {
+		return 0, 0, fmt.Errorf("expected a..b")
+	}
+	var (
+		first, last = 1, -1
+		err         error
+	)
+	if len(bounds[0]) != 0 {
+		if first, err = strconv.Atoi(bounds[0]); err != nil || first < 1 {
+			return 0, 0, fmt.Errorf("rows are counted from 1")
+		}
+	}
+	if len(bounds[1]) != 0 {
+		if last, err = strconv.Atoi(bounds[1]); err != nil || last < first {
+			return 0, 0, fmt.Errorf("the last row is before the first")
+		}
+		return first - 1, last - first + 1, nil
+	}
+	return first - 1, -1, nil
+}
+
+// terminal reports whether the standard output is a terminal rather than a file or pipe.
+func terminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// timing writes how long the command took to stderr when --timing is set.
+func timing(name string, arguments *arguments.Arguments) func() {
+	if _, ok := arguments.Flag("timing"); ok == false {
+		return nil
+	}
+	start := time.Now()
+	return func() {
+		fmt.Fprintln(os.Stderr, fmt.Sprintf(statusTiming, strings.ToLower(name), time.Since(start).Round(time.Millisecond)))
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/images"
+	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/stdin"
+)
+
+// imagesGC drops the artwork of emoji no longer in the dataset from the index and removes the blobs nothing references.
+func imagesGC(arguments *arguments.Arguments) {
+	lock()
+	index, err := images.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "images", err), err)
+	}
+	if emojipedia, err := emojipedia.Open(); err == nil {
+		index.Retain(emojipedia.Has)
+	}
+	if err := images.Write(index); err != nil {
+		fail(fmt.Sprintf(errorCannotWrite, "images", err), err)
+	}
+	removed, err := images.Prune(index)
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotWrite, "images", err), err)
+	}
+	for _, filename := range removed {
+		fmt.Println(filename)
+	}
+	fmt.Println(fmt.Sprintf(successPrune, len(removed)))
+}
+
+func imagesList(arguments *arguments.Arguments) {
+	index, err := images.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "images", err), err)
+	}
+	IDs := []string{}
+	for ID := range index.Emoji {
+		IDs = append(IDs, ID)
+	}
+	sort.Strings(IDs)
+	fmt.Fprintln(writer, "ID\t|Blob\t|Bytes\t|Address")
+	for _, ID := range IDs {
+		for address, blob := range index.Emoji[ID] {
+			size := "-"
+			if info, err := os.Stat(images.Path(blob)); err == nil {
+				size = fmt.Sprintf("%v", info.Size())
+			}
+			fmt.Fprintln(writer, fmt.Sprintf("%s\t|%s\t|%s\t|%.60s", ID, blob, size, address))
+		}
+	}
+	writer.Flush()
+}
+
+func imagesMain(arguments *arguments.Arguments) {
+	switch command(arguments, GC, LIST) {
+	case G, GC:
+		imagesGC(arguments.Next())
+	case L, LIST:
+		imagesList(arguments.Next())
+	default:
+		var (
+			g = stdin.Arg{
+				About:   "remove the stored artwork no emoji of the dataset references",
+				Short:   G,
+				Verbose: GC}
+			l = stdin.Arg{
+				About:   "show the stored artwork of each emoji and the address it was fetched from",
+				Short:   L,
+				Verbose: LIST}
+		)
+		fmt.Fprintln(writer, "usage: emojipedia [-im images] [<option>] [--flags]")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "artwork is stored once by the hash of its content, however many emoji or sources share it")
+		slice.New(g, l).Each(func(_ int, i interface{}) {
+			fmt.Fprintln(writer, i.(stdin.Arg))
+		})
+		fmt.Fprintln(writer)
+		writer.Flush()
+		unknown(arguments)
+	}
+}
@@ -0,0 +1,218 @@
+package images
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/store"
+)
+
+const (
+	blobs     string = "blobs"
+	indexfile string = "index.json"
+)
+
+// New instantiates a new empty Index pointer.
+func New() *Index {
+	return &Index{Emoji: map[string]map[string]string{}}
+}
+
+// Open attempts to open the Index of the artwork stored in the active profile. A profile without stored artwork
+// opens an empty Index.
+func Open() (*Index, error) {
+	filename := filepath.Join(directory.Images, indexfile)
+	content, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	index := New()
+	err = store.Decode(filename, content, index)
+	if err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// Fetch returns the path of the artwork of the emoji ID stored for the address, downloading it first when the
+// address has not been stored. Artwork given as a data URI is decoded rather than downloaded.
+// Artwork is stored once however many emoji or addresses share its content. Fetch reads and writes the Index without
+// locking it, so callers hold the dataset lock while the dataset is writable.
+func Fetch(ID string, address string) (string, error) {
+	index, err := Open()
+	if err != nil {
+		return "", err
+	}
+	if blob, ok := index.Blob(address); ok {
+		filename := Path(blob)
+		if _, err := os.Stat(filename); err == nil {
+			if index.Emoji[ID][address] != blob && store.Writable() == nil {
+				index.Add(ID, address, blob)
+				return filename, Write(index)
+			}
+			return filename, nil
+		}
+	}
+	if err := store.Writable(); err != nil {
+		return "", err
+	}
+	content, err := download(address)
+	if err != nil {
+		return "", err
+	}
+	blob := fmt.Sprintf("%x%s", sha256.Sum256(content), extension(address))
+	filename := Path(blob)
+	if _, err := os.Stat(filename); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(filename), os.ModePerm); err != nil {
+			return "", err
+		}
+		if err := ioutil.WriteFile(filename, content, os.ModePerm); err != nil {
+			return "", err
+		}
+	}
+	index.Add(ID, address, blob)
+	return filename, Write(index)
+}
+
+// Path returns the file a blob is stored in, in a folder named by the first two characters of its content hash.
+func Path(blob string) string {
+	return filepath.Join(directory.Images, blobs, blob[:2], blob)
+}
+
+// Prune removes every file of the images folder that is not a blob referenced by the Index, such as blobs
+// whose emoji were dropped from the Index, returning the paths it removed.
+func Prune(index *Index) ([]string, error) {
+	if err := store.Writable(); err != nil {
+		return nil, err
+	}
+	var (
+		referenced = map[string]bool{filepath.Join(directory.Images, indexfile): true}
+		removed    = []string{}
+	)
+	for _, addresses := range index.Emoji {
+		for _, blob := range addresses {
+			referenced[Path(blob)] = true
+		}
+	}
+	err := filepath.Walk(directory.Images, func(filename string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil || info.IsDir() || referenced[filename] {
+			return err
+		}
+		removed = append(removed, filename)
+		return os.Remove(filename)
+	})
+	return removed, err
+}
+
+// Write stores the Index in the images folder of the active profile.
+func Write(index *Index) error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(directory.Images, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	content, err := store.Marshal(index)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(directory.Images, indexfile), content, os.ModePerm)
+}
+
+// Index looks up the stored artwork of each emoji ID by the address it was fetched from. Artwork is stored as
+// blobs named by the SHA-256 hash of their content, so identical artwork is stored once.
+type Index struct {
+	Emoji map[string]map[string]string `json:"emoji"`
+}
+
+// Add method records the blob stored for the address of the emoji ID.
+func (pointer *Index) Add(ID string, address string, blob string) *Index {
+	if _, ok := pointer.Emoji[ID]; ok == false {
+		pointer.Emoji[ID] = map[string]string{}
+	}
+	pointer.Emoji[ID][address] = blob
+	return pointer
+}
+
+// Blob method returns the blob stored for the address by any emoji and a boolean indicating if one is stored.
+func (pointer *Index) Blob(address string) (string, bool) {
+	for _, addresses := range pointer.Emoji {
+		if blob, ok := addresses[address]; ok {
+			return blob, true
+		}
+	}
+	return "", false
+}
+
+// Retain method removes the emoji IDs the argument function does not keep, returning how many it removed.
+func (pointer *Index) Retain(keep func(ID string) bool) int {
+	n := 0
+	for ID := range pointer.Emoji {
+		if keep(ID) == false {
+			delete(pointer.Emoji, ID)
+			n++
+		}
+	}
+	return n
+}
+
+// decode returns the content of a base64 or percent-encoded data URI.
+func decode(address string) ([]byte, error) {
+	i := strings.Index(address, ",")
+	if i < 0 {
+		return nil, fmt.Errorf("images: malformed data URI")
+	}
+	header, data := address[:i], address[i+1:]
+	if strings.HasSuffix(header, ";base64") {
+		return base64.StdEncoding.DecodeString(data)
+	}
+	content, err := url.PathUnescape(data)
+	return []byte(content), err
+}
+
+// download returns the content of the artwork at the address, decoding data URIs.
+func download(address string) ([]byte, error) {
+	if strings.HasPrefix(address, "data:") {
+		return decode(address)
+	}
+	resp, err := client.Get(address)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("images: %s %s", address, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// extension returns the file extension of the artwork at the address, such as ".svg".
+func extension(address string) string {
+	if strings.HasPrefix(address, "data:image/svg") {
+		return ".svg"
+	}
+	if strings.HasPrefix(address, "data:") {
+		return ".png"
+	}
+	if u, err := url.Parse(address); err == nil {
+		if ext := path.Ext(u.Path); len(ext) != 0 {
+			return strings.ToLower(ext)
+		}
+	}
+	return ".png"
+}
@@ -0,0 +1,160 @@
+package index
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/store"
+)
+
+var (
+	// changed is 1 when the stored emoji or keywords changed since the Index was last written by this process.
+	// It is read and written atomically as packages store emoji from several goroutines.
+	changed int32
+)
+
+// Changed returns whether the stored emoji or keywords changed since the Index was last written by this process.
+func Changed() bool {
+	return atomic.LoadInt32(&changed) == 1
+}
+
+// New instantiates a new empty Index pointer.
+func New() *Index {
+	return &Index{Codes: map[string]string{}, Keywords: map[string][]string{}, Names: map[string]int64{}}
+}
+
+// Open attempts to open the Index of the active profile, reading its tables but none of its records.
+// A profile whose Index was not built, or was removed when the dataset changed, returns an os.IsNotExist error.
+func Open() (*Index, error) {
+	file, err := os.Open(directory.Index)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var size uint64
+	if err = binary.Read(file, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	index := New()
+	if err = gob.NewDecoder(io.LimitReader(file, int64(size))).Decode(index); err != nil {
+		return nil, err
+	}
+	index.start = int64(8 + size)
+	return index, nil
+}
+
+// Remove deletes the Index stored in the active profile and records that it has Changed. Packages call it whenever
+// they change the stored emoji or keywords, so that lookups read the dataset itself until the Index is written again.
+func Remove() error {
+	if Changed() {
+		return nil
+	}
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	if atomic.CompareAndSwapInt32(&changed, 0, 1) == false {
+		return nil
+	}
+	err := os.Remove(directory.Index)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Write stores the Index in the active profile: the length of its tables, its gob encoded tables and its records.
+// The Index is written to a temporary file of its own beside the profile first and moved into place, so lookups
+// never read half an Index and concurrent writes never share a file.
+func Write(index *Index) error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(filepath.Dir(directory.Index), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	tables := bytes.Buffer{}
+	if err = gob.NewEncoder(&tables).Encode(index); err != nil {
+		return err
+	}
+	content := bytes.Buffer{}
+	binary.Write(&content, binary.BigEndian, uint64(tables.Len()))
+	content.Write(tables.Bytes())
+	content.Write(index.records.Bytes())
+	if err = store.WriteFile(directory.Index, content.Bytes()); err != nil {
+		return err
+	}
+	atomic.StoreInt32(&changed, 0)
+	return nil
+}
+
+// Index is a compact file built with the dataset so that lookups by emoji name, codepoint sequence or keyword
+// read only what they need. Names holds the offset of the stored content of each emoji, Codes the name of the emoji
+// of each codepoint sequence (its ID) and Keywords the names of the emoji of each keyword.
+type Index struct {
+	Codes    map[string]string
+	Keywords map[string][]string
+	Names    map[string]int64
+	records  bytes.Buffer
+	start    int64
+}
+
+// Add method appends the stored content of an emoji to the records of the Index under its name and ID.
+func (pointer *Index) Add(name, ID string, content []byte) *Index {
+	pointer.Names[name] = int64(pointer.records.Len())
+	pointer.Codes[ID] = name
+	binary.Write(&pointer.records, binary.BigEndian, uint32(len(content)))
+	pointer.records.Write(content)
+	return pointer
+}
+
+// Keyword method indexes the names of the emoji under the keyword, in name order.
+func (pointer *Index) Keyword(key string, names ...string) *Index {
+	pointer.Keywords[key] = append(pointer.Keywords[key], names...)
+	sort.Strings(pointer.Keywords[key])
+	return pointer
+}
+
+// Name method returns the name of the emoji of the codepoint sequence and a boolean indicating if it was found.
+func (pointer *Index) Name(ID string) (string, bool) {
+	name, ok := pointer.Codes[ID]
+	return name, ok
+}
+
+// Named method returns the names of the emoji of the keyword and a boolean indicating if it was found.
+func (pointer *Index) Named(key string) ([]string, bool) {
+	names, ok := pointer.Keywords[key]
+	return names, ok
+}
+
+// Record method reads the stored content of the emoji of the name or ID and returns a boolean indicating if it was found.
+func (pointer *Index) Record(key string) ([]byte, bool) {
+	if name, ok := pointer.Codes[key]; ok {
+		key = name
+	}
+	offset, ok := pointer.Names[key]
+	if ok == false {
+		return nil, false
+	}
+	file, err := os.Open(directory.Index)
+	if err != nil {
+		return nil, false
+	}
+	defer file.Close()
+	header := make([]byte, 4)
+	if _, err = file.ReadAt(header, pointer.start+offset); err != nil {
+		return nil, false
+	}
+	content := make([]byte, binary.BigEndian.Uint32(header))
+	if _, err = file.ReadAt(content, pointer.start+offset+4); err != nil {
+		return nil, false
+	}
+	return content, true
+}
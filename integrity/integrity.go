@@ -0,0 +1,99 @@
+package integrity
+
+import (
+	"fmt"
+
+	"github.com/gellel/emojipedia/categories"
+	"github.com/gellel/emojipedia/category"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/subcategories"
+	"github.com/gellel/emojipedia/subcategory"
+)
+
+const (
+	missing string = "%s \"%s\" references missing %s \"%s\""
+)
+
+// Check verifies that every name referenced between the built categories, subcategories and emoji resolves.
+// References into a package that has not been built are not checked.
+// Returns a slice.Slice of human readable problems, empty when the dataset is consistent.
+func Check() *slice.Slice {
+	problems := slice.New()
+	for _, problem := range Problems() {
+		problems.Append(problem.String())
+	}
+	return problems.Sort()
+}
+
+// Problems returns every reference between the built categories, subcategories and emoji that does not resolve.
+// References into a package that has not been built are not checked.
+func Problems() []*Problem {
+	var (
+		problems         = []*Problem{}
+		c, categoriesErr = categories.Open()
+		e, emojiErr      = emojipedia.Open()
+		s, subErr        = subcategories.Open()
+		add              = func(kind, name, missingKind, missingName string) {
+			problems = append(problems, &Problem{Kind: kind, Missing: missingName, MissingKind: missingKind, Name: name})
+		}
+	)
+	if categoriesErr == nil {
+		c.Each(func(x *category.Category) {
+			if subErr == nil {
+				x.Subcategories.Each(func(_ int, i interface{}) {
+					if s.Has(i.(string)) == false {
+						add("category", x.Name, "subcategory", i.(string))
+					}
+				})
+			}
+			if emojiErr == nil {
+				x.Emoji.Each(func(_ int, i interface{}) {
+					if e.Has(i.(string)) == false {
+						add("category", x.Name, "emoji", i.(string))
+					}
+				})
+			}
+		})
+	}
+	if subErr == nil {
+		s.Each(func(x *subcategory.Subcategory) {
+			if categoriesErr == nil && c.Has(x.Category) == false {
+				add("subcategory", x.Name, "category", x.Category)
+			}
+			if emojiErr == nil {
+				x.Emoji.Each(func(_ int, i interface{}) {
+					if e.Has(i.(string)) == false {
+						add("subcategory", x.Name, "emoji", i.(string))
+					}
+				})
+			}
+		})
+	}
+	if emojiErr == nil {
+		e.Each(func(_ string, x *emoji.Emoji) {
+			if categoriesErr == nil && c.Has(x.Category) == false {
+				add("emoji", x.Name, "category", x.Category)
+			}
+			// Emoji built from github/gemoji alone are not grouped into subcategories.
+			if subErr == nil && len(x.Subcategory) != 0 && s.Has(x.Subcategory) == false {
+				add("emoji", x.Name, "subcategory", x.Subcategory)
+			}
+		})
+	}
+	return problems
+}
+
+// Problem is a name referenced by a category, subcategory or emoji that does not resolve.
+type Problem struct {
+	Kind        string
+	Missing     string
+	MissingKind string
+	Name        string
+}
+
+// String method describes the Problem.
+func (pointer *Problem) String() string {
+	return fmt.Sprintf(missing, pointer.Kind, pointer.Name, pointer.MissingKind, pointer.Missing)
+}
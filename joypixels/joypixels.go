@@ -0,0 +1,74 @@
+package joypixels
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/lexicon"
+	"github.com/gellel/emojipedia/source"
+	"github.com/gellel/emojipedia/text"
+)
+
+const (
+	// Name is the key JoyPixels metadata is stored under in emoji.Emoji.Sources.
+	Name = "joypixels"
+	// URL is the metadata published with the JoyPixels emoji-toolkit.
+	URL = "https://raw.githubusercontent.com/joypixels/emoji-toolkit/master/emoji.json"
+	// Art is the address of the 64px PNG artwork of a JoyPixels code point sequence.
+	Art = "https://cdn.jsdelivr.net/joypixels/assets/8.0/png/unicode/64/%s.png"
+)
+
+// Open attempts to read the JoyPixels metadata from a local file or, if the argument is a URL, over HTTP.
+func Open(path string) (*lexicon.Lexicon, error) {
+	reader, err := client.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return Parse(reader)
+}
+
+// Parse reads emoji-toolkit formatted metadata into a lexicon.Lexicon of source.Source pointers keyed by emoji ID.
+// The shortname and keywords of each emoji are kept as its tags and its skin tone variants are listed by ID.
+func Parse(reader io.Reader) (*lexicon.Lexicon, error) {
+	entries := map[string]*entry{}
+	err := json.NewDecoder(reader).Decode(&entries)
+	if err != nil {
+		return nil, err
+	}
+	lexicon := &lexicon.Lexicon{}
+	for code, e := range entries {
+		if len(e.CodePoints.Base) == 0 {
+			e.CodePoints.Base = code
+		}
+		s := source.New()
+		s.Annotation = e.Name
+		s.Art = fmt.Sprintf(Art, e.CodePoints.Base)
+		s.Group = text.Normalize(e.Category)
+		for _, tag := range append([]string{strings.Trim(e.Shortname, ":")}, e.Keywords...) {
+			if tag = text.Normalize(tag); len(tag) != 0 {
+				s.Tags.Append(tag)
+			}
+		}
+		for _, diversity := range e.Diversities {
+			s.Skintones.Append(source.ID(diversity))
+		}
+		lexicon.Add(source.ID(e.CodePoints.Base), s)
+	}
+	return lexicon, nil
+}
+
+// entry is a record of the emoji-toolkit metadata.
+type entry struct {
+	Category   string `json:"category"`
+	CodePoints struct {
+		Base string `json:"base"`
+	} `json:"code_points"`
+	Diversities []string `json:"diversities"`
+	Keywords    []string `json:"keywords"`
+	Name        string   `json:"name"`
+	Shortname   string   `json:"shortname"`
+}
@@ -0,0 +1,326 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Add is the operation adding a value to an object or inserting it into an array.
+	Add string = "add"
+	// Copy is the operation copying the value at From to Path.
+	Copy string = "copy"
+	// Move is the operation removing the value at From and adding it at Path.
+	Move string = "move"
+	// Remove is the operation removing the value at Path.
+	Remove string = "remove"
+	// Replace is the operation replacing the value at Path.
+	Replace string = "replace"
+	// Test is the operation checking that the value at Path equals the Value, failing the Patch otherwise.
+	Test string = "test"
+)
+
+var (
+	escaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	unescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// Diff returns the Patch turning the JSON document from into the JSON document to. Objects are compared key by key
+// and any other value that differs is replaced whole, after a Test of the value it replaces, so the Patch fails
+// to apply to a document that has since changed. A from document of nil creates the to document.
+func Diff(from, to []byte) (Patch, error) {
+	var a, b interface{}
+	if len(from) != 0 {
+		if err := json.Unmarshal(from, &a); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(to, &b); err != nil {
+		return nil, err
+	}
+	if len(from) == 0 {
+		return Patch{{Op: Add, Path: "", Value: b}}, nil
+	}
+	return diff(Patch{}, "", a, b), nil
+}
+
+// diff appends the operations turning the value a at the path into the value b.
+func diff(patch Patch, path string, a, b interface{}) Patch {
+	if reflect.DeepEqual(a, b) {
+		return patch
+	}
+	x, ok := a.(map[string]interface{})
+	y, same := b.(map[string]interface{})
+	if ok == false || same == false {
+		return append(patch, Operation{Op: Test, Path: path, Value: a}, Operation{Op: Replace, Path: path, Value: b})
+	}
+	keys := []string{}
+	for key := range x {
+		keys = append(keys, key)
+	}
+	for key := range y {
+		if _, ok := x[key]; ok == false {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var (
+			from, inFrom = x[key]
+			to, inTo     = y[key]
+			pointer      = path + "/" + escaper.Replace(key)
+		)
+		switch {
+		case inFrom == false:
+			patch = append(patch, Operation{Op: Add, Path: pointer, Value: to})
+		case inTo == false:
+			patch = append(patch, Operation{Op: Test, Path: pointer, Value: from}, Operation{Op: Remove, Path: pointer})
+		default:
+			patch = diff(patch, pointer, from, to)
+		}
+	}
+	return patch
+}
+
+// get returns the value the pointer refers to within the document.
+func get(document interface{}, path string) (interface{}, error) {
+	parts, err := tokens(path)
+	if err != nil {
+		return nil, err
+	}
+	value := document
+	for _, part := range parts {
+		switch container := value.(type) {
+		case map[string]interface{}:
+			v, ok := container[part]
+			if ok == false {
+				return nil, fmt.Errorf("\"%s\" does not exist", path)
+			}
+			value = v
+		case []interface{}:
+			i, err := index(part, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			value = container[i]
+		default:
+			return nil, fmt.Errorf("\"%s\" does not exist", path)
+		}
+	}
+	return value, nil
+}
+
+// index parses an array index token. The "-" token, past the last element, is only accepted when appending.
+func index(token string, length int, appending bool) (int, error) {
+	if token == "-" && appending {
+		return length, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("\"%s\" is not an array index", token)
+	}
+	limit := length - 1
+	if appending {
+		limit = length
+	}
+	if i > limit {
+		return 0, fmt.Errorf("index %v is out of range", i)
+	}
+	return i, nil
+}
+
+// normalized returns the value as it decodes from JSON, so values built in Go compare equal to decoded ones.
+func normalized(value interface{}) interface{} {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var decoded interface{}
+	json.Unmarshal(content, &decoded)
+	return decoded
+}
+
+// remove returns the document without the value the pointer refers to.
+func remove(document interface{}, path string) (interface{}, error) {
+	parts, err := tokens(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("cannot remove the whole document")
+	}
+	parent, err := get(document, path[:strings.LastIndex(path, "/")])
+	if err != nil {
+		return nil, err
+	}
+	last := parts[len(parts)-1]
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		if _, ok := container[last]; ok == false {
+			return nil, fmt.Errorf("\"%s\" does not exist", path)
+		}
+		delete(container, last)
+		return document, nil
+	case []interface{}:
+		i, err := index(last, len(container), false)
+		if err != nil {
+			return nil, err
+		}
+		return set(document, path[:strings.LastIndex(path, "/")], append(container[:i:i], container[i+1:]...), false)
+	}
+	return nil, fmt.Errorf("\"%s\" does not exist", path)
+}
+
+// set returns the document with the value at the pointer. Adding inserts into arrays, and the parent of the
+// pointer must exist. The empty pointer replaces the whole document.
+func set(document interface{}, path string, value interface{}, adding bool) (interface{}, error) {
+	parts, err := tokens(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return value, nil
+	}
+	parentPath := path[:strings.LastIndex(path, "/")]
+	parent, err := get(document, parentPath)
+	if err != nil {
+		return nil, err
+	}
+	last := parts[len(parts)-1]
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		container[last] = value
+		return document, nil
+	case []interface{}:
+		i, err := index(last, len(container), adding)
+		if err != nil {
+			return nil, err
+		}
+		if adding == false {
+			container[i] = value
+			return document, nil
+		}
+		inserted := append(append(append([]interface{}{}, container[:i]...), value), container[i:]...)
+		return set(document, parentPath, inserted, false)
+	}
+	return nil, fmt.Errorf("\"%s\" has no parent to add to", path)
+}
+
+// tokens splits a JSON Pointer into its unescaped reference tokens. The empty pointer refers to the whole document.
+func tokens(path string) ([]string, error) {
+	if len(path) == 0 {
+		return []string{}, nil
+	}
+	if strings.HasPrefix(path, "/") == false {
+		return nil, fmt.Errorf("pointer \"%s\" does not start with \"/\"", path)
+	}
+	parts := strings.Split(path[1:], "/")
+	for i, part := range parts {
+		parts[i] = unescaper.Replace(part)
+	}
+	return parts, nil
+}
+
+// File is the Patch of one stored file of a dataset, along with the key the file is stored by and its path
+// within the dataset folder. Removed files have no Patch.
+type File struct {
+	Key     string `json:"key"`
+	Patch   Patch  `json:"patch,omitempty"`
+	Path    string `json:"path"`
+	Removed bool   `json:"removed,omitempty"`
+}
+
+// Operation is a single RFC 6902 JSON Patch operation. Path and From are RFC 6901 JSON Pointers.
+type Operation struct {
+	From  string      `json:"from,omitempty"`
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// MarshalJSON method writes the Operation, keeping a null Value of the operations that take one.
+func (pointer Operation) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{"op": pointer.Op, "path": pointer.Path}
+	switch pointer.Op {
+	case Add, Replace, Test:
+		fields["value"] = pointer.Value
+	case Copy, Move:
+		fields["from"] = pointer.From
+	}
+	return json.Marshal(fields)
+}
+
+// apply applies the Operation to the document and returns the patched document.
+func (pointer Operation) apply(document interface{}) (interface{}, error) {
+	switch pointer.Op {
+	case Add:
+		return set(document, pointer.Path, normalized(pointer.Value), true)
+	case Copy:
+		value, err := get(document, pointer.From)
+		if err != nil {
+			return nil, err
+		}
+		return set(document, pointer.Path, normalized(value), true)
+	case Move:
+		if strings.HasPrefix(pointer.Path, pointer.From+"/") {
+			return nil, fmt.Errorf("cannot move \"%s\" into itself", pointer.From)
+		}
+		value, err := get(document, pointer.From)
+		if err != nil {
+			return nil, err
+		}
+		if document, err = remove(document, pointer.From); err != nil {
+			return nil, err
+		}
+		return set(document, pointer.Path, value, true)
+	case Remove:
+		return remove(document, pointer.Path)
+	case Replace:
+		if _, err := get(document, pointer.Path); err != nil {
+			return nil, err
+		}
+		return set(document, pointer.Path, normalized(pointer.Value), false)
+	case Test:
+		value, err := get(document, pointer.Path)
+		if err != nil {
+			return nil, err
+		}
+		if reflect.DeepEqual(value, normalized(pointer.Value)) == false {
+			return nil, fmt.Errorf("value differs")
+		}
+		return document, nil
+	}
+	return nil, fmt.Errorf("unknown operation")
+}
+
+// Patch is an RFC 6902 JSON Patch document: the operations applied in order to a JSON document.
+type Patch []Operation
+
+// Apply method applies the Patch to the JSON document and returns the patched document. A document of nil
+// is a document that does not exist yet. The document is left untouched when an operation fails.
+func (pointer Patch) Apply(document []byte) ([]byte, error) {
+	var value interface{}
+	if len(document) != 0 {
+		if err := json.Unmarshal(document, &value); err != nil {
+			return nil, err
+		}
+	}
+	for i, operation := range pointer {
+		var err error
+		if value, err = operation.apply(value); err != nil {
+			return nil, fmt.Errorf("jsonpatch: operation %v (%s \"%s\") failed; %s", i, operation.Op, operation.Path, err)
+		}
+	}
+	return json.Marshal(value)
+}
+
+// Update is the set of file Patches turning one dataset into another.
+type Update struct {
+	Files []*File `json:"files"`
+	From  string  `json:"from"`
+	To    string  `json:"to"`
+}
@@ -0,0 +1,43 @@
+package keycap
+
+import (
+	"strings"
+
+	"github.com/gellel/emojipedia/emojidata"
+)
+
+const (
+	// Bases lists every character that can be enclosed in a keycap.
+	Bases string = "#*0123456789"
+)
+
+// KeycapFor builds the fully-qualified keycap sequence (base, VS16, COMBINING ENCLOSING KEYCAP) for the argument base.
+// Returns false if the base cannot be enclosed in a keycap.
+func KeycapFor(base rune) (string, bool) {
+	if strings.ContainsRune(Bases, base) == false {
+		return "", false
+	}
+	return string([]rune{base, emojidata.EmojiSelector, emojidata.Keycap}), true
+}
+
+// BaseOf returns the character enclosed by a keycap sequence.
+// Both fully-qualified (#️⃣) and unqualified (#⃣) sequences are recognised.
+func BaseOf(keycap string) (rune, bool) {
+	runes := []rune(keycap)
+	switch {
+	case len(runes) == 3 && runes[1] == emojidata.EmojiSelector && runes[2] == emojidata.Keycap:
+	case len(runes) == 2 && runes[1] == emojidata.Keycap:
+	default:
+		return 0, false
+	}
+	if strings.ContainsRune(Bases, runes[0]) == false {
+		return 0, false
+	}
+	return runes[0], true
+}
+
+// IsKeycap checks whether the string is a single keycap sequence.
+func IsKeycap(s string) bool {
+	_, ok := BaseOf(s)
+	return ok
+}
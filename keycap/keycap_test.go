@@ -0,0 +1,98 @@
+package keycap
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// keycaps are the keycap lines of emoji-test.txt (Emoji 15.1), subgroup "keycap".
+const keycaps = `0023 FE0F 20E3                                         ; fully-qualified     # #️⃣ E0.6 keycap: #
+0023 20E3                                              ; unqualified         # #⃣ E0.6 keycap: #
+002A FE0F 20E3                                         ; fully-qualified     # *️⃣ E2.0 keycap: *
+002A 20E3                                              ; unqualified         # *⃣ E2.0 keycap: *
+0030 FE0F 20E3                                         ; fully-qualified     # 0️⃣ E0.6 keycap: 0
+0030 20E3                                              ; unqualified         # 0⃣ E0.6 keycap: 0
+0031 FE0F 20E3                                         ; fully-qualified     # 1️⃣ E0.6 keycap: 1
+0031 20E3                                              ; unqualified         # 1⃣ E0.6 keycap: 1
+0032 FE0F 20E3                                         ; fully-qualified     # 2️⃣ E0.6 keycap: 2
+0032 20E3                                              ; unqualified         # 2⃣ E0.6 keycap: 2
+0033 FE0F 20E3                                         ; fully-qualified     # 3️⃣ E0.6 keycap: 3
+0033 20E3                                              ; unqualified         # 3⃣ E0.6 keycap: 3
+0034 FE0F 20E3                                         ; fully-qualified     # 4️⃣ E0.6 keycap: 4
+0034 20E3                                              ; unqualified         # 4⃣ E0.6 keycap: 4
+0035 FE0F 20E3                                         ; fully-qualified     # 5️⃣ E0.6 keycap: 5
+0035 20E3                                              ; unqualified         # 5⃣ E0.6 keycap: 5
+0036 FE0F 20E3                                         ; fully-qualified     # 6️⃣ E0.6 keycap: 6
+0036 20E3                                              ; unqualified         # 6⃣ E0.6 keycap: 6
+0037 FE0F 20E3                                         ; fully-qualified     # 7️⃣ E0.6 keycap: 7
+0037 20E3                                              ; unqualified         # 7⃣ E0.6 keycap: 7
+0038 FE0F 20E3                                         ; fully-qualified     # 8️⃣ E0.6 keycap: 8
+0038 20E3                                              ; unqualified         # 8⃣ E0.6 keycap: 8
+0039 FE0F 20E3                                         ; fully-qualified     # 9️⃣ E0.6 keycap: 9
+0039 20E3                                              ; unqualified         # 9⃣ E0.6 keycap: 9
+1F51F                                                  ; fully-qualified     # 🔟 E0.6 keycap: 10`
+
+// keycapLine is a line of emoji-test.txt: its sequence, qualification status and the text after "keycap: ".
+type keycapLine struct {
+	base     string
+	sequence string
+	status   string
+}
+
+func keycapLines(t *testing.T) []keycapLine {
+	lines := []keycapLine{}
+	for _, line := range strings.Split(keycaps, "\n") {
+		var (
+			comment = line[strings.Index(line, "#")+1:]
+			fields  = strings.Split(line[:strings.Index(line, "#")], ";")
+			runes   = []rune{}
+		)
+		for _, code := range strings.Fields(fields[0]) {
+			r, err := strconv.ParseUint(code, 16, 32)
+			if err != nil {
+				t.Fatalf("cannot parse %q: %v", line, err)
+			}
+			runes = append(runes, rune(r))
+		}
+		lines = append(lines, keycapLine{
+			base:     strings.TrimSpace(comment[strings.Index(comment, "keycap:")+len("keycap:"):]),
+			sequence: string(runes),
+			status:   strings.TrimSpace(fields[1])})
+	}
+	return lines
+}
+
+func TestBaseOf(t *testing.T) {
+	for _, line := range keycapLines(t) {
+		base, ok := BaseOf(line.sequence)
+		if len([]rune(line.base)) != 1 {
+			// "keycap: 10" is the single character 🔟 rather than an enclosed base.
+			if ok {
+				t.Errorf("BaseOf(%q) = %q, want no keycap", line.sequence, base)
+			}
+			continue
+		}
+		if ok == false || string(base) != line.base {
+			t.Errorf("BaseOf(%q) = %q, %v, want %q", line.sequence, base, ok, line.base)
+		}
+		if IsKeycap(line.sequence) == false {
+			t.Errorf("IsKeycap(%q) = false", line.sequence)
+		}
+	}
+}
+
+func TestKeycapFor(t *testing.T) {
+	for _, line := range keycapLines(t) {
+		if line.status != "fully-qualified" || len([]rune(line.base)) != 1 {
+			continue
+		}
+		sequence, ok := KeycapFor([]rune(line.base)[0])
+		if ok == false || sequence != line.sequence {
+			t.Errorf("KeycapFor(%q) = %q, %v, want %q", line.base, sequence, ok, line.sequence)
+		}
+	}
+	if sequence, ok := KeycapFor('a'); ok {
+		t.Errorf("KeycapFor('a') = %q, want no keycap", sequence)
+	}
+}
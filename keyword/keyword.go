@@ -1,19 +1,18 @@
 package keyword
 
 import (
-	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"os"
-	"path/filepath"
 
 	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/index"
 	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/store"
 )
 
 // Open attempts to open a Keyword slice from the emojipedia/keywords folder.
 func Open(name string) (*slice.Slice, error) {
-	filepath := filepath.Join(directory.Keywords, fmt.Sprintf("%s.json", name))
+	filepath := store.Path(directory.Keywords, name)
 	reader, err := os.Open(filepath)
 	if err != nil {
 		return nil, err
@@ -24,7 +23,7 @@ func Open(name string) (*slice.Slice, error) {
 		return nil, err
 	}
 	slice := &slice.Slice{}
-	err = json.Unmarshal(content, slice)
+	err = store.Decode(filepath, content, slice)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +32,7 @@ func Open(name string) (*slice.Slice, error) {
 
 func Parse(content *[]byte) (*slice.Slice, error) {
 	keywords := &slice.Slice{}
-	err := json.Unmarshal(*content, keywords)
+	err := store.Unmarshal(*content, keywords)
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +40,7 @@ func Parse(content *[]byte) (*slice.Slice, error) {
 }
 
 func Read(name string) (*[]byte, error) {
-	filepath := filepath.Join(directory.Keywords, fmt.Sprintf("%s.json", name))
+	filepath := store.Path(directory.Keywords, name)
 	reader, err := os.Open(filepath)
 	if err != nil {
 		return nil, err
@@ -56,19 +55,38 @@ func Read(name string) (*[]byte, error) {
 
 // Remove deletes the Keyword data stored in the dependencies folder.
 func Remove(name string) error {
-	return os.Remove(filepath.Join(directory.Keywords, fmt.Sprintf("%s.json", name)))
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	if err := index.Remove(); err != nil {
+		return err
+	}
+	err := store.Trash(store.Path(directory.Keywords, name))
+	if err != nil {
+		return err
+	}
+	return store.Forget(directory.Keywords, name)
 }
 
 // Write stores and Keyword entry to the dependencies folder.
 func Write(key string, keywords *slice.Slice) error {
-	err := os.MkdirAll(directory.Keywords,  os.ModePerm)
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	if err := index.Remove(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(directory.Keywords, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	content, err := store.Marshal(keywords)
 	if err != nil {
 		return err
 	}
-	content, err := json.Marshal(keywords)
+	filepath, err := store.Assign(directory.Keywords, key)
 	if err != nil {
 		return err
 	}
-	filepath := filepath.Join(directory.Keywords, fmt.Sprintf("%s.json", key))
-	return ioutil.WriteFile(filepath, content,  os.ModePerm)
+	return store.WriteFile(filepath, content)
 }
@@ -0,0 +1,23 @@
+package keyword
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gellel/emojipedia/directory"
+)
+
+// BenchmarkOpen looks up the emoji of the first stored keyword. Skipped until the keywords package is built.
+func BenchmarkOpen(b *testing.B) {
+	files, err := ioutil.ReadDir(directory.Keywords)
+	if err != nil || len(files) == 0 {
+		b.Skip("the keywords package has not been built")
+	}
+	key := strings.TrimSuffix(filepath.Base(files[0].Name()), ".json")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Open(key)
+	}
+}
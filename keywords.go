@@ -4,32 +4,61 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/gellel/emojipedia/analyzer"
 	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/index"
 	"github.com/gellel/emojipedia/keywords"
+	"github.com/gellel/emojipedia/pattern"
 	"github.com/gellel/emojipedia/slice"
 	"github.com/gellel/emojipedia/stdin"
 )
 
+// keywordsOf returns the Keywords of the arguments read from the index of the dataset when it has been built,
+// or every Keywords of the locale.
+func keywordsOf(arguments *arguments.Arguments) *keywords.Keywords {
+	i, err := index.Open()
+	if err != nil || len(i.Keywords) == 0 || len(language) != 0 {
+		return keywords.GetLocale(language)
+	}
+	k := keywords.New()
+	arguments.Each(func(_ int, argument string) {
+		key := analyzer.Default.Key(argument)
+		if names, ok := i.Named(key); ok {
+			k.Add(key, names...)
+		}
+	})
+	return k
+}
+
 func keywordsGet(arguments *arguments.Arguments) {
 	var (
-		keywords = keywords.Get()
+		keywords = keywordsOf(arguments)
+		unknown  = []string{}
 	)
 	fmt.Fprintln(writer, "N\t|Name\t|Emoji")
 	arguments.Each(func(i int, argument string) {
-		if slice, ok := keywords.Get(argument); ok {
+		if slice, ok := keywords.GetOK(analyzer.Default.Key(argument)); ok {
 			fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v\t|%v", i, argument, slice.Join(" ")))
+		} else if strings.HasPrefix(argument, "--") == false {
+			unknown = append(unknown, argument)
 		}
 	})
 	writer.Flush()
+	notFound(unknown, K, KEYWORDS)
 }
 
 func keywordsKeys(arguments *arguments.Arguments) {
 	var (
 		keywords = keywords.Get()
+		n        = 0
+		patterns = patternsOf(arguments)
 	)
 	fmt.Fprintln(writer, "N\t|Name")
-	keywords.Keys().Sort().Each(func(i int, x interface{}) {
-		fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v", i, x.(string)))
+	keywords.Keys().Sort().Each(func(_ int, x interface{}) {
+		if pattern.Any(patterns, x.(string)) {
+			fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v", n, x.(string)))
+			n++
+		}
 	})
 	writer.Flush()
 }
@@ -41,7 +70,11 @@ func keywordsList(arguments *arguments.Arguments) {
 	fmt.Fprintln(writer, "N\t|Name\t|Emoji")
 	keywords.Keys().Sort().Each(func(i int, x interface{}) {
 		key := x.(string)
-		slice := keywords.Fetch(key)
+		slice, err := keywords.TryFetch(key)
+		if err != nil {
+			fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v\t|%v", i, key, err))
+			return
+		}
 		fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v\t|%v", i, key, slice.Len()))
 	})
 	writer.Flush()
@@ -57,7 +90,7 @@ func keywordsNumber(arguments *arguments.Arguments) {
 }
 
 func keywordsMain(arguments *arguments.Arguments) {
-	switch strings.ToUpper(arguments.Get(0)) {
+	switch command(arguments, BUILD, GET, KEYS, LIST, NUMBER, REMOVE) {
 	case B, BUILD:
 		build(KEYWORDS, keywords.Make)
 	case G, GET:
@@ -68,6 +101,8 @@ func keywordsMain(arguments *arguments.Arguments) {
 		keywordsList(arguments.Next())
 	case N, NUMBER:
 		keywordsNumber(arguments.Next())
+	case R, REMOVE:
+		remove(KEYWORDS, keywords.Remove)
 	default:
 		var (
 			b = stdin.Arg{
@@ -76,6 +111,8 @@ func keywordsMain(arguments *arguments.Arguments) {
 				Verbose: BUILD}
 			g = stdin.Arg{
 				About:   "get one or more keywords",
+				Args:    "<keyword> [...<keyword>]",
+				Example: "emojipedia keywords get smile",
 				Short:   G,
 				Verbose: GET}
 			k = stdin.Arg{
@@ -109,5 +146,6 @@ func keywordsMain(arguments *arguments.Arguments) {
 		})
 		fmt.Fprintln(writer)
 		writer.Flush()
+		unknown(arguments)
 	}
 }
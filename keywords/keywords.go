@@ -1,20 +1,30 @@
 package keywords
 
 import (
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"os"
+	"sort"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/gellel/emojipedia/analyzer"
+	"github.com/gellel/emojipedia/conflict"
 	"github.com/gellel/emojipedia/directory"
 	"github.com/gellel/emojipedia/keyword"
 	"github.com/gellel/emojipedia/lexicon"
+	"github.com/gellel/emojipedia/locale"
+	"github.com/gellel/emojipedia/pkg"
 	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/store"
 	"github.com/gellel/emojipedia/text"
+	"github.com/gellel/emojipedia/weights"
 )
 
 // New instantiates a new empty Keywords pointer.
 func New() *Keywords {
-	return &Keywords{&lexicon.Lexicon{}}
+	return &Keywords{lexicon.NewOrdered()}
 }
 
 // Get attempts to open all Keywords data from the emojipedia/keywords folder, but panics if an error occurs.
@@ -26,32 +36,98 @@ func Get() *Keywords {
 	return keywords
 }
 
+// GetLocale attempts to open the Keywords of a locale, but panics if an error occurs.
+func GetLocale(code string) *Keywords {
+	keywords, err := OpenLocale(code)
+	if err != nil {
+		panic(err)
+	}
+	return keywords
+}
+
 // Make builds Keywords dependencies from HTML scraped from unicode.org.
+// Keywords are indexed by their analyzer.Default key, so "smiling" and "smiles" share the "smile" keyword.
+// The weights.Weights of every keyword term are stored alongside for ranking searches.
+// When the build is restricted to some pkg.Categories, only the emoji of those categories are replaced in the
+// stored keywords and the stored weights.Weights are kept.
 func Make(document *goquery.Document) {
-	keywords := New()
+	var (
+		category string
+		keywords = New()
+		names    = []string{}
+		terms    = map[string][]string{}
+	)
 	document.Find("tr").Each(func(i int, selection *goquery.Selection) {
+		selection.Find("th.bighead a").Each(func(j int, s *goquery.Selection) {
+			category = text.Normalize(s.Text())
+		})
 		s := selection.Find("td.name")
 		name := strings.TrimSpace(s.First().Text())
 		keys := strings.TrimSpace(s.Last().Text())
-		if len(name) == 0 {
+		if len(name) == 0 || pkg.Selected(category) == false {
 			return
 		}
 		name = text.Normalize(name)
+		if _, ok := terms[name]; ok == false {
+			names, terms[name] = append(names, name), []string{}
+		}
 		for _, key := range strings.Split(keys, "|") {
-			key = text.Normalize(strings.TrimSpace(key))
-			keywords.Add(key, name)
+			if key = analyzer.Default.Key(key); len(key) != 0 {
+				keywords.Add(key, name)
+				terms[name] = append(terms[name], strings.Split(key, "-")...)
+			}
 		}
 	})
+	if pkg.Partial() {
+		keywords = partial(keywords, terms)
+	}
 	keywords.Each(func(key string, keywords *slice.Slice) {
 		keyword.Write(key, keywords)
 	})
+	if pkg.Partial() {
+		return
+	}
+	w := weights.New()
+	for _, name := range names {
+		w.Add(terms[name]...)
+	}
+	weights.Write(w)
+}
+
+// partial merges Keywords built for some categories into the stored Keywords: the emoji built are removed from
+// every stored keyword and added back to the keywords they were built with. Stored keywords left without emoji are removed.
+func partial(built *Keywords, names map[string][]string) *Keywords {
+	stored, err := Open()
+	if err != nil {
+		return built
+	}
+	merged := New()
+	stored.Each(func(key string, s *slice.Slice) {
+		kept := &slice.Slice{}
+		s.Each(func(_ int, i interface{}) {
+			if _, ok := names[i.(string)]; ok == false {
+				kept.Append(i)
+			}
+		})
+		if kept.Len() != 0 {
+			merged.Assign(key, kept)
+		} else if built.Has(key) == false {
+			keyword.Remove(key)
+		}
+	})
+	built.Each(func(key string, s *slice.Slice) {
+		s.Each(func(_ int, i interface{}) {
+			merged.Add(key, i.(string))
+		})
+	})
+	return merged
 }
 
 // Open attempts to open all Category data from the emojipedia/subcategories folder.
 func Open() (*Keywords, error) {
 	files, err := ioutil.ReadDir(directory.Keywords)
 	if err != nil {
-		return nil, err
+		return nil, store.Missing(err, store.ErrMissingKeywords)
 	}
 	keywords := New()
 	for _, file := range files {
@@ -65,21 +141,72 @@ func Open() (*Keywords, error) {
 	return keywords, nil
 }
 
+// OpenLocale attempts to open the Keywords translated for a locale from storage/<locale>/keywords.json,
+// indexed by their analyzer.Default key. Keywords list the translated emoji names, or the emoji ID where
+// the name is not translated. An empty locale, or one without translated keywords, opens the shared Keywords.
+func OpenLocale(code string) (*Keywords, error) {
+	if len(code) == 0 {
+		return Open()
+	}
+	l, err := locale.Open(code)
+	if err != nil {
+		return nil, err
+	}
+	if len(l.Keywords) == 0 {
+		return Open()
+	}
+	IDs := []string{}
+	for ID := range l.Keywords {
+		IDs = append(IDs, ID)
+	}
+	sort.Strings(IDs)
+	keywords := New()
+	for _, ID := range IDs {
+		name := ID
+		if translated, ok := l.Names[ID]; ok && len(translated) != 0 {
+			name = text.Normalize(translated)
+		}
+		for _, key := range l.Keywords[ID] {
+			if key = analyzer.Default.Key(key); len(key) != 0 {
+				keywords.Add(key, name)
+			}
+		}
+	}
+	return keywords, nil
+}
+
+// Remove deletes all Keyword data stored in the dependencies folder and the weights.Weights built with it.
+func Remove() error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	if err := store.Trash(directory.Keywords); err != nil {
+		return err
+	}
+	if err := weights.Remove(); err != nil && os.IsNotExist(err) == false {
+		return err
+	}
+	return nil
+}
+
 type keywords interface {
 	Add(key string, names ...string) *Keywords
 	Each(f func(slice *slice.Slice)) *Keywords
 	Fetch(key string) *slice.Slice
 	Get(key string) (*slice.Slice, bool)
+	GetOK(key string) (*slice.Slice, bool)
 	Has(key string) bool
 	Keys() *slice.Slice
 	Len() int
+	Merge(keywords *Keywords, strategy conflict.Strategy) *Keywords
 	Remove(key string) bool
+	TryFetch(key string) (*slice.Slice, error)
 	Values() *slice.Slice
 }
 
 // Keywords is a map-like struct with methods used to perform traversal and retrieval of slice.Slice pointers.
 type Keywords struct {
-	lexicon *lexicon.Lexicon
+	lexicon *lexicon.OrderedLexicon
 }
 
 // Add method adds one or more strings to the struct using the key reference to update or create the associated slice.
@@ -99,7 +226,7 @@ func (pointer *Keywords) Assign(key string, slice *slice.Slice) *Keywords {
 	return pointer
 }
 
-// Each method executes a provided function once for each slice.Slice pointer.
+// Each method executes a provided function once for each slice.Slice pointer, in the order they were added.
 func (pointer *Keywords) Each(f func(key string, slice *slice.Slice)) *Keywords {
 	pointer.lexicon.Each(func(key string, i interface{}) {
 		f(key, i.(*slice.Slice))
@@ -117,7 +244,21 @@ func (pointer *Keywords) Fetch(key string) *slice.Slice {
 // Panics if cannot convert to slice.Slice pointer.
 func (pointer *Keywords) Get(key string) (*slice.Slice, bool) {
 	property, ok := pointer.lexicon.Get(key)
-	return property.(*slice.Slice), ok
+	if ok == true {
+		return property.(*slice.Slice), ok
+	}
+	return nil, ok
+}
+
+// GetOK returns the slice.Slice pointer held by the argument key and a boolean indicating if it was successfully retrieved.
+// Unlike Get, a value that is not a slice.Slice pointer is reported as not retrieved rather than panicking.
+func (pointer *Keywords) GetOK(key string) (*slice.Slice, bool) {
+	property, ok := pointer.lexicon.Get(key)
+	if ok == false {
+		return nil, false
+	}
+	s, ok := property.(*slice.Slice)
+	return s, ok && s != nil
 }
 
 // Has method checks that a given key exists in the Keywords.
@@ -125,7 +266,7 @@ func (pointer *Keywords) Has(key string) bool {
 	return pointer.lexicon.Has(key)
 }
 
-// Keys method returns a slice.Slice of a given Keywords' own property names, in the same order as we get with a normal loop.
+// Keys method returns a slice.Slice of a given Keywords' own property names, in the order they were added.
 func (pointer *Keywords) Keys() *slice.Slice {
 	slice := slice.New()
 	pointer.lexicon.Each(func(key string, i interface{}) {
@@ -139,13 +280,68 @@ func (pointer *Keywords) Len() int {
 	return pointer.lexicon.Len()
 }
 
+// MarshalJSON encodes the Keywords as a plain JSON object keyed by name.
+func (pointer *Keywords) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pointer.lexicon)
+}
+
+// Merge method adds a copy of every keyword of the argument Keywords to the Keywords.
+// Keywords held by both are resolved by the conflict.Strategy: kept, replaced by the merged emoji or given the emoji of both.
+func (pointer *Keywords) Merge(keywords *Keywords, strategy conflict.Strategy) *Keywords {
+	keywords.Each(func(key string, s *slice.Slice) {
+		held, ok := pointer.Get(key)
+		switch {
+		case ok == false || strategy == conflict.Replace:
+			pointer.Assign(key, s.Copy())
+		case strategy == conflict.Union:
+			held.Union(s)
+		}
+	})
+	return pointer
+}
+
 // Remove method removes a entry from the Keywords if it exists. Returns a boolean to confirm if it succeeded.
 func (pointer *Keywords) Remove(key string) bool {
 	return pointer.lexicon.Remove(key)
 }
 
+// TryFetch retrieves the slice.Slice pointer held by the argument key.
+// Returns an error if the key does not exist or does not hold a slice.Slice pointer.
+func (pointer *Keywords) TryFetch(key string) (*slice.Slice, error) {
+	property, err := pointer.lexicon.TryFetch(key)
+	if err != nil {
+		return nil, fmt.Errorf("keywords: no keyword \"%s\"", key)
+	}
+	s, ok := property.(*slice.Slice)
+	if ok == false || s == nil {
+		return nil, fmt.Errorf("keywords: keyword \"%s\" does not hold a list of emoji", key)
+	}
+	return s, nil
+}
+
+// UnmarshalJSON decodes a plain JSON object keyed by name into the Keywords in the order it is written, replacing its contents.
+func (pointer *Keywords) UnmarshalJSON(content []byte) error {
+	var (
+		order  = lexicon.NewOrdered()
+		values = map[string]*slice.Slice{}
+	)
+	err := json.Unmarshal(content, order)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(content, &values)
+	if err != nil {
+		return err
+	}
+	pointer.lexicon = lexicon.NewOrdered()
+	order.Each(func(key string, _ interface{}) {
+		pointer.lexicon.Add(key, values[key])
+	})
+	return nil
+}
+
 // Values method returns a Slice of a given Keywords's own enumerable property values,
-// in the same order as that provided by a for...in loop.
+// in the order they were added.
 func (pointer *Keywords) Values() *slice.Slice {
 	slice := slice.New()
 	pointer.lexicon.Each(func(key string, i interface{}) {
@@ -1,6 +1,9 @@
 package lexicon
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/gellel/emojipedia/slice"
 )
 
@@ -27,6 +30,7 @@ type lexicon interface {
 	Len() int
 	Map(f func(key string, value interface{}) interface{}) *Lexicon
 	Remove(key string) bool
+	TryFetch(key string) (interface{}, error)
 	Values() *slice.Slice
 }
 
@@ -94,6 +98,14 @@ func (pointer *Lexicon) Map(f func(key string, value interface{}) interface{}) *
 	return pointer
 }
 
+// MarshalJSON encodes the Lexicon as a plain JSON object with sorted keys. A nil Lexicon is encoded as an empty object.
+func (pointer *Lexicon) MarshalJSON() ([]byte, error) {
+	if pointer == nil || *pointer == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[string]interface{}(*pointer))
+}
+
 // Missing method checks if a key is not present in the Lexicon.
 func (pointer *Lexicon) Missing(key string) bool {
 	return pointer.Has(key) == false
@@ -117,6 +129,26 @@ func (pointer *Lexicon) Replace(key string, value interface{}) bool {
 	return ok
 }
 
+// TryFetch retrieves the interface held by the argument key. Returns an error if key does not exist.
+func (pointer *Lexicon) TryFetch(key string) (interface{}, error) {
+	value, ok := (*pointer)[key]
+	if ok == false {
+		return nil, fmt.Errorf("lexicon: no value for key \"%s\"", key)
+	}
+	return value, nil
+}
+
+// UnmarshalJSON decodes a plain JSON object into the Lexicon, replacing its contents.
+func (pointer *Lexicon) UnmarshalJSON(content []byte) error {
+	values := map[string]interface{}{}
+	err := json.Unmarshal(content, &values)
+	if err != nil {
+		return err
+	}
+	(*pointer) = Lexicon(values)
+	return nil
+}
+
 // Values method returns a Slice of a given Lexicon's own enumerable property values, in the same order as that provided by a for...in loop.
 func (pointer *Lexicon) Values() *slice.Slice {
 	slice := slice.New()
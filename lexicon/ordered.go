@@ -0,0 +1,198 @@
+package lexicon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gellel/emojipedia/slice"
+)
+
+var (
+	_ ordered = (*OrderedLexicon)(nil)
+)
+
+// NewOrdered instantiates a new empty OrderedLexicon pointer.
+// OrderedLexicon pointers behave as Lexicon pointers but remember the order their keys were first added in,
+// so that traversals and encoded JSON follow that order rather than the random order of a map.
+func NewOrdered() *OrderedLexicon {
+	return &OrderedLexicon{values: map[string]interface{}{}}
+}
+
+type ordered interface {
+	Add(key string, value interface{}) *OrderedLexicon
+	Each(f func(key string, value interface{})) *OrderedLexicon
+	Fetch(key string) interface{}
+	Get(key string) (interface{}, bool)
+	Has(key string) bool
+	Keys() *slice.Slice
+	Len() int
+	Lexicon() *Lexicon
+	Map(f func(key string, value interface{}) interface{}) *OrderedLexicon
+	Remove(key string) bool
+	TryFetch(key string) (interface{}, error)
+	Values() *slice.Slice
+}
+
+// OrderedLexicon is a map-like object, iterated in insertion order, whose methods are used to perform traversal
+// and mutation operations by key-value pair.
+type OrderedLexicon struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// Add method adds one element to the OrderedLexicon using the key reference and returns the modified OrderedLexicon.
+// Replacing the value of a key does not change its position.
+func (pointer *OrderedLexicon) Add(key string, value interface{}) *OrderedLexicon {
+	if pointer.values == nil {
+		pointer.values = map[string]interface{}{}
+	}
+	if _, ok := pointer.values[key]; ok == false {
+		pointer.keys = append(pointer.keys, key)
+	}
+	pointer.values[key] = value
+	return pointer
+}
+
+// Each method executes a provided function once for each OrderedLexicon element, in insertion order.
+func (pointer *OrderedLexicon) Each(f func(key string, value interface{})) *OrderedLexicon {
+	for _, key := range append([]string{}, pointer.keys...) {
+		f(key, pointer.values[key])
+	}
+	return pointer
+}
+
+// Fetch retrieves the interface held by the argument key. Returns nil if key does not exist.
+func (pointer *OrderedLexicon) Fetch(key string) interface{} {
+	return pointer.values[key]
+}
+
+// Get returns the interface held by the argument key and a boolean indicating if it was successfully retrieved.
+func (pointer *OrderedLexicon) Get(key string) (interface{}, bool) {
+	value, ok := pointer.values[key]
+	return value, ok
+}
+
+// Has method checks that a given key exists in the OrderedLexicon.
+func (pointer *OrderedLexicon) Has(key string) bool {
+	_, ok := pointer.values[key]
+	return ok
+}
+
+// Keys method returns a Slice of a given OrderedLexicon's own property names, in insertion order.
+func (pointer *OrderedLexicon) Keys() *slice.Slice {
+	slice := slice.New()
+	for _, key := range pointer.keys {
+		slice.Append(key)
+	}
+	return slice
+}
+
+// Len method returns the number of elements in the OrderedLexicon.
+func (pointer *OrderedLexicon) Len() int {
+	return len(pointer.keys)
+}
+
+// Lexicon method returns the elements of the OrderedLexicon as an unordered Lexicon.
+func (pointer *OrderedLexicon) Lexicon() *Lexicon {
+	lexicon := New()
+	pointer.Each(func(key string, value interface{}) {
+		lexicon.Add(key, value)
+	})
+	return lexicon
+}
+
+// Map method executes a provided function once for each OrderedLexicon element and sets the returned value to the current key.
+func (pointer *OrderedLexicon) Map(f func(key string, value interface{}) interface{}) *OrderedLexicon {
+	pointer.Each(func(key string, value interface{}) {
+		pointer.values[key] = f(key, value)
+	})
+	return pointer
+}
+
+// MarshalJSON encodes the OrderedLexicon as a plain JSON object with its keys in insertion order.
+// A nil OrderedLexicon is encoded as an empty object.
+func (pointer *OrderedLexicon) MarshalJSON() ([]byte, error) {
+	if pointer == nil {
+		return []byte("{}"), nil
+	}
+	buffer := bytes.Buffer{}
+	buffer.WriteByte('{')
+	for i, key := range pointer.keys {
+		if i != 0 {
+			buffer.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(pointer.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(k)
+		buffer.WriteByte(':')
+		buffer.Write(v)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// Remove method removes a entry from the OrderedLexicon if it exists. Returns a boolean to confirm if it succeeded.
+func (pointer *OrderedLexicon) Remove(key string) bool {
+	ok := pointer.Has(key)
+	if ok == true {
+		delete(pointer.values, key)
+		for i, k := range pointer.keys {
+			if k == key {
+				pointer.keys = append(pointer.keys[:i], pointer.keys[i+1:]...)
+				break
+			}
+		}
+	}
+	return ok
+}
+
+// TryFetch retrieves the interface held by the argument key. Returns an error if key does not exist.
+func (pointer *OrderedLexicon) TryFetch(key string) (interface{}, error) {
+	value, ok := pointer.values[key]
+	if ok == false {
+		return nil, fmt.Errorf("lexicon: no value for key \"%s\"", key)
+	}
+	return value, nil
+}
+
+// UnmarshalJSON decodes a plain JSON object into the OrderedLexicon in the order its keys are written, replacing its contents.
+func (pointer *OrderedLexicon) UnmarshalJSON(content []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); ok == false || delim != '{' {
+		return fmt.Errorf("lexicon: cannot decode %v into an OrderedLexicon", token)
+	}
+	pointer.keys, pointer.values = nil, map[string]interface{}{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+		pointer.Add(token.(string), value)
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+// Values method returns a Slice of a given OrderedLexicon's own property values, in insertion order.
+func (pointer *OrderedLexicon) Values() *slice.Slice {
+	slice := slice.New()
+	pointer.Each(func(key string, value interface{}) {
+		slice.Append(value)
+	})
+	return slice
+}
@@ -0,0 +1,113 @@
+package locale
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/text"
+)
+
+const (
+	descriptions string = "descriptions.json"
+	keywords     string = "keywords.json"
+	names        string = "names.json"
+)
+
+var (
+	// tag matches a BCP 47 language tag: a language, then an optional script, region and variants.
+	tag = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z]{4})?(-[a-zA-Z]{2}|-[0-9]{3})?(-[a-zA-Z0-9]{5,8}|-[0-9][a-zA-Z0-9]{3})*$`)
+)
+
+// Tag returns the argument BCP 47 language tag in its canonical case, such as "pt-BR" for "pt-br" or "zh-Hant" for "ZH-HANT",
+// or an error if it is not a language tag. Only tags are accepted, so a locale cannot name a folder outside the profile.
+func Tag(code string) (string, error) {
+	if tag.MatchString(code) == false {
+		return "", fmt.Errorf("locale: \"%s\" is not a language tag such as fr or pt-BR", code)
+	}
+	subtags := strings.Split(code, "-")
+	subtags[0] = strings.ToLower(subtags[0])
+	for i := 1; i < len(subtags); i++ {
+		switch {
+		case len(subtags[i]) == 4 && i == 1:
+			subtags[i] = strings.ToUpper(subtags[i][:1]) + strings.ToLower(subtags[i][1:])
+		case len(subtags[i]) == 2:
+			subtags[i] = strings.ToUpper(subtags[i])
+		default:
+			subtags[i] = strings.ToLower(subtags[i])
+		}
+	}
+	return strings.Join(subtags, "-"), nil
+}
+
+// Open attempts to open the translations stored for the locale code in storage/<locale>/.
+// Translations that have not been stored are left empty, so the shared dataset is used in their place.
+// The locale code must be a language Tag.
+func Open(code string) (*Locale, error) {
+	code, err := Tag(code)
+	if err != nil {
+		return nil, err
+	}
+	locale := &Locale{
+		Code:         code,
+		Descriptions: map[string]string{},
+		Keywords:     map[string][]string{},
+		Names:        map[string]string{}}
+	for file, v := range locale.files() {
+		path := filepath.Join(directory.Locale(code), file)
+		content, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		err = store.Decode(path, content, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return locale, nil
+}
+
+// Locale holds the names, descriptions and keywords of the emoji in one language, each keyed by emoji.Emoji.ID.
+type Locale struct {
+	Code         string
+	Descriptions map[string]string
+	Keywords     map[string][]string
+	Names        map[string]string
+}
+
+// Apply method replaces the name, description and keywords of the emoji.Emoji with those translated by the Locale.
+// Fields without a translation are left untouched.
+func (pointer *Locale) Apply(e *emoji.Emoji) *emoji.Emoji {
+	if name, ok := pointer.Names[e.ID]; ok && len(name) != 0 {
+		e.SetName(text.Normalize(name))
+	}
+	if description, ok := pointer.Descriptions[e.ID]; ok && len(description) != 0 {
+		e.SetDescription(description)
+	}
+	if keywords, ok := pointer.Keywords[e.ID]; ok {
+		s := slice.New()
+		for _, keyword := range keywords {
+			s.Append(text.Normalize(keyword))
+		}
+		e.SetKeywords(s)
+	}
+	return e
+}
+
+// files returns the translations of the Locale by the file they are stored in.
+func (pointer *Locale) files() map[string]interface{} {
+	return map[string]interface{}{
+		descriptions: &pointer.Descriptions,
+		keywords:     &pointer.Keywords,
+		names:        &pointer.Names}
+}
@@ -1,33 +1,216 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/gellel/emojipedia/analyzer"
 	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emojidata"
+	"github.com/gellel/emojipedia/locale"
+	"github.com/gellel/emojipedia/merge"
+	"github.com/gellel/emojipedia/pkg"
 	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/text"
+	"github.com/gellel/emojipedia/webhook"
 )
 
 func main() {
 	arguments := arguments.NewArguments(os.Args[1:])
-	switch strings.ToUpper(arguments.Get(0)) {
+	if format, ok := arguments.Flag("error-format"); ok {
+		errorFormat = strings.ToUpper(format)
+	}
+	if _, ok := arguments.Flag("by-id"); ok {
+		store.ByID = true
+	}
+	if indent, ok := arguments.Flag("indent"); ok {
+		store.Indent = "\t"
+		if n, err := strconv.Atoi(indent); err == nil {
+			store.Indent = strings.Repeat(" ", n)
+		}
+	}
+	if value, ok := arguments.Flag("parser"); ok {
+		parser = strings.ToUpper(value)
+	}
+	if value, ok := arguments.Flag("source"); ok {
+		sources = strings.Split(strings.ToUpper(value), ",")
+	}
+	if path, ok := arguments.Flag("precedence"); ok {
+		p, err := merge.Open(path)
+		if err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, path, err), err)
+		}
+		precedence = p
+	}
+	if url, ok := arguments.Flag("webhook"); ok {
+		webhook.URL = url
+	}
+	if secret, ok := arguments.Flag("webhook-secret"); ok {
+		webhook.Secret = secret
+	}
+	if _, ok := arguments.Flag("strict"); ok {
+		store.Strict = true
+		store.Reject = func(err error) {
+			fmt.Println(fmt.Sprintf(errorRejected, errors.Unwrap(err)))
+		}
+	}
+	if _, ok := arguments.Flag("read-only"); ok {
+		store.ReadOnly = true
+	}
+	if code, ok := arguments.Flag("locale"); ok {
+		tag, err := locale.Tag(code)
+		if err != nil {
+			failWith(usage, err.Error(), err)
+		}
+		analyzer.Use(tag)
+		language = tag
+	}
+	if profile, ok := arguments.Flag("profile"); ok {
+		directory.Use(profile)
+	}
+	if version, ok := arguments.Flag("unicode-version"); ok {
+		if err := pkg.Pin(version); err != nil {
+			failWith(usage, err.Error(), err)
+		}
+		emojidata.Version = pkg.Version
+	}
+	for _, categories := range arguments.Flags("category") {
+		for _, category := range strings.Split(categories, ",") {
+			if category = text.Normalize(category); len(category) != 0 {
+				pkg.Categories = append(pkg.Categories, category)
+			}
+		}
+	}
+	if agent, ok := arguments.Flag("user-agent"); ok {
+		client.UserAgent = agent
+	}
+	for _, header := range arguments.Flags("header") {
+		if parts := strings.SplitN(header, ":", 2); len(parts) == 2 {
+			client.Header.Add(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+		}
+	}
+	if proxy, ok := arguments.Flag("proxy"); ok {
+		client.Proxy = proxy
+	}
+	if path, ok := arguments.Flag("ca-bundle"); ok {
+		client.CA = path
+	}
+	if path, ok := arguments.Flag("client-cert"); ok {
+		client.Certificate = path
+	}
+	if path, ok := arguments.Flag("client-key"); ok {
+		client.Key = path
+	}
+	if _, ok := arguments.Flag("insecure"); ok {
+		client.Insecure = true
+	}
+	if err := client.Configure(); err != nil {
+		failWith(usage, fmt.Sprintf(errorCannotOpen, "http client", err), err)
+	}
+	if value, ok := arguments.Flag("concurrency"); ok {
+		client.Concurrency, _ = strconv.Atoi(value)
+	}
+	if value, ok := arguments.Flag("delay"); ok {
+		client.Delay, _ = time.ParseDuration(value)
+	}
+	if wait, ok := arguments.Flag("wait"); ok {
+		store.Wait, _ = time.ParseDuration(wait)
+	}
+	use(indexing, profiler, timing, locking, paging)
+	defer cleanup()
+	defer recovered()
+	dispatch(arguments)
+}
+
+// dispatch runs the command the first argument names, or prints the usage of the program.
+func dispatch(arguments *arguments.Arguments) {
+	plugin(arguments)
+	switch command(arguments, commands...) {
+	case A, ALT:
+		run(ALT, altMain, arguments.Next())
+	case AN, ANNOTATE:
+		run(ANNOTATE, annotateMain, arguments.Next())
+	case AP, APPLY:
+		run(APPLY, applyMain, arguments.Next())
+	case BB, BENCH:
+		run(BENCH, benchMain, arguments.Next())
+	case B, BUILD:
+		run(BUILD, buildMain, arguments.Next())
 	case C, CATEGORIES:
-		categoriesMain(arguments.Next())
+		run(CATEGORIES, categoriesMain, arguments.Next())
 	case CC, CATEGORY:
-		categoryMain(arguments.Next())
+		run(CATEGORY, categoryMain, arguments.Next())
+	case CU, CHECKUPSTREAM:
+		run(CHECKUPSTREAM, upstreamMain, arguments.Next())
 	case EE, EMOJI:
-		emojiMain(arguments.Next())
+		run(EMOJI, emojiMain, arguments.Next())
+	case DM, DAEMON:
+		run(DAEMON, daemonMain, arguments.Next())
+	case D, DIFF:
+		run(DIFF, diffMain, arguments.Next())
+	case DC, DISCORD:
+		run(DISCORD, discordMain, arguments.Next())
+	case DR, DOCTOR:
+		run(DOCTOR, doctorMain, arguments.Next())
 	case E, EMOJIPEDIA:
-		emojipediaMain(arguments.Next())
+		run(EMOJIPEDIA, emojipediaMain, arguments.Next())
+	case FV, FAV:
+		run(FAV, favMain, arguments.Next())
+	case FD, FETCHDATASET:
+		run(FETCHDATASET, fetchMain, arguments.Next())
+	case F, FLAG:
+		run(FLAG, flagMain, arguments.Next())
+	case G, GEN:
+		run(GEN, genMain, arguments.Next())
+	case GR, GREP:
+		run(GREP, grepMain, arguments.Next())
+	case H, HELP:
+		run(HELP, helpMain, arguments.Next())
+	case HI, HISTORY:
+		run(HISTORY, historyMain, arguments.Next())
+	case IM, IMAGES:
+		run(IMAGES, imagesMain, arguments.Next())
 	case K, KEYWORDS:
-		keywordsMain(arguments.Next())
+		run(KEYWORDS, keywordsMain, arguments.Next())
+	case NW, NEW:
+		run(NEW, newMain, arguments.Next())
+	case PK, PACK:
+		run(PACK, packMain, arguments.Next())
+	case PP, PROFILES:
+		run(PROFILES, profilesMain, arguments.Next())
+	case RN, RENDER:
+		run(RENDER, renderMain, arguments.Next())
+	case RL, RESTORELAST:
+		run(RESTORELAST, restoreMain, arguments.Next())
+	case RP, REPAIR:
+		run(REPAIR, repairMain, arguments.Next())
 	case S, SUBCATEGORIES:
-		subcategoriesMain(arguments.Next())
+		run(SUBCATEGORIES, subcategoriesMain, arguments.Next())
+	case SV, SERVE:
+		run(SERVE, serveMain, arguments.Next())
+	case SH, SHELL:
+		run(SHELL, shellMain, arguments.Next())
+	case ST, STATUS:
+		run(STATUS, statusMain, arguments.Next())
 	case SS, SUBCATEGORY:
-		subcategoryMain(arguments.Next())
+		run(SUBCATEGORY, subcategoryMain, arguments.Next())
+	case TG, TAG:
+		run(TAG, tagMain, arguments.Next())
+	case TP, TOPICS:
+		run(TOPICS, topicsMain, arguments.Next())
+	case T, TREE:
+		run(TREE, treeMain, arguments.Next())
 	case U, UNICODE:
-		unicodeorgMain(arguments.Next())
+		run(UNICODE, unicodeorgMain, arguments.Next())
+	case W, WATCH:
+		run(WATCH, watchMain, arguments.Next())
 	default:
 		fmt.Fprintln(writer, "usage: emojipedia [-abbreviation|verbose] <command> [args [...<args>]]")
 		fmt.Fprintln(writer)
@@ -35,20 +218,51 @@ func main() {
 		fmt.Fprintln(writer)
 		fmt.Fprintln(writer, "building a new subprogram/getting started")
 		fmt.Fprintln(writer, building)
+		fmt.Fprintln(writer, bopt)
+		fmt.Fprintln(writer, fdopt)
+		fmt.Fprintln(writer, apopt)
+		fmt.Fprintln(writer, wopt)
+		fmt.Fprintln(writer, cuopt)
+		fmt.Fprintln(writer, dropt)
+		fmt.Fprintln(writer, stopt)
+		fmt.Fprintln(writer, hpopt)
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "flags")
+		slice.New(requesting, proxying, encoding, identifying, selecting, indenting, localizing, paginating, parsing, preferring, profiling, protecting, reporting, sourcing, strict, tracing, measuring, versioning, notifying, waiting).Each(func(_ int, i interface{}) {
+			fmt.Fprintln(writer, i.(string))
+		})
 		fmt.Fprintln(writer)
 		fmt.Fprintln(writer, "removing an installed package")
 		fmt.Fprintln(writer, removing)
+		fmt.Fprintln(writer, rlopt)
+		fmt.Fprintln(writer, rpopt)
 		fmt.Fprintln(writer)
 		fmt.Fprintln(writer, "browsing programs collection of contents")
-		slice.New(copt, kopt, eopt, sopt).Each(func(_ int, i interface{}) {
+		slice.New(copt, dopt, kopt, eopt, fvopt, hiopt, imopt, nwopt, popt, sopt, tgopt, tpopt, topt).Each(func(_ int, i interface{}) {
 			fmt.Fprintln(writer, i.(string))
 		})
 		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "transforming and exporting content")
+		slice.New(aopt, anopt, dcopt, dmopt, gopt, gropt, pkopt, rnopt, svopt, shopt).Each(func(_ int, i interface{}) {
+			fmt.Fprintln(writer, i.(string))
+		})
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "measuring performance")
+		fmt.Fprintln(writer, bbopt)
+		fmt.Fprintln(writer)
 		fmt.Fprintln(writer, "browsing specific content")
-		slice.New(ccopt, eeopt, ssopt).Each(func(_ int, i interface{}) {
+		slice.New(ccopt, eeopt, fopt, ssopt).Each(func(_ int, i interface{}) {
 			fmt.Fprintln(writer, i.(string))
 		})
 		fmt.Fprintln(writer)
+		if commands := plugins(); len(commands) != 0 {
+			fmt.Fprintln(writer, "plugins on the PATH")
+			for _, command := range commands {
+				fmt.Fprintln(writer, fmt.Sprintf("  [%s]\truns %s%s", command, pluginPrefix, command))
+			}
+			fmt.Fprintln(writer)
+		}
 		writer.Flush()
+		unknown(arguments)
 	}
 }
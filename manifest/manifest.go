@@ -0,0 +1,73 @@
+package manifest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/store"
+)
+
+// New instantiates a new Manifest pointer for the active profile.
+func New() *Manifest {
+	now := time.Now().UTC()
+	return &Manifest{
+		Created:  now,
+		Packages: map[string]time.Time{},
+		Profile:  directory.Profile,
+		Updated:  now}
+}
+
+// Open attempts to open the Manifest of the active profile. A missing Manifest is reported as an error.
+func Open() (*Manifest, error) {
+	content, err := ioutil.ReadFile(directory.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	manifest := New()
+	err = store.Decode(directory.Manifest, content, manifest)
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Touch records that a package was (re)built in the active profile, creating the Manifest if required.
+func Touch(name string) error {
+	manifest, err := Open()
+	if os.IsNotExist(err) {
+		manifest, err = New(), nil
+	}
+	if err != nil {
+		return err
+	}
+	manifest.Updated = time.Now().UTC()
+	manifest.Packages[name] = manifest.Updated
+	return Write(manifest)
+}
+
+// Write stores the Manifest in the active profile.
+func Write(manifest *Manifest) error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(filepath.Dir(directory.Manifest), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	content, err := store.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(directory.Manifest, content, os.ModePerm)
+}
+
+// Manifest describes a stored dataset: the profile it belongs to and when each of its packages was built.
+type Manifest struct {
+	Created  time.Time            `json:"created"`
+	Packages map[string]time.Time `json:"packages"`
+	Profile  string               `json:"profile"`
+	Updated  time.Time            `json:"updated"`
+}
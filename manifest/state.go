@@ -0,0 +1,58 @@
+package manifest
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+const (
+	// Stale is how long after it was built a dataset is reported as stale.
+	Stale = 90 * 24 * time.Hour
+)
+
+// Inspect reports the State of the named package stored at the path of the active profile.
+// The package is built when the Manifest says so, or when its files were last written for datasets
+// built before the Manifest was kept.
+func Inspect(name, path string) *State {
+	state := &State{Name: name, Path: path}
+	info, err := os.Stat(path)
+	if err != nil {
+		return state
+	}
+	state.Built, state.Exists, state.Items = info.ModTime().UTC(), true, 1
+	if info.IsDir() {
+		files, _ := ioutil.ReadDir(path)
+		state.Items = 0
+		for _, file := range files {
+			if file.Mode().IsRegular() {
+				state.Items++
+			}
+		}
+	}
+	if manifest, err := Open(); err == nil {
+		if built, ok := manifest.Packages[name]; ok {
+			state.Built = built
+		}
+	}
+	return state
+}
+
+// State is whether a package of the active profile is stored, how many files it holds and when it was built.
+type State struct {
+	Built  time.Time
+	Exists bool
+	Items  int
+	Name   string
+	Path   string
+}
+
+// Age method returns how long before the argument time the package was built.
+func (pointer *State) Age(now time.Time) time.Duration {
+	return now.Sub(pointer.Built)
+}
+
+// Stale method returns whether the package was built more than Stale before the argument time.
+func (pointer *State) Stale(now time.Time) bool {
+	return pointer.Exists && pointer.Age(now) > Stale
+}
@@ -0,0 +1,216 @@
+package merge
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/store"
+)
+
+const (
+	// CLDR names the Unicode Common Locale Data Repository as a source.
+	CLDR string = "cldr"
+	// Emojipedia names emojipedia.org, the source of emoji descriptions.
+	Emojipedia string = "emojipedia"
+	// Gemoji names the github/gemoji database as a source.
+	Gemoji string = "gemoji"
+	// Unicode names the unicode.org emoji chart as a source.
+	Unicode string = "unicode"
+)
+
+var (
+	sliceType = reflect.TypeOf(&slice.Slice{})
+)
+
+// Default returns the Precedence used when none is configured: descriptions from emojipedia.org, the localized names
+// from CLDR, the union of every source's keywords, shortcodes and art sources, and everything else from unicode.org before gemoji.
+// Emoji are renamed to their CLDR short names by validating them rather than by merging.
+func Default() *Precedence {
+	return &Precedence{
+		Default: []string{Unicode, Gemoji},
+		Fields: map[string]*Rule{
+			"description": {Sources: []string{Emojipedia, Unicode}},
+			"keywords":    {Sources: []string{Unicode, Gemoji, CLDR, Emojipedia}, Union: true},
+			"names":       {Sources: []string{CLDR}},
+			"shortcodes":  {Sources: []string{Gemoji, Unicode}, Union: true},
+			"sources":     {Sources: []string{Unicode, Gemoji}, Union: true}}}
+}
+
+// Open attempts to read a Precedence from a JSON file. Fields the file does not configure keep their Default rule.
+func Open(path string) (*Precedence, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	precedence := &Precedence{}
+	err = store.Decode(path, content, precedence)
+	if err != nil {
+		return nil, err
+	}
+	defaults := Default()
+	if len(precedence.Default) == 0 {
+		precedence.Default = defaults.Default
+	}
+	if precedence.Fields == nil {
+		precedence.Fields = map[string]*Rule{}
+	}
+	for field, rule := range defaults.Fields {
+		if _, ok := precedence.Fields[field]; ok == false {
+			precedence.Fields[field] = rule
+		}
+	}
+	return precedence, nil
+}
+
+// Emoji merges the candidate emoji.Emoji of each named source into a new emoji.Emoji, field by field.
+// Each field is taken from the first source in its precedence that populates it, or is the union of every source
+// for Union rules. The emoji.Provenance of each field is carried over from the candidates it was taken from;
+// candidates without one are recorded as the named source with an unknown fetch time.
+func Emoji(precedence *Precedence, candidates map[string]*emoji.Emoji) *emoji.Emoji {
+	var (
+		merged    = emoji.New()
+		populated = map[string]map[string]bool{}
+		t         = reflect.TypeOf(*merged)
+		value     = reflect.ValueOf(merged).Elem()
+	)
+	for source, candidate := range candidates {
+		populated[source] = map[string]bool{}
+		for _, field := range candidate.Populated() {
+			populated[source][field] = true
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if field == "provenance" {
+			continue
+		}
+		rule := precedence.Rule(field)
+		for _, source := range rule.Sources {
+			if populated[source][field] == false {
+				continue
+			}
+			candidate := candidates[source]
+			if provenance, ok := candidate.Provenance[field]; ok && len(provenance) != 0 {
+				merged.SetProvenance(record(merged.Provenance, field, provenance...))
+			} else {
+				merged.Record(field, source, time.Time{})
+			}
+			v := reflect.ValueOf(candidate).Elem().Field(i)
+			if rule.Union == false {
+				value.Field(i).Set(v)
+				break
+			}
+			union(value.Field(i), v)
+		}
+	}
+	return merged
+}
+
+// All merges the emojipedia.Emojipedia of each named source into a new emojipedia.Emojipedia, matching emoji by ID.
+// Only the emoji held by the first default source are merged, so a supplementary source cannot introduce emoji
+// that the rest of the dataset (such as its categories and subcategories) does not know about.
+func All(precedence *Precedence, sources map[string]*emojipedia.Emojipedia) *emojipedia.Emojipedia {
+	merged := emojipedia.New()
+	primary, ok := sources[precedence.Default[0]]
+	if ok == false {
+		return merged
+	}
+	primary.Each(func(ID string, _ *emoji.Emoji) {
+		candidates := map[string]*emoji.Emoji{}
+		for name, source := range sources {
+			if e, ok := source.Get(ID); ok {
+				candidates[name] = e
+			}
+		}
+		merged.Add(Emoji(precedence, candidates))
+	})
+	return merged
+}
+
+// fallback returns the sources followed by the named source, unless they already include it.
+func fallback(sources []string, source string) []string {
+	for _, s := range sources {
+		if s == source {
+			return append([]string{}, sources...)
+		}
+	}
+	return append(append([]string{}, sources...), source)
+}
+
+// record appends provenance to the named field of a provenance map, creating the map if required.
+func record(provenance map[string][]*emoji.Provenance, field string, values ...*emoji.Provenance) map[string][]*emoji.Provenance {
+	if provenance == nil {
+		provenance = map[string][]*emoji.Provenance{}
+	}
+	provenance[field] = append(provenance[field], values...)
+	return provenance
+}
+
+// union adds the values of a candidate's field missing from the merged field. Other values are only set while the merged field is unset.
+// slice.Slice values are compared by their string form and map entries keep the first source's value.
+func union(merged, v reflect.Value) {
+	switch {
+	case v.Type() == sliceType:
+		if merged.IsNil() {
+			merged.Set(reflect.ValueOf(&slice.Slice{}))
+		}
+		s := merged.Interface().(*slice.Slice)
+		held := map[string]bool{}
+		s.Each(func(_ int, i interface{}) {
+			held[strings.ToLower(i.(string))] = true
+		})
+		v.Interface().(*slice.Slice).Each(func(_ int, i interface{}) {
+			if key := strings.ToLower(i.(string)); held[key] == false {
+				held[key] = true
+				s.Append(i)
+			}
+		})
+	case v.Kind() == reflect.Map:
+		if merged.IsNil() {
+			merged.Set(reflect.MakeMap(v.Type()))
+		}
+		for _, key := range v.MapKeys() {
+			if merged.MapIndex(key).IsValid() == false {
+				merged.SetMapIndex(key, v.MapIndex(key))
+			}
+		}
+	case merged.IsZero():
+		merged.Set(v)
+	}
+}
+
+// Precedence configures the order sources are consulted in for each emoji.Emoji field, named by its JSON key.
+// Fields without a Rule are taken from the Default sources.
+type Precedence struct {
+	Default []string         `json:"default"`
+	Fields  map[string]*Rule `json:"fields"`
+}
+
+// Fallback method returns a copy of the Precedence that consults the named source for every field after
+// the sources configured for it, so emoji held only by that source keep its values.
+func (pointer *Precedence) Fallback(source string) *Precedence {
+	precedence := &Precedence{Default: fallback(pointer.Default, source), Fields: map[string]*Rule{}}
+	for field, rule := range pointer.Fields {
+		precedence.Fields[field] = &Rule{Sources: fallback(rule.Sources, source), Union: rule.Union}
+	}
+	return precedence
+}
+
+// Rule method returns the Rule configured for the named field, falling back to the Default sources.
+func (pointer *Precedence) Rule(field string) *Rule {
+	if rule, ok := pointer.Fields[field]; ok {
+		return rule
+	}
+	return &Rule{Sources: pointer.Default}
+}
+
+// Rule lists the sources of a field by precedence. Union rules combine the values of every source instead of taking the first.
+type Rule struct {
+	Sources []string `json:"sources"`
+	Union   bool     `json:"union"`
+}
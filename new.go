@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojidata"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/text"
+)
+
+var (
+	emojiVersion = regexp.MustCompile(`^\d+(\.\d+)?$`)
+)
+
+// added returns the emoji added in or after the emoji version, or released on or after the date, given with --since,
+// newest first. Without --since the emoji of the latest emoji version are returned.
+func added(arguments *arguments.Arguments) []*emoji.Emoji {
+	var (
+		emojipedia = emojipedia.GetLocale(language)
+		since, ok  = arguments.Flag("since")
+		values     = []*emoji.Emoji{}
+	)
+	emojipedia.Each(func(_ string, e *emoji.Emoji) {
+		if len(e.Version) != 0 {
+			values = append(values, e)
+		}
+	})
+	if len(values) == 0 {
+		return values
+	}
+	if ok == false {
+		for _, e := range values {
+			if emojidata.Compare(e.Version, since) > 0 {
+				since = e.Version
+			}
+		}
+	}
+	include := func(e *emoji.Emoji) bool {
+		return emojidata.Compare(e.Version, since) >= 0
+	}
+	if emojiVersion.MatchString(since) == false {
+		date, err := time.Parse("2006-01-02", since)
+		if err != nil {
+			failWith(usage, fmt.Sprintf(errorSince, since), err)
+		}
+		include = func(e *emoji.Emoji) bool {
+			released, ok := emojidata.Released(e.Version)
+			return ok && released.Before(date) == false
+		}
+	}
+	selected := []*emoji.Emoji{}
+	for _, e := range values {
+		if include(e) {
+			selected = append(selected, e)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		if order := emojidata.Compare(selected[i].Version, selected[j].Version); order != 0 {
+			return order > 0
+		}
+		return selected[i].Number < selected[j].Number
+	})
+	return selected
+}
+
+func newMain(arguments *arguments.Arguments) {
+	fmt.Fprintln(writer, "\t|Name\t|Version\t|Released\t|Category\t|Subcategory")
+	for _, e := range added(arguments) {
+		released := "-"
+		if date, ok := emojidata.Released(e.Version); ok {
+			released = date.Format("2006-01-02")
+		}
+		fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v\t|%v\t|%v\t|%v\t|%v", text.Emojize(e.Unicode), e.Name, e.Version, released, e.Category, e.Subcategory))
+	}
+	writer.Flush()
+}
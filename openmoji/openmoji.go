@@ -0,0 +1,77 @@
+package openmoji
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/lexicon"
+	"github.com/gellel/emojipedia/source"
+	"github.com/gellel/emojipedia/text"
+)
+
+const (
+	// Name is the key OpenMoji metadata is stored under in emoji.Emoji.Sources.
+	Name = "openmoji"
+	// URL is the metadata published by the OpenMoji project.
+	URL = "https://raw.githubusercontent.com/hfg-gmuend/openmoji/master/data/openmoji.json"
+	// Art is the address of the color SVG artwork of an OpenMoji hexcode.
+	Art = "https://raw.githubusercontent.com/hfg-gmuend/openmoji/master/color/svg/%s.svg"
+)
+
+// Open attempts to read the OpenMoji metadata from a local file or, if the argument is a URL, over HTTP.
+func Open(path string) (*lexicon.Lexicon, error) {
+	reader, err := client.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return Parse(reader)
+}
+
+// Parse reads OpenMoji formatted metadata into a lexicon.Lexicon of source.Source pointers keyed by emoji ID.
+// Skin tone variants are listed by ID in the source.Source of the emoji they are based on.
+func Parse(reader io.Reader) (*lexicon.Lexicon, error) {
+	entries := []*entry{}
+	err := json.NewDecoder(reader).Decode(&entries)
+	if err != nil {
+		return nil, err
+	}
+	lexicon := &lexicon.Lexicon{}
+	for _, e := range entries {
+		s := source.New()
+		s.Annotation = e.Annotation
+		s.Art = fmt.Sprintf(Art, e.Hexcode)
+		s.Group = text.Normalize(e.Group)
+		for _, tags := range []string{e.Tags, e.OpenmojiTags} {
+			for _, tag := range strings.Split(tags, ",") {
+				if tag = text.Normalize(tag); len(tag) != 0 {
+					s.Tags.Append(tag)
+				}
+			}
+		}
+		lexicon.Add(source.ID(e.Hexcode), s)
+	}
+	for _, e := range entries {
+		if len(e.Skintone) == 0 || len(e.SkintoneBaseHexcode) == 0 {
+			continue
+		}
+		if base, ok := lexicon.Get(source.ID(e.SkintoneBaseHexcode)); ok {
+			base.(*source.Source).Skintones.Append(source.ID(e.Hexcode))
+		}
+	}
+	return lexicon, nil
+}
+
+// entry is a record of the OpenMoji metadata.
+type entry struct {
+	Annotation          string `json:"annotation"`
+	Group               string `json:"group"`
+	Hexcode             string `json:"hexcode"`
+	OpenmojiTags        string `json:"openmoji_tags"`
+	Skintone            string `json:"skintone"`
+	SkintoneBaseHexcode string `json:"skintone_base_hexcode"`
+	Tags                string `json:"tags"`
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emojidata"
+	"github.com/gellel/emojipedia/index"
+	"github.com/gellel/emojipedia/pkg"
+	"github.com/gellel/emojipedia/release"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/text"
+)
+
+// published opens the pack a fetch-dataset argument names: the URL of a pack, a pack file or the dataset version
+// of a published pack. The URL or file the pack was opened from is returned with it, to find its sidecar files by.
+func published(source string) (io.ReadCloser, string, error) {
+	if text.IsURL(source) == false {
+		if _, err := os.Stat(source); err == nil {
+			reader, err := os.Open(source)
+			return reader, source, err
+		}
+		source = release.Address(source)
+	}
+	resp, err := client.Get(source)
+	if err != nil {
+		return nil, source, err
+	}
+	if resp.StatusCode != 200 {
+		resp.Body.Close()
+		return nil, source, fmt.Errorf("%s", resp.Status)
+	}
+	return resp.Body, source, nil
+}
+
+// sidecar reads the file published beside a pack under its name followed by the extension, such as its
+// ".sha256" checksum or ".sig" signature. Returns nil when no such file was published.
+func sidecar(source, extension string) []byte {
+	if text.IsURL(source) == false {
+		content, _ := ioutil.ReadFile(source + extension)
+		return content
+	}
+	resp, err := client.Get(source + extension)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil
+	}
+	content, _ := ioutil.ReadAll(resp.Body)
+	return content
+}
+
+// checksumOf reads the checksum out of the "<checksum>  <file>" line of a sha256sum file.
+func checksumOf(content []byte) string {
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToLower(fields[0])
+}
+
+// trust reads the public keys of every --verify flag. Packs must be signed by one of them to be installed.
+func trust(arguments *arguments.Arguments) []*release.PublicKey {
+	keys := []*release.PublicKey{}
+	for _, file := range arguments.Flags("verify") {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			failWith(usage, fmt.Sprintf(errorCannotOpen, file, err), err)
+		}
+		key, err := release.ParsePublicKey(content)
+		if err != nil {
+			failWith(usage, fmt.Sprintf(errorCannotOpen, file, err), err)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// verify checks that the signature published beside the pack was made by one of the trusted keys over the digest
+// of the pack, and that it was signed as the dataset version the pack holds, returning the trusted comment of the signature.
+func verify(source, version string, digest []byte, keys []*release.PublicKey) (string, error) {
+	content := sidecar(source, release.Extension)
+	if content == nil {
+		return "", fmt.Errorf("pack has no signature \"%s\"", source+release.Extension)
+	}
+	signature, err := release.ParseSignature(content)
+	if err != nil {
+		return "", err
+	}
+	for _, key := range keys {
+		if key.ID != signature.ID {
+			continue
+		}
+		if err = key.Verify(digest, signature); err != nil {
+			return "", err
+		}
+		// The comment is signed with the pack, so an older pack cannot pass for the version it replaces.
+		if signed := signedVersion(signature.Comment); signed != version {
+			return "", fmt.Errorf("pack holds dataset \"%s\" but was signed as \"%s\"", version, signed)
+		}
+		return signature.Comment, nil
+	}
+	return "", fmt.Errorf("pack is signed by key %s, which is not trusted", signature.Key())
+}
+
+// signedVersion reads the dataset version out of the "file:<name> version:<version> timestamp:<time>" trusted comment
+// packs are signed with. Returns an empty string when the comment names no version.
+func signedVersion(comment string) string {
+	for _, field := range strings.Fields(comment) {
+		if strings.HasPrefix(field, "version:") {
+			return strings.TrimPrefix(field, "version:")
+		}
+	}
+	return ""
+}
+
+func fetchMain(arguments *arguments.Arguments) {
+	source := arguments.Get(0)
+	if len(source) == 0 || strings.HasPrefix(source, "--") {
+		failWith(usage, fmt.Sprintf(errorFetchDataset, source, "give the url, file or version of a pack"), ErrMissingArgument)
+	}
+	keys := trust(arguments)
+	reader, source, err := published(source)
+	if err != nil {
+		fail(fmt.Sprintf(errorFetchDataset, source, err), err)
+	}
+	defer reader.Close()
+	var (
+		checksum = checksumOf(sidecar(source, ".sha256"))
+		digest   = sha512.New()
+		hash     = sha256.New()
+		staging  = directory.Root + ".staging"
+		tee      = io.TeeReader(reader, io.MultiWriter(hash, digest))
+	)
+	if len(checksum) == 0 {
+		// Published packs always have a checksum beside them; a pack file may have been copied without it.
+		if text.IsURL(source) {
+			err = fmt.Errorf("pack has no published checksum \"%s\"", source+".sha256")
+			fail(fmt.Sprintf(errorFetchDataset, source, err), err)
+		}
+		fmt.Fprintln(os.Stderr, fmt.Sprintf(statusUnchecked, source+".sha256"))
+	}
+	if err = os.RemoveAll(staging); err != nil {
+		fail(fmt.Sprintf(errorFetchDataset, source, err), err)
+	}
+	manifest, err := release.Unpack(tee, staging)
+	if err == nil {
+		_, err = io.Copy(ioutil.Discard, tee)
+	}
+	if err == nil && len(checksum) != 0 && checksum != hex.EncodeToString(hash.Sum(nil)) {
+		err = fmt.Errorf("pack does not match its published checksum %s", checksum)
+	}
+	if err == nil && len(keys) != 0 {
+		var comment string
+		if comment, err = verify(source, manifest.Version, digest.Sum(nil), keys); err == nil {
+			fmt.Println(fmt.Sprintf(statusVerified, comment))
+		}
+	}
+	if err != nil {
+		os.RemoveAll(staging)
+		fail(fmt.Sprintf(errorFetchDataset, source, err), err)
+	}
+	if err = swap(staging, directory.Root); err != nil {
+		fail(fmt.Sprintf(errorFetchDataset, source, err), err)
+	}
+	if err = index.Remove(); err != nil {
+		fail(fmt.Sprintf(errorFetchDataset, source, err), err)
+	}
+	emojidata.Reset()
+	store.Reset()
+	fmt.Println(fmt.Sprintf(successFetchDataset, manifest.Version, len(manifest.Files), directory.Root))
+}
+
+// keygen writes a new key pair for signing packs to "<name>.pub" and "<name>.key", refusing to replace either.
+func keygen(arguments *arguments.Arguments) {
+	name := arguments.Get(0)
+	if len(name) == 0 {
+		name = "emojipedia"
+	}
+	public, private, err := release.GenerateKey(rand.Reader)
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotWrite, name, err), err)
+	}
+	files := []string{name + ".key", name + ".pub"}
+	for _, file := range files {
+		if _, err := os.Stat(file); err == nil {
+			failWith(usage, fmt.Sprintf(errorCannotWrite, file, "file exists"), os.ErrExist)
+		}
+	}
+	// The private key is only readable by its owner.
+	if err = ioutil.WriteFile(files[0], private.Encode(), 0600); err == nil {
+		err = ioutil.WriteFile(files[1], public.Encode(), 0644)
+	}
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotWrite, name, err), err)
+	}
+	fmt.Println(strings.Join(files, "\n"))
+}
+
+func packMain(arguments *arguments.Arguments) {
+	if command(arguments, KEYGEN) == KEYGEN {
+		keygen(arguments.Next())
+		exit(exitOK)
+	}
+	version, ok := arguments.Flag("version")
+	if ok == false {
+		version = "latest"
+		if metadata, err := pkg.Meta(); err == nil && len(metadata.Version) != 0 {
+			version = metadata.Version
+		}
+	}
+	var signer *release.PrivateKey
+	if path, ok := arguments.Flag("sign"); ok {
+		content, err := ioutil.ReadFile(path)
+		if err == nil {
+			signer, err = release.ParsePrivateKey(content)
+		}
+		if err != nil {
+			failWith(usage, fmt.Sprintf(errorCannotOpen, path, err), err)
+		}
+	}
+	if _, err := os.Stat(directory.Emoji); err != nil {
+		fail(fmt.Sprintf(errorCannotFind, strings.ToLower(EMOJIPEDIA)), err)
+	}
+	file := arguments.Get(0)
+	if len(file) == 0 || strings.HasPrefix(file, "--") {
+		file = release.Name(version)
+	}
+	writer, err := os.Create(file)
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotWrite, file, err), err)
+	}
+	var (
+		digest = sha512.New()
+		hash   = sha256.New()
+	)
+	manifest, err := release.Pack(io.MultiWriter(writer, hash, digest), directory.Root, version)
+	if closed := writer.Close(); err == nil {
+		err = closed
+	}
+	if err != nil {
+		os.Remove(file)
+		fail(fmt.Sprintf(errorCannotWrite, file, err), err)
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	err = ioutil.WriteFile(file+".sha256", []byte(fmt.Sprintf("%s  %s\n", checksum, filepath.Base(file))), 0644)
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotWrite, file+".sha256", err), err)
+	}
+	if signer != nil {
+		comment := fmt.Sprintf("file:%s version:%s timestamp:%v", filepath.Base(file), version, manifest.Created.Unix())
+		err = ioutil.WriteFile(file+release.Extension, signer.Sign(digest.Sum(nil), comment).Encode(), 0644)
+		if err != nil {
+			fail(fmt.Sprintf(errorCannotWrite, file+release.Extension, err), err)
+		}
+	}
+	fmt.Println(fmt.Sprintf(successPack, len(manifest.Files), file))
+	fmt.Println(checksum)
+}
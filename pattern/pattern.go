@@ -0,0 +1,102 @@
+package pattern
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/slice"
+)
+
+// Compile returns the Pattern of the expression. Globs match whole values, with * matching any run of characters,
+// ? any one character and [...] one character of a class. Regular expressions match anywhere in a value unless anchored.
+// Both are matched regardless of case.
+func Compile(expression string, regex bool) (*Pattern, error) {
+	if regex {
+		re, err := regexp.Compile("(?i)" + expression)
+		if err != nil {
+			return nil, err
+		}
+		return &Pattern{Expression: expression, re: re}, nil
+	}
+	glob := strings.ToLower(expression)
+	if _, err := path.Match(glob, ""); err != nil {
+		return nil, err
+	}
+	return &Pattern{Expression: expression, glob: glob}, nil
+}
+
+// CompileAll returns the Patterns of the expressions, failing on the first that does not compile.
+func CompileAll(expressions []string, regex bool) ([]*Pattern, error) {
+	patterns := []*Pattern{}
+	for _, expression := range expressions {
+		p, err := Compile(expression, regex)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+// Any returns whether any of the Patterns matches the value. No Patterns match every value.
+func Any(patterns []*Pattern, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if p.Match(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// AnyEmoji returns whether any of the Patterns matches the emoji.Emoji. No Patterns match every emoji.Emoji.
+func AnyEmoji(patterns []*Pattern, e *emoji.Emoji) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if p.Emoji(e) {
+			return true
+		}
+	}
+	return false
+}
+
+// Pattern is a compiled glob or regular expression that names, keywords and shortcodes are matched against.
+type Pattern struct {
+	Expression string
+	glob       string
+	re         *regexp.Regexp
+}
+
+// Emoji method returns whether the Pattern matches the name, one of the keywords or one of the shortcodes
+// of the emoji.Emoji. Shortcodes are matched without their colons.
+func (pointer *Pattern) Emoji(e *emoji.Emoji) bool {
+	if pointer.Match(e.Name) {
+		return true
+	}
+	for _, values := range []*slice.Slice{e.Keywords, e.Shortcodes} {
+		if values == nil {
+			continue
+		}
+		for _, i := range *values {
+			if value, ok := i.(string); ok && pointer.Match(strings.Trim(value, ":")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Match method returns whether the Pattern matches the value.
+func (pointer *Pattern) Match(value string) bool {
+	if pointer.re != nil {
+		return pointer.re.MatchString(value)
+	}
+	ok, _ := path.Match(pointer.glob, strings.ToLower(value))
+	return ok
+}
@@ -1,73 +1,263 @@
 package pkg
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"os"
 	"path/filepath"
-	"runtime"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/text"
 )
 
 const (
-	URL = "http://www.unicode.org/emoji/charts/emoji-list.html"
+	// Name is the source name the unicode-org chart is recorded by.
+	Name = "unicode"
+	URL  = "http://www.unicode.org/emoji/charts/emoji-list.html"
 )
 
 const (
-	dir    string = "emojipedia"
-	folder string = "unicode"
+	filename  string = "unicode.html"
+	metaname  string = "unicode.json"
+	versioned string = "https://unicode.org/emoji/charts-%s/emoji-list.html"
 )
 
 var (
-	_, b, _, _  = runtime.Caller(0)
-	root        = filepath.Dir(filepath.Dir(b))
-	storagepath = filepath.Join(root, fmt.Sprintf(".%s", dir), folder)
+	// Categories restricts builds to the emoji of the named categories (such as "smileys-and-emotion").
+	// Empty Categories build every emoji.
+	Categories []string
+	// Version pins the emoji version of the unicode-org chart (such as "15.1"). An empty Version tracks the live chart.
+	Version string
 )
 
+var (
+	versionPattern = regexp.MustCompile(`^\d+(\.\d+)?$`)
+)
+
+// Address returns the URL of the unicode-org chart of the pinned Version.
+func Address() string {
+	if len(Version) == 0 {
+		return URL
+	}
+	return fmt.Sprintf(versioned, Version)
+}
+
+// Partial returns whether builds are restricted to some Categories.
+func Partial() bool {
+	return len(Categories) != 0
+}
+
+// Selected returns whether the emoji of the category are built: every category is when no Categories are named.
+func Selected(category string) bool {
+	if len(Categories) == 0 {
+		return true
+	}
+	for _, c := range Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Unknown returns the Categories that name no category heading of the unicode-org chart in the document.
+func Unknown(document *goquery.Document) []string {
+	var (
+		headings = map[string]bool{}
+		unknown  = []string{}
+	)
+	document.Find("th.bighead a").Each(func(_ int, s *goquery.Selection) {
+		headings[text.Normalize(s.Text())] = true
+	})
+	for _, c := range Categories {
+		if headings[c] == false {
+			unknown = append(unknown, c)
+		}
+	}
+	return unknown
+}
+
+// Pin sets the Version, accepting a major emoji version ("15") or a major and minor version ("15.1").
+func Pin(version string) error {
+	if versionPattern.MatchString(version) == false {
+		return fmt.Errorf("pkg: unicode version \"%s\" is not of the form 15.1", version)
+	}
+	if strings.Contains(version, ".") == false {
+		version = version + ".0"
+	}
+	Version = version
+	return nil
+}
+
 func HTTP() (*http.Response, error) {
-	resp, err := http.Get(URL)
+	return client.Fetch(Address())
+}
+
+// HTTPIfChanged requests the unicode-org HTML only if it differs from the stored copy described by the metadata,
+// using its ETag and Last-Modified headers. A nil response means unicode.org reported the page as not modified.
+func HTTPIfChanged(metadata *Metadata) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, Address(), nil)
 	if err != nil {
 		return nil, err
 	}
+	if metadata != nil && len(metadata.ETag) != 0 {
+		req.Header.Set("If-None-Match", metadata.ETag)
+	}
+	if metadata != nil && len(metadata.LastModified) != 0 {
+		req.Header.Set("If-Modified-Since", metadata.LastModified)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		return nil, nil
+	}
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf(resp.Status)
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s", resp.Status)
 	}
 	return resp, nil
 }
 
 // Open attempts to open the unicode-org HTTP response from the emojipedia/unicode folder.
 func Open() (*goquery.Document, error) {
-	filepath := filepath.Join(storagepath, "unicode.html")
-	reader, err := os.Open(filepath)
+	reader, err := Reader()
 	if err != nil {
 		return nil, err
 	}
-	document, err := goquery.NewDocumentFromReader(reader)
+	defer reader.Close()
+	return goquery.NewDocumentFromReader(reader)
+}
+
+// Reader opens the unicode-org HTTP response from the emojipedia/unicode folder for streaming.
+// Dumps stored by earlier versions with their HTTP headers are migrated first.
+func Reader() (*os.File, error) {
+	err := Migrate()
 	if err != nil {
 		return nil, err
 	}
-	defer reader.Close()
-	return document, nil
+	reader, err := os.Open(filepath.Join(directory.Unicode, filename))
+	if err != nil {
+		return nil, store.Missing(err, store.ErrMissingUnicodeFile)
+	}
+	return reader, nil
 }
 
-// Write stores and unicode-org HTTP response to the dependencies folder.
+// Meta attempts to open the HTTP headers recorded alongside the unicode-org HTML.
+func Meta() (*Metadata, error) {
+	content, err := ioutil.ReadFile(filepath.Join(directory.Unicode, metaname))
+	if err != nil {
+		return nil, err
+	}
+	metadata := &Metadata{}
+	err = json.Unmarshal(content, metadata)
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// Fetched returns when the stored unicode-org HTML was requested. Metadata recorded before fetch times were kept
+// falls back to the Date header the HTML was served with, and a dump without metadata to the time the file was written.
+func Fetched() time.Time {
+	if metadata, err := Meta(); err == nil {
+		if metadata.Fetched.IsZero() == false {
+			return metadata.Fetched
+		}
+		if date, err := http.ParseTime(metadata.Header.Get("Date")); err == nil {
+			return date.UTC()
+		}
+	}
+	if info, err := os.Stat(filepath.Join(directory.Unicode, filename)); err == nil {
+		return info.ModTime().UTC()
+	}
+	return time.Time{}
+}
+
+// Migrate rewrites a unicode-org dump stored with its HTTP headers into the HTML body and a separate metadata file.
+// Dumps that hold only HTML are left untouched, as are dumps in a read-only dataset.
+func Migrate() error {
+	filepath := filepath.Join(directory.Unicode, filename)
+	content, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return store.Missing(err, store.ErrMissingUnicodeFile)
+	}
+	if !bytes.HasPrefix(content, []byte("HTTP/")) || store.Writable() != nil {
+		return nil
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(content)), nil)
+	if err != nil {
+		return err
+	}
+	return Write(resp)
+}
+
+// Write stores the body of the unicode-org HTTP response to the dependencies folder
+// and records its status and headers (including the ETag) in a separate metadata file.
 func Write(resp *http.Response) error {
-	err := os.MkdirAll(storagepath,  os.ModePerm)
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(directory.Unicode, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	meta, err := store.Marshal(&Metadata{
+		ETag:         resp.Header.Get("ETag"),
+		Fetched:      time.Now().UTC(),
+		Header:       resp.Header,
+		LastModified: resp.Header.Get("Last-Modified"),
+		Status:       resp.Status,
+		Version:      Version})
 	if err != nil {
 		return err
 	}
-	dump, err := httputil.DumpResponse(resp, true)
+	err = ioutil.WriteFile(filepath.Join(directory.Unicode, metaname), meta, os.ModePerm)
 	if err != nil {
 		return err
 	}
-	filepath := filepath.Join(storagepath, "unicode.html")
-	return ioutil.WriteFile(filepath, dump,  os.ModePerm)
+	return ioutil.WriteFile(filepath.Join(directory.Unicode, filename), body, os.ModePerm)
 }
 
 // Remove deletes the unicode-org data stored in the dependencies folder.
 func Remove() error {
-	return os.Remove(filepath.Join(storagepath, "unicode.html"))
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := store.Trash(filepath.Join(directory.Unicode, filename))
+	if err != nil {
+		return err
+	}
+	err = store.Trash(filepath.Join(directory.Unicode, metaname))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Metadata holds the HTTP status and headers the unicode-org HTML was served with and the Version it was pinned to.
+type Metadata struct {
+	ETag         string      `json:"etag"`
+	Fetched      time.Time   `json:"fetched"`
+	Header       http.Header `json:"header"`
+	LastModified string      `json:"lastModified"`
+	Status       string      `json:"status"`
+	Version      string      `json:"version,omitempty"`
 }
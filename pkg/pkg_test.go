@@ -0,0 +1,14 @@
+package pkg
+
+import "testing"
+
+// BenchmarkOpen parses the stored unicode.org chart into a document. Skipped until the unicode package is built.
+func BenchmarkOpen(b *testing.B) {
+	if _, err := Open(); err != nil {
+		b.Skip(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Open()
+	}
+}
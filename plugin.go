@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/directory"
+)
+
+const (
+	pluginPrefix string = "emojipedia-"
+)
+
+// plugins returns the commands the emojipedia-<command> executables on the PATH add, sorted by name.
+func plugins() []string {
+	var (
+		commands = []string{}
+		seen     = map[string]bool{}
+	)
+	for _, folder := range filepath.SplitList(os.Getenv("PATH")) {
+		files, err := ioutil.ReadDir(folder)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			name := file.Name()
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			executable := runtime.GOOS == "windows" || file.Mode()&0111 != 0
+			if !strings.HasPrefix(name, pluginPrefix) || file.IsDir() || executable == false {
+				continue
+			}
+			command := strings.TrimPrefix(name, pluginPrefix)
+			if len(command) != 0 && seen[command] == false {
+				seen[command] = true
+				commands = append(commands, command)
+			}
+		}
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+// plugin runs the emojipedia-<command> executable on the PATH for the command the first argument names,
+// handing it the remaining arguments and the storage folder of the active profile as EMOJIPEDIA_HOME.
+// The program exits with the exit code of the plugin. Returns when there is no such plugin
+// or when the argument is the exact name or alias of a built in command, which take precedence.
+// Plugins are looked up before the start of a command is resolved, so a plugin named "cat" is not
+// taken for the start of categories or category.
+func plugin(arguments *arguments.Arguments) {
+	command := arguments.Get(0)
+	if len(command) == 0 || strings.HasPrefix(command, "-") {
+		return
+	}
+	if _, ok := aliases[strings.ToUpper(command)]; ok {
+		return
+	}
+	for _, c := range commands {
+		if strings.EqualFold(c, command) {
+			return
+		}
+	}
+	path, err := exec.LookPath(pluginPrefix + strings.ToLower(command))
+	if err != nil {
+		return
+	}
+	args := []string{}
+	arguments.Next().Each(func(_ int, argument string) {
+		args = append(args, argument)
+	})
+	cmd := exec.Command(path, args...)
+	cmd.Env = append(os.Environ(), fmt.Sprintf("EMOJIPEDIA_HOME=%s", directory.Root))
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	err = cmd.Run()
+	if exitError, ok := err.(*exec.ExitError); ok {
+		exit(exitError.ExitCode())
+	}
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, path, err), err)
+	}
+	exit(exitOK)
+}
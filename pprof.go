@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+
+	"github.com/gellel/emojipedia/arguments"
+)
+
+// profiler writes the pprof profiles and execution trace of the command asked for by
+// --cpuprofile, --memprofile and --trace.
+func profiler(_ string, arguments *arguments.Arguments) func() {
+	if name, ok := arguments.Flag("cpuprofile"); ok {
+		file := createProfile(name)
+		if err := pprof.StartCPUProfile(file); err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, name, err), err)
+		}
+		cleanups = append(cleanups, func() {
+			pprof.StopCPUProfile()
+			file.Close()
+		})
+	}
+	if name, ok := arguments.Flag("memprofile"); ok {
+		file := createProfile(name)
+		cleanups = append(cleanups, func() {
+			runtime.GC()
+			pprof.WriteHeapProfile(file)
+			file.Close()
+		})
+	}
+	if name, ok := arguments.Flag("trace"); ok {
+		file := createProfile(name)
+		if err := trace.Start(file); err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, name, err), err)
+		}
+		cleanups = append(cleanups, func() {
+			trace.Stop()
+			file.Close()
+		})
+	}
+	return nil
+}
+
+func createProfile(name string) *os.File {
+	if len(name) == 0 {
+		failWith(usage, fmt.Sprintf(errorCannotFind, "profile file name"), ErrMissingArgument)
+	}
+	file, err := os.Create(name)
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, name, err), err)
+	}
+	return file
+}
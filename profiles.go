@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/manifest"
+)
+
+func profilesMain(arguments *arguments.Arguments) {
+	profiles, err := directory.Profiles()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "profiles", err), err)
+	}
+	fmt.Fprintln(writer, "Profile\t|Created\t|Updated\t|Packages")
+	for _, profile := range profiles {
+		directory.Use(profile)
+		m, err := manifest.Open()
+		if err != nil {
+			fmt.Fprintln(writer, fmt.Sprintf("%s\t|-\t|-\t|-", profile))
+			continue
+		}
+		fmt.Fprintln(writer, fmt.Sprintf("%s\t|%s\t|%s\t|%v", profile, m.Created.Format("2006-01-02"), m.Updated.Format("2006-01-02"), len(m.Packages)))
+	}
+	writer.Flush()
+}
@@ -0,0 +1,224 @@
+package release
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gellel/emojipedia/store"
+)
+
+const (
+	// Contents is the file closing every pack, listing the checksum of each file packed before it.
+	Contents string = "pack.json"
+	// URL is the address the pack of a dataset version is published at.
+	URL string = "https://github.com/gellel/emojipedia/releases/download/dataset-%s/%s"
+)
+
+var (
+	// Excluded are the entries of the storage folder that are never packed: the lock, trash and other profiles,
+	// the favorites, history and shell history of the user, interrupted builds and what is rebuilt or downloaded on demand.
+	Excluded = []string{".history", ".lock", ".trash", "checkpoint.json", "history.json", "history.json.lock", "images", "index.bin", "profiles", "renders", "user.json"}
+)
+
+// Address returns the URL the pack of the dataset version is published at.
+func Address(version string) string {
+	return fmt.Sprintf(URL, version, Name(version))
+}
+
+// Name returns the file name of the pack of the dataset version, such as "emojipedia-15.1.tar.gz".
+func Name(version string) string {
+	return fmt.Sprintf("emojipedia-%s.tar.gz", version)
+}
+
+// Pack writes every file of the root folder that is not Excluded to the writer as a gzipped tarball,
+// followed by the Manifest of the pack listing the SHA-256 checksum of each file.
+func Pack(writer io.Writer, root, version string) (*Manifest, error) {
+	var (
+		compressor = gzip.NewWriter(writer)
+		archive    = tar.NewWriter(compressor)
+		manifest   = &Manifest{Created: time.Now().UTC(), Files: map[string]string{}, Version: version}
+	)
+	err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(root, file)
+		if err != nil || relative == "." {
+			return err
+		}
+		relative = filepath.ToSlash(relative)
+		if excluded(relative) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() == false {
+			return nil
+		}
+		checksum, err := add(archive, file, relative, info)
+		if err != nil {
+			return err
+		}
+		manifest.Files[relative] = checksum
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	content, err := store.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+	header := &tar.Header{Mode: 0644, ModTime: manifest.Created, Name: Contents, Size: int64(len(content)), Typeflag: tar.TypeReg}
+	if err = archive.WriteHeader(header); err != nil {
+		return nil, err
+	}
+	if _, err = archive.Write(content); err != nil {
+		return nil, err
+	}
+	if err = archive.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, compressor.Close()
+}
+
+// Unpack extracts a pack read from the reader into the folder and checks every file against the Manifest of the pack.
+// Packs holding paths outside the folder, Excluded entries, files missing from the Manifest or files that do not match
+// their checksum are rejected, leaving whatever was extracted for the caller to remove.
+func Unpack(reader io.Reader, folder string) (*Manifest, error) {
+	decompressor, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	defer decompressor.Close()
+	var (
+		archive   = tar.NewReader(decompressor)
+		checksums = map[string]string{}
+		manifest  *Manifest
+	)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := path.Clean(header.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("release: pack holds \"%s\" outside the dataset", header.Name)
+		}
+		if excluded(name) {
+			return nil, fmt.Errorf("release: pack holds \"%s\", which is never packed", header.Name)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if name == Contents {
+			content, err := ioutil.ReadAll(archive)
+			if err != nil {
+				return nil, err
+			}
+			manifest = &Manifest{}
+			if err = store.Decode(Contents, content, manifest); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if checksums[name], err = extract(archive, filepath.Join(folder, filepath.FromSlash(name))); err != nil {
+			return nil, err
+		}
+	}
+	if manifest == nil {
+		return nil, fmt.Errorf("release: pack has no \"%s\"", Contents)
+	}
+	return manifest, manifest.Verify(checksums)
+}
+
+// add writes the file to the archive under its relative path and returns the checksum of its contents.
+func add(archive *tar.Writer, file, relative string, info os.FileInfo) (string, error) {
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return "", err
+	}
+	header.Name = relative
+	if err = archive.WriteHeader(header); err != nil {
+		return "", err
+	}
+	reader, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(archive, hash), reader); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// excluded checks whether the relative path is, or is within, an Excluded entry of the storage folder.
+func excluded(relative string) bool {
+	top := strings.Split(relative, "/")[0]
+	for _, name := range Excluded {
+		if top == name {
+			return true
+		}
+	}
+	return false
+}
+
+// extract writes the current file of the archive to the path and returns the checksum of its contents.
+func extract(archive io.Reader, file string) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+		return "", err
+	}
+	writer, err := os.Create(file)
+	if err != nil {
+		return "", err
+	}
+	defer writer.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(writer, hash), archive); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Manifest describes a pack: the dataset version it holds, when it was packed and the checksum of each file.
+type Manifest struct {
+	Created time.Time         `json:"created"`
+	Files   map[string]string `json:"files"`
+	Version string            `json:"version"`
+}
+
+// Verify method checks that the argument checksums, by the path of each extracted file,
+// are exactly the files of the Manifest.
+func (pointer *Manifest) Verify(checksums map[string]string) error {
+	for file, checksum := range checksums {
+		expected, ok := pointer.Files[file]
+		switch {
+		case ok == false:
+			return fmt.Errorf("release: pack holds \"%s\" which is not listed in \"%s\"", file, Contents)
+		case expected != checksum:
+			return fmt.Errorf("release: \"%s\" does not match its checksum", file)
+		}
+	}
+	for file := range pointer.Files {
+		if _, ok := checksums[file]; ok == false {
+			return fmt.Errorf("release: pack is missing \"%s\"", file)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package release
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// Extension is appended to the name of a pack to name its detached Signature.
+	Extension string = ".sig"
+)
+
+const (
+	trusted   string = "trusted comment: "
+	untrusted string = "untrusted comment: "
+)
+
+// GenerateKey creates an Ed25519 key pair from the random source, such as crypto/rand.Reader.
+func GenerateKey(random io.Reader) (*PublicKey, *PrivateKey, error) {
+	public, private, err := ed25519.GenerateKey(random)
+	if err != nil {
+		return nil, nil, err
+	}
+	id := identify(public)
+	return &PublicKey{ID: id, Key: public}, &PrivateKey{ID: id, Key: private}, nil
+}
+
+// ParsePublicKey reads a PublicKey written by the PublicKey Encode method. Keys whose ID is not the ID of the key itself are rejected.
+func ParsePublicKey(content []byte) (*PublicKey, error) {
+	value, _, err := decode(content, 8+ed25519.PublicKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("release: cannot read public key; %s", err)
+	}
+	key := &PublicKey{Key: ed25519.PublicKey(value[8:])}
+	copy(key.ID[:], value[:8])
+	if id := identify(key.Key); id != key.ID {
+		return nil, fmt.Errorf("release: public key is named %x but is key %x", key.ID, id)
+	}
+	return key, nil
+}
+
+// ParsePrivateKey reads a PrivateKey written by the PrivateKey Encode method.
+func ParsePrivateKey(content []byte) (*PrivateKey, error) {
+	value, _, err := decode(content, 8+ed25519.SeedSize)
+	if err != nil {
+		return nil, fmt.Errorf("release: cannot read private key; %s", err)
+	}
+	key := &PrivateKey{Key: ed25519.NewKeyFromSeed(value[8:])}
+	copy(key.ID[:], value[:8])
+	return key, nil
+}
+
+// ParseSignature reads a Signature written by the Signature Encode method.
+func ParseSignature(content []byte) (*Signature, error) {
+	value, lines, err := decode(content, 8+ed25519.SignatureSize)
+	if err != nil {
+		return nil, fmt.Errorf("release: cannot read signature; %s", err)
+	}
+	signature := &Signature{Value: value[8:]}
+	copy(signature.ID[:], value[:8])
+	for _, line := range lines {
+		if strings.HasPrefix(line, trusted) {
+			signature.Comment = strings.TrimPrefix(line, trusted)
+		}
+	}
+	return signature, nil
+}
+
+// decode returns the base64 value of the first line of the content that is not a comment, which must be of
+// the argument length, along with every line of the content.
+func decode(content []byte, length int) ([]byte, []string, error) {
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, untrusted) || strings.HasPrefix(line, trusted) {
+			continue
+		}
+		value, err := base64.StdEncoding.DecodeString(line)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(value) != length {
+			return nil, nil, fmt.Errorf("expected %v bytes but found %v", length, len(value))
+		}
+		return value, lines, nil
+	}
+	return nil, nil, fmt.Errorf("no key or signature found")
+}
+
+// encode writes the comment and base64 value lines of a key or signature file.
+func encode(comment string, values ...[]byte) []byte {
+	return []byte(fmt.Sprintf("%s%s\n%s\n", untrusted, comment, base64.StdEncoding.EncodeToString(bytes.Join(values, nil))))
+}
+
+// identify returns the key ID of the public key: the first eight bytes of its SHA-256 checksum.
+func identify(public ed25519.PublicKey) [8]byte {
+	var (
+		checksum = sha256.Sum256(public)
+		id       [8]byte
+	)
+	copy(id[:], checksum[:8])
+	return id
+}
+
+// message returns what a Signature signs: the digest of the pack followed by the trusted comment,
+// so the comment cannot be changed without invalidating the Signature.
+func message(digest []byte, comment string) []byte {
+	return append(append([]byte{}, digest...), comment...)
+}
+
+// PrivateKey is an Ed25519 key packs are signed with, along with the ID of its PublicKey.
+type PrivateKey struct {
+	ID  [8]byte
+	Key ed25519.PrivateKey
+}
+
+// Encode method returns the PrivateKey as the lines of a key file. The seed of the key is not encrypted,
+// so the file must be kept as secret as the key itself.
+func (pointer *PrivateKey) Encode() []byte {
+	return encode(fmt.Sprintf("emojipedia private key %x", pointer.ID), pointer.ID[:], pointer.Key.Seed())
+}
+
+// Sign method signs the digest of a pack along with the trusted comment, such as the name and version of the pack.
+func (pointer *PrivateKey) Sign(digest []byte, comment string) *Signature {
+	return &Signature{
+		Comment: comment,
+		ID:      pointer.ID,
+		Value:   ed25519.Sign(pointer.Key, message(digest, comment))}
+}
+
+// PublicKey is an Ed25519 key Signatures are verified with, identified by the first eight bytes of its checksum.
+type PublicKey struct {
+	ID  [8]byte
+	Key ed25519.PublicKey
+}
+
+// Encode method returns the PublicKey as the lines of a key file.
+func (pointer *PublicKey) Encode() []byte {
+	return encode(fmt.Sprintf("emojipedia public key %x", pointer.ID), pointer.ID[:], pointer.Key)
+}
+
+// Verify method checks that the Signature was made by the PrivateKey of the PublicKey over the digest of a pack
+// and the trusted comment of the Signature.
+func (pointer *PublicKey) Verify(digest []byte, signature *Signature) error {
+	if pointer.ID != signature.ID {
+		return fmt.Errorf("release: signed by key %x, not by key %x", signature.ID, pointer.ID)
+	}
+	if ed25519.Verify(pointer.Key, message(digest, signature.Comment), signature.Value) == false {
+		return fmt.Errorf("release: signature of key %x does not match the pack", pointer.ID)
+	}
+	return nil
+}
+
+// Signature is a detached signature of a pack: the ID of the key that made it, the signed value and the trusted comment.
+type Signature struct {
+	Comment string
+	ID      [8]byte
+	Value   []byte
+}
+
+// Encode method returns the Signature as the lines of a signature file.
+func (pointer *Signature) Encode() []byte {
+	content := encode(fmt.Sprintf("signature from emojipedia key %x", pointer.ID), pointer.ID[:], pointer.Value)
+	return append(content, fmt.Sprintf("%s%s\n", trusted, pointer.Comment)...)
+}
+
+// Key method returns the hex encoded ID of the key that made the Signature.
+func (pointer *Signature) Key() string {
+	return hex.EncodeToString(pointer.ID[:])
+}
@@ -4,10 +4,10 @@ import "fmt"
 
 func remove(name string, remover func() error) {
 	fmt.Println(fmt.Sprintf(statusRemovePackage, name))
+	lock()
 	err := remover()
 	if err != nil {
-		fmt.Println(fmt.Sprintf(errorRemovePackage, name, err))
-	} else {
-		fmt.Println(fmt.Sprintf(successRemovePackage, name))
+		fail(fmt.Sprintf(errorRemovePackage, name, err), err)
 	}
+	fmt.Println(fmt.Sprintf(successRemovePackage, name))
 }
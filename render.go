@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/images"
+	"github.com/gellel/emojipedia/joypixels"
+	"github.com/gellel/emojipedia/openmoji"
+	"github.com/gellel/emojipedia/render"
+	"github.com/gellel/emojipedia/store"
+)
+
+// renderMain renders the artwork of an emoji to a PNG file for each size given, 64 pixels by default. The artwork
+// of the sources named by --art is preferred in order, OpenMoji's SVG artwork first by default. Files are written
+// to the renders folder of the storage folder unless --out names another.
+func renderMain(arguments *arguments.Arguments) {
+	var (
+		emojipedia  = emojipedia.GetLocale(language)
+		name        = arguments.Get(0)
+		out         = directory.Renders
+		preferences = []string{openmoji.Name, joypixels.Name}
+		sizes       = []int{}
+	)
+	if value, ok := arguments.Flag("art"); ok {
+		preferences = strings.Split(value, ",")
+	}
+	if value, ok := arguments.Flag("out"); ok {
+		out = value
+	}
+	if len(name) == 0 || strings.HasPrefix(name, "--") {
+		failWith(usage, fmt.Sprintf(errorCannotFind, "emoji"), ErrMissingArgument)
+	}
+	e, ok := emojipedia.Get(name)
+	if ok == false {
+		notFound([]string{name}, RN, RENDER)
+	}
+	arguments.Next().Each(func(_ int, argument string) {
+		size, err := strconv.Atoi(argument)
+		if err != nil || size < render.Min || size > render.Max {
+			failWith(usage, fmt.Sprintf(errorRenderSize, argument, render.Min, render.Max), err)
+		}
+		sizes = append(sizes, size)
+	})
+	if len(sizes) == 0 {
+		sizes = append(sizes, 64)
+	}
+	art := e.Art(preferences...)
+	if len(art) == 0 {
+		failWith(missing, fmt.Sprintf(errorCannotFind, e.Name+" artwork"), ErrNotFound)
+	}
+	// Fetching the artwork writes the images index, so it is read and written under the dataset lock
+	// unless the dataset is read-only and only stored artwork can be rendered.
+	if store.Writable() == nil {
+		lock()
+	}
+	in, err := images.Fetch(e.ID, art)
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, art, err), err)
+	}
+	for _, size := range sizes {
+		filename := filepath.Join(out, fmt.Sprintf("%s-%v.png", e.Name, size))
+		if err := render.File(in, size, filename); err != nil {
+			fail(fmt.Sprintf(errorCannotWrite, filename, err), err)
+		}
+		fmt.Println(filename)
+	}
+}
@@ -0,0 +1,187 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Max is the largest size, in pixels, artwork is rendered at.
+	Max int = 512
+	// Min is the smallest size, in pixels, artwork is rendered at.
+	Min int = 16
+)
+
+var (
+	// ErrNoRasterizer is returned when SVG artwork is rendered but none of the Rasterizers is installed.
+	ErrNoRasterizer = errors.New("render: no svg rasterizer found on the PATH")
+	// ErrSize is returned for sizes outside Min and Max.
+	ErrSize = fmt.Errorf("render: size must be between %v and %v pixels", Min, Max)
+)
+
+// Rasterizer is a program on the PATH that renders an SVG file to a square PNG file.
+type Rasterizer struct {
+	Args func(size string, in, out string) []string
+	Name string
+}
+
+// Rasterizers are the programs SVG artwork is rendered with, the first installed being used.
+var Rasterizers = []Rasterizer{
+	{Name: "resvg", Args: func(size, in, out string) []string {
+		return []string{"-w", size, "-h", size, in, out}
+	}},
+	{Name: "rsvg-convert", Args: func(size, in, out string) []string {
+		return []string{"-w", size, "-h", size, "-o", out, in}
+	}},
+	{Name: "inkscape", Args: func(size, in, out string) []string {
+		return []string{"-w", size, "-h", size, "-o", out, in}
+	}}}
+
+// File renders the artwork file to a square PNG file of the argument size. SVG artwork is rasterized by the first
+// installed of the Rasterizers, and PNG, JPEG and GIF artwork is resampled.
+func File(in string, size int, out string) error {
+	if size < Min || size > Max {
+		return ErrSize
+	}
+	if err := os.MkdirAll(filepath.Dir(out), os.ModePerm); err != nil {
+		return err
+	}
+	if strings.EqualFold(filepath.Ext(in), ".svg") {
+		return SVG(in, size, out)
+	}
+	return Raster(in, size, out)
+}
+
+// Raster resamples a PNG, JPEG or GIF file to a square PNG file of the argument size.
+func Raster(in string, size int, out string) error {
+	reader, err := os.Open(in)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	src, _, err := image.Decode(reader)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return png.Encode(file, Scale(src, size))
+}
+
+// SVG rasterizes an SVG file to a square PNG file of the argument size with the first installed of the Rasterizers.
+func SVG(in string, size int, out string) error {
+	for _, r := range Rasterizers {
+		program, err := exec.LookPath(r.Name)
+		if err != nil {
+			continue
+		}
+		output, err := exec.Command(program, r.Args(strconv.Itoa(size), in, out)...).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("render: %s: %v %s", r.Name, err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+	return ErrNoRasterizer
+}
+
+// Scale resamples the image to a square of the argument size with bilinear filtering, keeping its aspect ratio
+// and centering it on a transparent background.
+func Scale(src image.Image, size int) image.Image {
+	var (
+		bounds = src.Bounds()
+		dst    = image.NewNRGBA(image.Rect(0, 0, size, size))
+		rgba   = image.NewNRGBA(bounds)
+		scale  = float64(size) / float64(larger(bounds.Dx(), bounds.Dy()))
+		width  = int(float64(bounds.Dx())*scale + 0.5)
+		height = int(float64(bounds.Dy())*scale + 0.5)
+		left   = (size - width) / 2
+		top    = (size - height) / 2
+	)
+	draw.Draw(rgba, bounds, src, bounds.Min, draw.Src)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			// The center of the destination pixel in source coordinates.
+			sx := (float64(x)+0.5)/scale - 0.5 + float64(bounds.Min.X)
+			sy := (float64(y)+0.5)/scale - 0.5 + float64(bounds.Min.Y)
+			dst.SetNRGBA(left+x, top+y, bilinear(rgba, sx, sy))
+		}
+	}
+	return dst
+}
+
+// bilinear returns the color of the image at fractional coordinates, weighting the four nearest pixels
+// by their premultiplied alpha so transparent pixels do not darken the edges.
+func bilinear(src *image.NRGBA, x, y float64) color.NRGBA {
+	var (
+		b      = src.Bounds()
+		x0     = clamp(int(floor(x)), b.Min.X, b.Max.X-1)
+		y0     = clamp(int(floor(y)), b.Min.Y, b.Max.Y-1)
+		x1     = clamp(x0+1, b.Min.X, b.Max.X-1)
+		y1     = clamp(y0+1, b.Min.Y, b.Max.Y-1)
+		fx     = x - floor(x)
+		fy     = y - floor(y)
+		sum    [4]float64
+		points = [4]struct {
+			x, y int
+			w    float64
+		}{{x0, y0, (1 - fx) * (1 - fy)}, {x1, y0, fx * (1 - fy)}, {x0, y1, (1 - fx) * fy}, {x1, y1, fx * fy}}
+	)
+	for _, p := range points {
+		c := src.NRGBAAt(p.x, p.y)
+		a := float64(c.A) * p.w
+		sum[0] += float64(c.R) * a
+		sum[1] += float64(c.G) * a
+		sum[2] += float64(c.B) * a
+		sum[3] += a
+	}
+	if sum[3] == 0 {
+		return color.NRGBA{}
+	}
+	return color.NRGBA{
+		R: uint8(sum[0]/sum[3] + 0.5),
+		G: uint8(sum[1]/sum[3] + 0.5),
+		B: uint8(sum[2]/sum[3] + 0.5),
+		A: uint8(sum[3] + 0.5)}
+}
+
+// clamp returns the value limited to the range of low and high.
+func clamp(value, low, high int) int {
+	if value < low {
+		return low
+	}
+	if value > high {
+		return high
+	}
+	return value
+}
+
+// floor returns the largest whole number not above the value.
+func floor(value float64) float64 {
+	f := float64(int(value))
+	if f > value {
+		f--
+	}
+	return f
+}
+
+// larger returns the larger of the values.
+func larger(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
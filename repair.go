@@ -0,0 +1,261 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/category"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/integrity"
+	"github.com/gellel/emojipedia/merge"
+	"github.com/gellel/emojipedia/pkg"
+	"github.com/gellel/emojipedia/slug"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/subcategory"
+)
+
+// repairEmoji regenerates stored emoji that cannot be decoded and restores emoji that categories and subcategories
+// reference but that are missing, using the emoji parsed from the stored unicode.org HTML.
+func repairEmoji(parsed *emojipedia.Emojipedia, report func(action, target, detail string)) {
+	files, _ := ioutil.ReadDir(directory.Emoji)
+	for _, file := range files {
+		content, err := ioutil.ReadFile(filepath.Join(directory.Emoji, file.Name()))
+		if err == nil {
+			_, err = emoji.Parse(&content)
+		}
+		if err == nil {
+			continue
+		}
+		var (
+			path = filepath.Join(directory.Emoji, file.Name())
+			stem = strings.TrimSuffix(file.Name(), ".json")
+		)
+		e, ok := parsed.Get(stem)
+		if !ok {
+			report("unrepairable", stem, err.Error())
+			continue
+		}
+		// The emoji is written before the corrupt file is trashed, which it usually replaces in place.
+		if err := emoji.Write(e); err != nil {
+			report("unrepairable", stem, err.Error())
+			continue
+		}
+		if content, readErr := ioutil.ReadFile(path); readErr == nil {
+			if _, parseErr := emoji.Parse(&content); parseErr != nil {
+				store.Trash(path)
+			}
+		}
+		report("regenerated", e.Name, err.Error())
+	}
+	restored := map[string]bool{}
+	for _, problem := range integrity.Problems() {
+		if problem.MissingKind != "emoji" || restored[problem.Missing] {
+			continue
+		}
+		restored[problem.Missing] = true
+		referrer := problem.Kind + " " + problem.Name
+		e, ok := parsed.Get(problem.Missing)
+		if !ok {
+			report("unrepairable", problem.Missing, "referenced by "+referrer+" but not found on unicode.org")
+			continue
+		}
+		if err := emoji.Write(e); err != nil {
+			report("unrepairable", problem.Missing, err.Error())
+			continue
+		}
+		report("restored", problem.Missing, "referenced by "+referrer)
+	}
+}
+
+// repairAliases migrates the emoji, categories and subcategories stored under names unicode has since renamed or split,
+// rewriting them with the current names they are given when they are read.
+func repairAliases(report func(action, target, detail string)) {
+	type names struct {
+		Category      string   `json:"category"`
+		Name          string   `json:"name"`
+		Subcategories []string `json:"subcategories"`
+		Subcategory   string   `json:"subcategory"`
+	}
+	stored := func(folder string, f func(former *names, content []byte)) {
+		files, _ := ioutil.ReadDir(folder)
+		for _, file := range files {
+			content, err := ioutil.ReadFile(filepath.Join(folder, file.Name()))
+			former := &names{}
+			if err == nil && json.Unmarshal(content, former) == nil && len(former.Name) != 0 {
+				f(former, content)
+			}
+		}
+	}
+	stored(directory.Emoji, func(former *names, content []byte) {
+		e, err := emoji.Parse(&content)
+		if err != nil || (e.Category == former.Category && e.Subcategory == former.Subcategory) {
+			return
+		}
+		if err = emoji.Write(e); err != nil {
+			report("unrepairable", e.Name, err.Error())
+			return
+		}
+		report("migrated", e.Name, fmt.Sprintf("%s %s is now %s %s", former.Category, former.Subcategory, e.Category, e.Subcategory))
+	})
+	stored(directory.Subcategory, func(former *names, content []byte) {
+		s, err := subcategory.Parse(&content)
+		if err != nil || (s.Name == former.Name && s.Category == former.Category) {
+			return
+		}
+		// The migrated subcategory is written before the former one is removed, so a failed write loses nothing.
+		err = subcategory.Write(s)
+		if err == nil && s.Name != former.Name {
+			err = subcategory.Remove(former.Name)
+		}
+		if err != nil {
+			report("unrepairable", former.Name, err.Error())
+			return
+		}
+		report("migrated", s.Name, fmt.Sprintf("%s %s is now %s %s", former.Category, former.Name, s.Category, s.Name))
+	})
+	stored(directory.Category, func(former *names, content []byte) {
+		c, err := category.Parse(&content)
+		if err != nil {
+			return
+		}
+		shares := category.Split(c)
+		if len(shares) == 1 && shares[0].Name == former.Name && (c.Subcategories == nil || c.Subcategories.Join(" ") == strings.Join(former.Subcategories, " ")) {
+			return
+		}
+		// A divided category is written as its shares, joining the categories they went to when those are stored.
+		migrated := []string{}
+		for _, share := range shares {
+			if err == nil {
+				if held, openErr := category.Open(share.Name); openErr == nil && held.Name == share.Name {
+					share = held.Union(share)
+				}
+				err = category.Write(share)
+				migrated = append(migrated, share.Name)
+			}
+		}
+		if err == nil && (len(shares) != 1 || shares[0].Name != former.Name) {
+			err = category.Remove(former.Name)
+		}
+		if err != nil {
+			report("unrepairable", former.Name, err.Error())
+			return
+		}
+		report("migrated", strings.Join(migrated, " "), fmt.Sprintf("category %s is now %s", former.Name, strings.Join(migrated, " and ")))
+	})
+}
+
+// repairIndexes rebuilds the mapping files of the emoji, category and subcategory folders.
+func repairIndexes(report func(action, target, detail string)) {
+	folders := map[string]func(content []byte) (string, bool){
+		directory.Category: func(content []byte) (string, bool) {
+			c, err := category.Parse(&content)
+			if err != nil {
+				return "", false
+			}
+			return c.Name, true
+		},
+		directory.Emoji: func(content []byte) (string, bool) {
+			e, err := emoji.Parse(&content)
+			if err != nil {
+				return "", false
+			}
+			if store.ByID {
+				return e.ID, true
+			}
+			return e.Name, true
+		},
+		directory.Subcategory: func(content []byte) (string, bool) {
+			s, err := subcategory.Parse(&content)
+			if err != nil {
+				return "", false
+			}
+			return s.Name, true
+		}}
+	for _, folder := range []string{directory.Category, directory.Emoji, directory.Subcategory} {
+		if _, err := os.Stat(folder); os.IsNotExist(err) {
+			continue
+		}
+		n, err := store.Reindex(folder, folders[folder])
+		if err != nil {
+			report("unrepairable", filepath.Base(folder)+" index", err.Error())
+			continue
+		}
+		report("reindexed", filepath.Base(folder), fmt.Sprintf("%v mapped filenames", n))
+	}
+}
+
+// repairDescriptions fetches the descriptions of every stored emoji that does not have one. The emojipedia.org
+// slugs of the emoji are recorded first, when they have not been, so each emoji page is found.
+func repairDescriptions(report func(action, target, detail string)) {
+	local, err := emojipedia.Open()
+	if err != nil {
+		report("unrepairable", EMOJIPEDIA, err.Error())
+		return
+	}
+	if slugs, err := slug.Open(); err == nil && slugs.Len() == 0 {
+		repairSlugs(report)
+	}
+	local.Each(func(_ string, e *emoji.Emoji) {
+		if len(e.Description) != 0 && e.Description != "NIL" {
+			return
+		}
+		description, err := emoji.Describe(e)
+		if err != nil || len(description) == 0 {
+			return
+		}
+		e.SetDescription(description).Record("description", merge.Emojipedia, time.Now())
+		if err := emoji.Write(e); err != nil {
+			report("unrepairable", e.Name, err.Error())
+			return
+		}
+		report("described", e.Name, "fetched description")
+	})
+}
+
+// repairSlugs records the emojipedia.org slug of every emoji linked from its category index pages.
+func repairSlugs(report func(action, target, detail string)) {
+	slugs, err := slug.Build()
+	if err == nil {
+		err = slug.Write(slugs)
+	}
+	if err != nil {
+		report("unrepairable", "slugs", err.Error())
+		return
+	}
+	slug.Reset()
+	report("indexed", "slugs", fmt.Sprintf("%v emoji pages", slugs.Len()))
+}
+
+func repairMain(arguments *arguments.Arguments) {
+	var (
+		fixed  = 0
+		report = func(action, target, detail string) {
+			if action != "unrepairable" {
+				fixed++
+			}
+			fmt.Fprintln(writer, fmt.Sprintf("%s\t|%s\t|%s", action, target, detail))
+		}
+	)
+	fmt.Fprintln(writer, "Action\t|Target\t|Detail")
+	document, err := pkg.Open()
+	if err != nil {
+		report("unrepairable", EMOJIPEDIA, err.Error())
+	} else {
+		repairEmoji(emojipedia.Parse(document), report)
+	}
+	repairAliases(report)
+	repairIndexes(report)
+	if strings.ToUpper(arguments.Get(0)) == D || strings.ToUpper(arguments.Get(0)) == DESCRIPTION {
+		repairDescriptions(report)
+	}
+	writer.Flush()
+	fmt.Println(fmt.Sprintf("repaired %v entries", fixed))
+}
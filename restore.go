@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/index"
+	"github.com/gellel/emojipedia/store"
+)
+
+func restoreMain(arguments *arguments.Arguments) {
+	restored, err := store.Restore()
+	if len(restored) != 0 {
+		index.Remove()
+	}
+	for _, path := range restored {
+		fmt.Println(path)
+	}
+	if os.IsNotExist(err) {
+		fmt.Println("trash is empty. nothing to restore.")
+		exit(exitOK)
+	}
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "trash", err), err)
+	}
+	fmt.Println(fmt.Sprintf("success! restored %v files from the trash", len(restored)))
+}
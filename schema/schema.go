@@ -0,0 +1,105 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gellel/emojipedia/lexicon"
+	"github.com/gellel/emojipedia/slice"
+)
+
+const (
+	// Draft is the JSON Schema dialect the generated schemas declare.
+	Draft string = "http://json-schema.org/draft-07/schema#"
+)
+
+var (
+	lexiconType = reflect.TypeOf(lexicon.Lexicon{})
+	sliceType   = reflect.TypeOf(slice.Slice{})
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// Schema is a JSON Schema document.
+type Schema map[string]interface{}
+
+// Of generates the JSON Schema describing how the argument value is stored.
+// Struct fields are read from their json tags and are required unless tagged omitempty or held by pointer,
+// which are optional and may be null.
+// The Slice and Lexicon collections hold names and translations, so their members are described as strings.
+func Of(title string, v interface{}) Schema {
+	schema := of(reflect.TypeOf(v))
+	schema["$schema"] = Draft
+	schema["title"] = title
+	return schema
+}
+
+func of(t reflect.Type) Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == sliceType:
+		return Schema{"type": "array", "items": Schema{"type": "string"}}
+	case t == lexiconType:
+		return Schema{"type": "object", "additionalProperties": Schema{"type": "string"}}
+	case t == timeType:
+		return Schema{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return Schema{"type": "array", "items": of(t.Elem())}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": of(t.Elem())}
+	case reflect.Struct:
+		var (
+			properties = Schema{}
+			required   = []string{}
+		)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := strings.Split(field.Tag.Get("json"), ",")
+			if field.PkgPath != "" || tag[0] == "-" {
+				continue
+			}
+			name := field.Name
+			if len(tag[0]) != 0 {
+				name = tag[0]
+			}
+			properties[name] = of(field.Type)
+			if field.Type.Kind() == reflect.Ptr || omitempty(tag) {
+				properties[name] = nullable(properties[name].(Schema))
+				continue
+			}
+			required = append(required, name)
+		}
+		return Schema{"type": "object", "properties": properties, "required": required}
+	}
+	return Schema{}
+}
+
+// nullable allows null in place of the values of the schema.
+func nullable(schema Schema) Schema {
+	if t, ok := schema["type"].(string); ok {
+		schema["type"] = []string{t, "null"}
+	}
+	return schema
+}
+
+// omitempty checks whether the options of a json tag include omitempty.
+func omitempty(tag []string) bool {
+	for _, option := range tag[1:] {
+		if option == "omitempty" {
+			return true
+		}
+	}
+	return false
+}
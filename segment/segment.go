@@ -0,0 +1,168 @@
+package segment
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/gellel/emojipedia/emojidata"
+)
+
+const (
+	zwj rune = '\u200D'
+)
+
+var (
+	wide = [][2]rune{
+		{'\u1100', '\u115F'},
+		{'\u231A', '\u231B'},
+		{'\u2E80', '\u303E'},
+		{'\u3041', '\u33FF'},
+		{'\u3400', '\u4DBF'},
+		{'\u4E00', '\u9FFF'},
+		{'\uA000', '\uA4CF'},
+		{'\uAC00', '\uD7A3'},
+		{'\uF900', '\uFAFF'},
+		{'\uFE30', '\uFE4F'},
+		{'\uFF00', '\uFF60'},
+		{'\uFFE0', '\uFFE6'},
+		{'\U0001F004', '\U0001F004'},
+		{'\U0001F300', '\U0001F64F'},
+		{'\U0001F680', '\U0001F6FF'},
+		{'\U0001F900', '\U0001F9FF'},
+		{'\U0001FA70', '\U0001FAFF'},
+		{'\U00020000', '\U0003FFFD'}}
+)
+
+// Cluster is a user-perceived character: one or more runes that render as a single glyph.
+type Cluster struct {
+	Start int    // Start is the byte offset of the Cluster in the source string.
+	End   int    // End is the byte offset following the Cluster in the source string.
+	Text  string // Text is the substring held by the Cluster.
+}
+
+// Segment splits the argument string into grapheme clusters, keeping ZWJ sequences,
+// skin tone modifiers, variation selectors, keycaps, tag sequences and regional indicator flags together.
+func Segment(s string) []Cluster {
+	var (
+		clusters  = []Cluster{}
+		previous  rune
+		regionals int
+		start     int
+	)
+	for i, r := range s {
+		if i != 0 && boundary(previous, r, regionals) {
+			clusters = append(clusters, Cluster{Start: start, End: i, Text: s[start:i]})
+			start, regionals = i, 0
+		}
+		if isRegional(r) {
+			regionals++
+		}
+		previous = r
+	}
+	if start < len(s) {
+		clusters = append(clusters, Cluster{Start: start, End: len(s), Text: s[start:]})
+	}
+	return clusters
+}
+
+// Count returns the number of grapheme clusters in the argument string.
+func Count(s string) int {
+	return len(Segment(s))
+}
+
+// Truncate shortens the argument string to at most n grapheme clusters without splitting a cluster.
+func Truncate(s string, n int) string {
+	clusters := Segment(s)
+	if n <= 0 {
+		return ""
+	}
+	if n >= len(clusters) {
+		return s
+	}
+	return s[:clusters[n-1].End]
+}
+
+// boundary reports whether a cluster break exists between runes a and b.
+// regionals counts the regional indicators already held by the current cluster.
+func boundary(a, b rune, regionals int) bool {
+	switch {
+	case a == '\r' && b == '\n':
+		return false
+	case a == '\r' || a == '\n' || b == '\r' || b == '\n':
+		return true
+	case isExtend(b) || b == zwj:
+		return false
+	case a == zwj:
+		return false
+	case isRegional(a) && isRegional(b):
+		return regionals%2 == 0
+	}
+	return true
+}
+
+func isExtend(r rune) bool {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true
+	case r >= '\uFE00' && r <= '\uFE0F':
+		return true
+	case r >= '\U0001F3FB' && r <= '\U0001F3FF':
+		return true
+	case r >= '\U000E0020' && r <= '\U000E007F':
+		return true
+	}
+	return false
+}
+
+func isRegional(r rune) bool {
+	return r >= '\U0001F1E6' && r <= '\U0001F1FF'
+}
+
+// Width returns the number of terminal columns the argument string occupies.
+// Emoji clusters (ZWJ sequences, flags, keycaps, modified and VS16-qualified emoji) occupy two columns,
+// VS15-qualified clusters and narrow characters occupy one and control characters and lone marks occupy none.
+// ZWJ sequences that are not recommended for general interchange are displayed as their parts and occupy their width.
+func Width(s string) int {
+	width := 0
+	for _, cluster := range Segment(s) {
+		width = width + clusterWidth(cluster.Text)
+	}
+	return width
+}
+
+func clusterWidth(s string) int {
+	runes := []rune(s)
+	r := runes[0]
+	switch {
+	case unicode.IsControl(r) || unicode.In(r, unicode.Mn, unicode.Me):
+		return 0
+	case strings.ContainsRune(s, emojidata.TextSelector):
+		return 1
+	case strings.ContainsRune(s, zwj) && emojidata.IsRGI(s) == false:
+		width := 0
+		for _, part := range strings.Split(s, string(zwj)) {
+			if len(part) != 0 {
+				width = width + clusterWidth(part)
+			}
+		}
+		return width
+	case strings.ContainsRune(s, emojidata.EmojiSelector) || strings.ContainsRune(s, zwj) || isRegional(r):
+		return 2
+	case len(runes) > 1 && runes[1] >= '\U0001F3FB' && runes[1] <= '\U0001F3FF':
+		return 2
+	case emojidata.IsEmojiPresentation(s) || isWide(r):
+		return 2
+	}
+	return 1
+}
+
+// isWide reports whether the rune sits in a block rendered double-width by terminals,
+// covering the pictographic emoji blocks and the East Asian wide and fullwidth ranges.
+func isWide(r rune) bool {
+	for _, x := range wide {
+		if r >= x[0] && r <= x[1] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package sentiment
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/lexicon"
+)
+
+const (
+	// Name is the source name sentiment is recorded by.
+	Name = "sentiment"
+	// URL is the Emoji Sentiment Ranking (Kralj Novak et al., 2015) published as CSV.
+	URL = "http://kt.ijs.si/data/Emoji_sentiment_ranking/Emoji_Sentiment_Data_v1.0.csv"
+)
+
+// New instantiates a new Sentiment pointer from raw occurrence counts.
+func New(negative, neutral, positive int) *Sentiment {
+	return &Sentiment{
+		Negative:    negative,
+		Neutral:     neutral,
+		Occurrences: negative + neutral + positive,
+		Positive:    positive}
+}
+
+// Open attempts to read a sentiment lexicon from a local file or, if the argument is a URL, over HTTP.
+func Open(path string) (*lexicon.Lexicon, error) {
+	reader, err := client.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return Parse(reader)
+}
+
+// Parse reads an Emoji Sentiment Ranking formatted CSV into a lexicon.Lexicon of Sentiment pointers keyed by emoji character.
+// Expects the columns: emoji, codepoint, occurrences, position, negative, neutral, positive.
+func Parse(reader io.Reader) (*lexicon.Lexicon, error) {
+	records, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	lexicon := &lexicon.Lexicon{}
+	for i, record := range records {
+		if i == 0 || len(record) < 7 {
+			continue
+		}
+		counts := []int{}
+		for _, field := range record[4:7] {
+			n, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", i+1, err)
+			}
+			counts = append(counts, n)
+		}
+		lexicon.Add(record[0], New(counts[0], counts[1], counts[2]))
+	}
+	return lexicon, nil
+}
+
+// Sentiment holds how often an emoji occurred in negative, neutral and positive contexts.
+type Sentiment struct {
+	Negative    int `json:"negative"`
+	Neutral     int `json:"neutral"`
+	Occurrences int `json:"occurrences"`
+	Positive    int `json:"positive"`
+}
+
+// Add method merges the counts of the argument Sentiment into the Sentiment.
+func (pointer *Sentiment) Add(sentiment *Sentiment) *Sentiment {
+	pointer.Negative = pointer.Negative + sentiment.Negative
+	pointer.Neutral = pointer.Neutral + sentiment.Neutral
+	pointer.Occurrences = pointer.Occurrences + sentiment.Occurrences
+	pointer.Positive = pointer.Positive + sentiment.Positive
+	return pointer
+}
+
+// Label method classifies the Sentiment as "positive", "negative" or "neutral" by its Score.
+func (pointer *Sentiment) Label() string {
+	switch score := pointer.Score(); {
+	case score > 0.05:
+		return "positive"
+	case score < -0.05:
+		return "negative"
+	}
+	return "neutral"
+}
+
+// Score method returns the sentiment score between -1 (negative) and 1 (positive).
+func (pointer *Sentiment) Score() float64 {
+	if pointer.Occurrences == 0 {
+		return 0
+	}
+	return float64(pointer.Positive-pointer.Negative) / float64(pointer.Occurrences)
+}
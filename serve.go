@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/confusable"
+	"github.com/gellel/emojipedia/discord"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/escape"
+	"github.com/gellel/emojipedia/slack"
+)
+
+func serveMain(arguments *arguments.Arguments) {
+	address := arguments.Get(0)
+	if len(address) == 0 {
+		address = ":8080"
+	}
+	mux := http.NewServeMux()
+	emojipedia := emojipedia.GetLocale(language)
+	mux.Handle(confusable.Path, confusable.Handler())
+	mux.Handle(discord.Path, discord.Handler(emojipedia))
+	mux.Handle(escape.Path, escape.Handler(emojipedia))
+	mux.Handle(slack.Path, slack.Handler(emojipedia))
+	fmt.Println(fmt.Sprintf(statusServe, address))
+	if err := http.ListenAndServe(address, mux); err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, address, err), err)
+	}
+}
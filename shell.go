@@ -0,0 +1,381 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gellel/emojipedia/analyzer"
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/history"
+	"github.com/gellel/emojipedia/locale"
+	"github.com/gellel/emojipedia/store"
+)
+
+const (
+	historyfile string = ".history"
+)
+
+const (
+	// listed is the most completions tab lists below the prompt.
+	listed int = 50
+)
+
+// session is the state the shell keeps between the commands run at its prompt.
+type session struct {
+	history []string
+	ids     map[string]string
+	names   []string
+	path    string
+}
+
+// shellMain reads commands from a prompt until it is closed or told to exit, running each in the same process
+// so the locale and profile chosen at the prompt are kept between them, and the dataset is read once and kept
+// in memory until it changes. Commands are remembered in a history file beside the default dataset.
+func shellMain(arguments *arguments.Arguments) {
+	if interactive {
+		fmt.Println("already in the shell.")
+		return
+	}
+	// The cleanups of the shell itself, such as stopping its profile, run once the shell is left
+	// rather than after the first command run at its prompt.
+	outer := cleanups
+	cleanups, interactive = []func(){}, true
+	emojipedia.Stamp = stamp
+	defer func() {
+		cleanups, interactive = outer, false
+		emojipedia.Stamp = nil
+	}()
+	s := &session{path: filepath.Join(directory.Root, historyfile)}
+	if content, err := ioutil.ReadFile(s.path); err == nil {
+		s.history = strings.Split(strings.TrimSpace(string(content)), "\n")
+	}
+	fmt.Println("emojipedia shell. type help for the commands, exit to leave.")
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		line, ok := s.read(reader)
+		if ok == false {
+			fmt.Println()
+			return
+		}
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		line, ok = s.recall(line)
+		if ok == false {
+			fmt.Println(fmt.Sprintf(errorChoiceNotFound, line, "!n", "!!"))
+			continue
+		}
+		s.remember(line)
+		fields := strings.Fields(line)
+		switch strings.ToLower(fields[0]) {
+		case "exit", "quit":
+			return
+		case "help":
+			fmt.Fprintln(writer, "  complete <text>\tlist the commands and emoji names starting with the text (or press tab)")
+			fmt.Fprintln(writer, "  history\tlist the commands run at the prompt; rerun one with !n or the last with !! (-hi lists the emoji used)")
+			fmt.Fprintln(writer, "  locale [code]\tshow or change the locale of the commands that follow")
+			fmt.Fprintln(writer, "  profile [name]\tshow or change the profile of the commands that follow (default for none)")
+			fmt.Fprintln(writer, "  exit\tleave the shell")
+			fmt.Fprintln(writer, "  <command>\trun any emojipedia command without the program name, such as \"categories list\" (--locale and --profile apply to it alone)")
+			writer.Flush()
+		case "complete":
+			for _, completion := range s.complete(strings.Join(fields[1:], " ")) {
+				fmt.Println(completion)
+			}
+		case "history":
+			for i, line := range s.history {
+				fmt.Println(fmt.Sprintf("%5d  %s", i+1, line))
+			}
+		case "locale":
+			if len(fields) > 1 {
+				tag, err := locale.Tag(fields[1])
+				if err != nil {
+					fmt.Println(err.Error())
+					continue
+				}
+				language = tag
+				analyzer.Use(language)
+			}
+			fmt.Println(language)
+		case "profile":
+			if len(fields) > 1 {
+				name := fields[1]
+				if name == "default" {
+					name = ""
+				}
+				directory.Use(name)
+				s.names = nil
+			}
+			fmt.Println(directory.Root)
+		default:
+			execute(fields)
+		}
+	}
+}
+
+// execute runs a command of the shell, returning the code it exited with.
+// The --locale and --profile flags of the command apply to it alone.
+func execute(fields []string) (code int) {
+	var (
+		arguments = arguments.NewArguments(fields)
+		profile   = directory.Profile
+		tag       = language
+	)
+	defer func() {
+		directory.Use(profile)
+		language = tag
+		analyzer.Use(language)
+	}()
+	defer func() {
+		r := recover()
+		cleanup()
+		switch r := r.(type) {
+		case nil:
+		case exited:
+			code = int(r)
+		case error:
+			c, hint := classify(r)
+			report(c, r.Error(), hint, r)
+			code = c.Exit
+		default:
+			panic(r)
+		}
+	}()
+	if code, ok := arguments.Flag("locale"); ok {
+		tag, err := locale.Tag(code)
+		if err != nil {
+			failWith(usage, err.Error(), err)
+		}
+		analyzer.Use(tag)
+		language = tag
+	}
+	if name, ok := arguments.Flag("profile"); ok {
+		directory.Use(name)
+	}
+	dispatch(arguments)
+	return exitOK
+}
+
+// complete returns the commands, or the names of the emoji of the active profile, that start with the argument text.
+func (pointer *session) complete(text string) []string {
+	text = strings.ToLower(text)
+	completions := []string{}
+	for _, command := range commands {
+		if command = strings.ToLower(command); strings.HasPrefix(command, text) {
+			completions = append(completions, command)
+		}
+	}
+	if pointer.names == nil {
+		pointer.names = []string{}
+		if e, err := emojipedia.OpenLocale(language); err == nil {
+			e.Keys().Each(func(_ int, i interface{}) {
+				pointer.names = append(pointer.names, i.(string))
+			})
+			sort.Strings(pointer.names)
+			pointer.ids = map[string]string{}
+			e.Each(func(ID string, x *emoji.Emoji) {
+				pointer.ids[x.Name] = ID
+			})
+		}
+	}
+	// The emoji used often and recently are completed first.
+	var (
+		now    = time.Now().UTC()
+		recent = history.New()
+	)
+	if h, err := history.Open(); err == nil {
+		recent = h
+	}
+	names := append([]string{}, pointer.names...)
+	sort.SliceStable(names, func(i, j int) bool {
+		return recent.Frecency(pointer.ids[names[i]], now) > recent.Frecency(pointer.ids[names[j]], now)
+	})
+	for _, name := range names {
+		if strings.HasPrefix(name, text) {
+			completions = append(completions, name)
+		}
+	}
+	return completions
+}
+
+// read prints the prompt and reads a line. On a terminal tab completes the word before the cursor, the up and down
+// arrows recall the history, backspace erases and ctrl-c clears the line. Returns false once stdin is closed
+// or ctrl-d is typed on an empty line.
+func (pointer *session) read(reader *bufio.Reader) (string, bool) {
+	fmt.Print(pointer.prompt())
+	restore, ok := raw()
+	if ok == false {
+		line, err := reader.ReadString('\n')
+		return strings.TrimRight(line, "\r\n"), err == nil || len(line) != 0
+	}
+	defer restore()
+	var (
+		line     = []rune{}
+		recalled = len(pointer.history)
+	)
+	redraw := func() {
+		fmt.Print("\r\033[K", pointer.prompt(), string(line))
+	}
+	for {
+		r, _, err := reader.ReadRune()
+		if err != nil {
+			return "", false
+		}
+		switch r {
+		case '\r', '\n':
+			fmt.Print("\r\n")
+			return string(line), true
+		case 3:
+			fmt.Print("^C\r\n")
+			line = []rune{}
+			redraw()
+		case 4:
+			if len(line) == 0 {
+				return "", false
+			}
+		case 8, 127:
+			if len(line) != 0 {
+				line = line[:len(line)-1]
+				redraw()
+			}
+		case '\t':
+			line = pointer.tab(line)
+			redraw()
+		case 27:
+			if b, _ := reader.ReadByte(); b != '[' {
+				continue
+			}
+			switch b, _ := reader.ReadByte(); {
+			case b == 'A' && recalled > 0:
+				recalled--
+			case b == 'B' && recalled < len(pointer.history):
+				recalled++
+			default:
+				continue
+			}
+			line = []rune{}
+			if recalled < len(pointer.history) {
+				line = []rune(pointer.history[recalled])
+			}
+			redraw()
+		default:
+			if unicode.IsPrint(r) {
+				line = append(line, r)
+				fmt.Print(string(r))
+			}
+		}
+	}
+}
+
+// tab completes the last word of the line. A single completion replaces the word; several are listed below
+// the prompt and the word is extended to the start they share.
+func (pointer *session) tab(line []rune) []rune {
+	var (
+		text        = string(line)
+		start       = strings.LastIndex(text, " ") + 1
+		completions = pointer.complete(text[start:])
+	)
+	switch len(completions) {
+	case 0:
+		return line
+	case 1:
+		return []rune(text[:start] + completions[0] + " ")
+	}
+	shared := []rune(completions[0])
+	for _, completion := range completions[1:] {
+		for strings.HasPrefix(completion, string(shared)) == false {
+			shared = shared[:len(shared)-1]
+		}
+	}
+	fmt.Print("\r\n")
+	if len(completions) > listed {
+		completions = append(completions[:listed:listed], fmt.Sprintf("(%v more)", len(completions)-listed))
+	}
+	fmt.Print(strings.Join(completions, "  "), "\r\n")
+	return append([]rune(text[:start]), shared...)
+}
+
+// raw puts the terminal of stdin in raw mode with stty so the shell reads every key as it is typed, returning
+// a function restoring its mode. Returns false when stdin is not a terminal or stty cannot change it.
+func raw() (func(), bool) {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil, false
+	}
+	stty := func(args ...string) ([]byte, error) {
+		cmd := exec.Command("stty", args...)
+		cmd.Stdin = os.Stdin
+		return cmd.Output()
+	}
+	mode, err := stty("-g")
+	if err != nil {
+		return nil, false
+	}
+	if _, err = stty("raw", "-echo"); err != nil {
+		return nil, false
+	}
+	return func() {
+		stty(strings.TrimSpace(string(mode)))
+	}, true
+}
+
+// prompt returns the prompt of the shell, naming the locale and profile when they are set.
+func (pointer *session) prompt() string {
+	state := []string{}
+	if len(directory.Profile) != 0 {
+		state = append(state, directory.Profile)
+	}
+	if len(language) != 0 {
+		state = append(state, language)
+	}
+	if len(state) == 0 {
+		return "emojipedia> "
+	}
+	return fmt.Sprintf("emojipedia[%s]> ", strings.Join(state, " "))
+}
+
+// recall replaces "!!" with the last command and "!n" with the nth command of the history.
+func (pointer *session) recall(line string) (string, bool) {
+	if strings.HasPrefix(line, "!") == false {
+		return line, true
+	}
+	n := len(pointer.history)
+	if line != "!!" {
+		i, err := strconv.Atoi(strings.TrimPrefix(line, "!"))
+		if err != nil {
+			return line, false
+		}
+		n = i
+	}
+	if n < 1 || n > len(pointer.history) {
+		return line, false
+	}
+	return pointer.history[n-1], true
+}
+
+// remember adds the line to the history, appending it to the history file when the storage folder exists.
+func (pointer *session) remember(line string) {
+	pointer.history = append(pointer.history, line)
+	if store.Writable() != nil {
+		return
+	}
+	file, err := os.OpenFile(pointer.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	fmt.Fprintln(file, line)
+}
@@ -0,0 +1,119 @@
+package slack
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/text"
+)
+
+const (
+	// Path is the route slash-commands are served from.
+	Path string = "/slack/command"
+)
+
+var (
+	// Secret is the Slack app signing secret used to verify requests. Defaults to the SLACK_SIGNING_SECRET environment variable.
+	Secret = os.Getenv("SLACK_SIGNING_SECRET")
+	// Tolerance is how old a request timestamp may be before it is rejected as a replay.
+	Tolerance = 5 * time.Minute
+)
+
+// Handler answers slash-commands such as "/emoji taco" with the emoji found for the command text.
+func Handler(emojipedia *emojipedia.Emojipedia) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !Verify(r.Header, body, Secret, time.Now()) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		content, err := store.Marshal(Message(emojipedia, form.Get("text")))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(content)
+	})
+}
+
+// Lookup finds the Emoji best matching a search term: an emoji ID or name, a pasted emoji character or a keyword.
+func Lookup(emojipedia *emojipedia.Emojipedia, term string) (*emoji.Emoji, bool) {
+	results := emojipedia.Search(term)
+	if results.Len() == 0 {
+		return nil, false
+	}
+	return results.Fetch(0).(*emoji.Emoji), true
+}
+
+// Message builds the Slack response describing the Emoji found for the search term.
+func Message(emojipedia *emojipedia.Emojipedia, term string) *Response {
+	e, ok := Lookup(emojipedia, term)
+	if !ok {
+		return &Response{
+			ResponseType: "ephemeral",
+			Text:         fmt.Sprintf("no emoji found for \"%s\"", strings.TrimSpace(term))}
+	}
+	description := emoji.AltText(e, "")
+	if len(e.Description) != 0 && e.Description != "NIL" {
+		description = e.Description
+	}
+	return &Response{
+		ResponseType: "in_channel",
+		Text:         fmt.Sprintf("%s `%s` %s", text.Emojize(e.Unicode), Shortcode(e), description)}
+}
+
+// Shortcode returns the colon-wrapped shortcode chat services use for the Emoji, such as ":red_heart:".
+func Shortcode(e *emoji.Emoji) string {
+	return ":" + strings.Replace(e.Name, "-", "_", -1) + ":"
+}
+
+// Verify checks the X-Slack-Signature of a request body against the signing secret,
+// rejecting requests whose X-Slack-Request-Timestamp is outside the Tolerance of now.
+func Verify(header http.Header, body []byte, secret string, now time.Time) bool {
+	if len(secret) == 0 {
+		return false
+	}
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || math.Abs(now.Sub(time.Unix(seconds, 0)).Seconds()) > Tolerance.Seconds() {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(bytes.Join([][]byte{[]byte("v0"), []byte(timestamp), body}, []byte(":")))
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature")))
+}
+
+// Response is the JSON body Slack expects in reply to a slash-command.
+type Response struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
@@ -1,6 +1,7 @@
 package slice
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -28,11 +29,21 @@ func New(values ...interface{}) *Slice {
 	return (&Slice{}).Assign(values...)
 }
 
+// Strings instantiates a new Slice pointer holding the argument strings, in order.
+func Strings(values ...string) *Slice {
+	slice := &Slice{}
+	for _, value := range values {
+		slice.Append(value)
+	}
+	return slice
+}
+
 type slice interface {
 	Append(value interface{}) *Slice
 	Assign(values ...interface{}) *Slice
 	Bounds(i int) bool
 	Concatenate(slice *Slice) *Slice
+	Copy() *Slice
 	Each(f func(i int, value interface{})) *Slice
 	Fetch(i int) interface{}
 	Get(i int) (interface{}, bool)
@@ -43,6 +54,7 @@ type slice interface {
 	Poll() interface{}
 	Pop() interface{}
 	Replace(i int, value interface{}) bool
+	Union(slice *Slice) *Slice
 }
 
 // Slice is a list-like object whose methods are used to perform traversal and mutation operations.
@@ -74,6 +86,15 @@ func (pointer *Slice) Concatenate(slice *Slice) *Slice {
 	return pointer
 }
 
+// Copy method returns a new Slice holding the elements of the Slice. A nil Slice is copied as nil.
+func (pointer *Slice) Copy() *Slice {
+	if pointer == nil {
+		return nil
+	}
+	slice := new(*pointer...)
+	return &slice
+}
+
 // Each method executes a provided function once for each Slice element.
 func (pointer *Slice) Each(f func(i int, value interface{})) *Slice {
 	for i, value := range *pointer {
@@ -127,6 +148,14 @@ func (pointer *Slice) Less(i, j int) bool {
 	return ok
 }
 
+// MarshalJSON encodes the Slice as a plain JSON array. A nil Slice is encoded as an empty array.
+func (pointer *Slice) MarshalJSON() ([]byte, error) {
+	if pointer == nil || *pointer == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]interface{}(*pointer))
+}
+
 // Map method executes a provided function once for each Slice elements and sets the returned value to the current index.
 func (pointer *Slice) Map(f func(i int, value interface{}) interface{}) *Slice {
 	for i, value := range *pointer {
@@ -212,6 +241,35 @@ func (pointer *Slice) Sort() *Slice {
 	return pointer
 }
 
+// Union method appends each element of the argument Slice that the Slice does not already hold and returns the modified Slice.
+// Elements must be comparable.
+func (pointer *Slice) Union(slice *Slice) *Slice {
+	held := map[interface{}]bool{}
+	for _, value := range *pointer {
+		held[value] = true
+	}
+	if slice != nil {
+		for _, value := range *slice {
+			if held[value] == false {
+				held[value] = true
+				(*pointer) = append(*pointer, value)
+			}
+		}
+	}
+	return pointer
+}
+
+// UnmarshalJSON decodes a plain JSON array into the Slice, replacing its contents.
+func (pointer *Slice) UnmarshalJSON(content []byte) error {
+	values := []interface{}{}
+	err := json.Unmarshal(content, &values)
+	if err != nil {
+		return err
+	}
+	(*pointer) = new(values...)
+	return nil
+}
+
 // Swap moves element i to j and j to i.
 func (pointer *Slice) Swap(i int, j int) {
 	s := *pointer
@@ -0,0 +1,215 @@
+package slug
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emojidata"
+	"github.com/gellel/emojipedia/store"
+)
+
+const (
+	// URL is the address of emojipedia.org, under which every emoji page is named by its slug.
+	URL = "https://emojipedia.org/"
+)
+
+var (
+	// Pages are the category index pages of emojipedia.org linking to the page of every emoji.
+	Pages = []string{"activity", "flags", "food-drink", "nature", "objects", "people", "smileys", "symbols", "travel-places"}
+)
+
+var (
+	once  sync.Once
+	slugs *Slugs
+)
+
+// New instantiates a new empty Slugs pointer.
+func New() *Slugs {
+	return &Slugs{Characters: map[string]string{}}
+}
+
+// Build records the slug of every emoji linked from the category index Pages of emojipedia.org.
+func Build() (*Slugs, error) {
+	links, err := Crawl()
+	if err != nil {
+		return nil, err
+	}
+	slugs := New()
+	for _, link := range links {
+		slugs.Add(link.Character, link.Slug)
+	}
+	return slugs, nil
+}
+
+// Crawl requests the category index Pages of emojipedia.org and returns the emoji each links to, in the order
+// they are listed. Pages that cannot be requested are skipped; Crawl fails only when no page could be read.
+func Crawl() ([]*Link, error) {
+	var (
+		err   error
+		links = []*Link{}
+		pages = map[string]bool{}
+		read  int
+	)
+	for _, page := range Pages {
+		pages[page] = true
+	}
+	for _, page := range Pages {
+		var document *goquery.Document
+		if document, err = request(URL + page + "/"); err != nil {
+			continue
+		}
+		read++
+		document.Find("a[href]").Each(func(_ int, selection *goquery.Selection) {
+			href, _ := selection.Attr("href")
+			fields := strings.Fields(selection.Text())
+			if len(fields) == 0 {
+				return
+			}
+			if slug, ok := slugOf(href); ok && pages[slug] == false && emoji(fields[0]) {
+				links = append(links, &Link{Character: fields[0], Name: strings.Join(fields[1:], " "), Page: page, Slug: slug})
+			}
+		})
+	}
+	if read == 0 {
+		return nil, err
+	}
+	return links, nil
+}
+
+// Open attempts to open the Slugs of the active profile. A profile without Slugs opens empty Slugs.
+func Open() (*Slugs, error) {
+	content, err := ioutil.ReadFile(directory.Slugs)
+	if os.IsNotExist(err) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	slugs := New()
+	err = store.Decode(directory.Slugs, content, slugs)
+	if err != nil {
+		return nil, err
+	}
+	return slugs, nil
+}
+
+// Remove deletes the Slugs stored in the active profile.
+func Remove() error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	return store.Trash(directory.Slugs)
+}
+
+// Reset discards the loaded Slugs so the next URLs are resolved from the stored file again.
+func Reset() {
+	once, slugs = sync.Once{}, nil
+}
+
+// URLs returns the emojipedia.org pages that may describe the emoji character of the name, most likely first:
+// the page its slug was recorded for, the page named after it and the page of the character itself,
+// which emojipedia.org redirects to the emoji page.
+func URLs(character, name string) []string {
+	once.Do(func() {
+		s, err := Open()
+		if err != nil {
+			s = New()
+		}
+		slugs = s
+	})
+	urls := []string{}
+	if slug, ok := slugs.Get(character); ok && slug != name {
+		urls = append(urls, URL+slug+"/")
+	}
+	return append(urls, URL+name+"/", URL+url.PathEscape(emojidata.Normalize(character))+"/")
+}
+
+// Write stores the Slugs in the active profile.
+func Write(slugs *Slugs) error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(filepath.Dir(directory.Slugs), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	content, err := store.Marshal(slugs)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(directory.Slugs, content, os.ModePerm)
+}
+
+// emoji checks whether the text of a link begins with an emoji rather than a word, digit or symbol.
+func emoji(s string) bool {
+	for _, r := range s {
+		return r > 0xFF && emojidata.IsEmoji(r)
+	}
+	return false
+}
+
+// request fetches and parses an emojipedia.org page.
+func request(address string) (*goquery.Document, error) {
+	resp, err := client.Get(address)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+	return goquery.NewDocumentFromReader(resp.Body)
+}
+
+// slugOf returns the last segment of the path of an emojipedia.org link.
+// Links to other hosts or with a query are not emoji pages.
+func slugOf(href string) (string, bool) {
+	u, err := url.Parse(href)
+	if err != nil || (len(u.Host) != 0 && strings.HasSuffix(u.Host, "emojipedia.org") == false) || len(u.RawQuery) != 0 {
+		return "", false
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	slug := segments[len(segments)-1]
+	return slug, len(slug) != 0
+}
+
+// Link is an emoji linked from a category index page of emojipedia.org: its character, the name it is
+// listed by, the page it was listed on and the slug of its own page.
+type Link struct {
+	Character string
+	Name      string
+	Page      string
+	Slug      string
+}
+
+// Slugs records the emojipedia.org slug of each emoji character as linked from the category index pages,
+// which often differs from the name unicode.org gives the emoji.
+type Slugs struct {
+	Characters map[string]string `json:"characters"`
+}
+
+// Add method records the slug of the emoji character. Variation selectors are ignored.
+func (pointer *Slugs) Add(character, slug string) *Slugs {
+	pointer.Characters[emojidata.Normalize(character)] = slug
+	return pointer
+}
+
+// Get method returns the slug of the emoji character and a boolean indicating if it was found.
+func (pointer *Slugs) Get(character string) (string, bool) {
+	slug, ok := pointer.Characters[emojidata.Normalize(character)]
+	return slug, ok
+}
+
+// Len method returns the number of emoji characters with a recorded slug.
+func (pointer *Slugs) Len() int {
+	return len(pointer.Characters)
+}
@@ -0,0 +1,136 @@
+package snippet
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/text"
+)
+
+// New creates the Snippets of an emoji.Emoji: one for each of its shortcodes and one for its name,
+// unless the name is also a shortcode. Keywords are wrapped in colons, as in ":taco:".
+func New(e *emoji.Emoji) []*Snippet {
+	var (
+		character = text.Emojize(e.Unicode)
+		keywords  = []string{}
+		snippets  = []*Snippet{}
+	)
+	if e.Shortcodes != nil {
+		e.Shortcodes.Each(func(_ int, i interface{}) {
+			keywords = append(keywords, i.(string))
+		})
+	}
+	if contains(keywords, e.Name) == false {
+		keywords = append(keywords, e.Name)
+	}
+	for _, keyword := range keywords {
+		snippets = append(snippets, &Snippet{Keyword: fmt.Sprintf(":%s:", keyword), Name: e.Name, Text: character})
+	}
+	return snippets
+}
+
+// Alfred writes the Snippets as an Alfred snippet collection, a zip archive holding a json file for each Snippet
+// that Alfred imports when it is saved with the .alfredsnippets extension.
+func Alfred(writer io.Writer, snippets []*Snippet) error {
+	archive := zip.NewWriter(writer)
+	for _, s := range snippets {
+		uid := s.UID()
+		file, err := archive.Create(uid + ".json")
+		if err != nil {
+			return err
+		}
+		err = json.NewEncoder(file).Encode(map[string]interface{}{
+			"alfredsnippet": map[string]string{
+				"keyword": s.Keyword,
+				"name":    fmt.Sprintf("%s %s", s.Text, s.Name),
+				"snippet": s.Text,
+				"uid":     uid}})
+		if err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// Espanso writes the Snippets as an espanso match file, to save in the match folder of the espanso configuration.
+func Espanso(writer io.Writer, snippets []*Snippet) error {
+	_, err := fmt.Fprintln(writer, "# generated by emojipedia; regenerate rather than edit\nmatches:")
+	if err != nil {
+		return err
+	}
+	for _, s := range snippets {
+		// JSON strings are valid YAML double-quoted scalars, escapes included.
+		trigger, _ := json.Marshal(s.Keyword)
+		replace, _ := json.Marshal(s.Text)
+		_, err := fmt.Fprintf(writer, "  - trigger: %s\n    replace: %s\n", trigger, replace)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Raycast writes the Snippets as the json array Raycast imports snippets from.
+func Raycast(writer io.Writer, snippets []*Snippet) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(snippets)
+}
+
+// VSCode writes the emoji.Emoji as a VS Code snippets file, to save as a .code-snippets file in the snippets folder
+// of the user or a project. Each emoji.Emoji is completed from its shortcodes and name, wrapped in colons, and its keywords.
+func VSCode(writer io.Writer, emoji []*emoji.Emoji) error {
+	type completion struct {
+		Body        string   `json:"body"`
+		Description string   `json:"description"`
+		Prefix      []string `json:"prefix"`
+	}
+	completions := map[string]*completion{}
+	for _, e := range emoji {
+		c := &completion{Body: text.Emojize(e.Unicode), Description: e.Name, Prefix: []string{}}
+		for _, s := range New(e) {
+			c.Prefix = append(c.Prefix, s.Keyword)
+		}
+		if e.Keywords != nil {
+			e.Keywords.Each(func(_ int, i interface{}) {
+				if contains(c.Prefix, i.(string)) == false {
+					c.Prefix = append(c.Prefix, i.(string))
+				}
+			})
+		}
+		completions[e.Name] = c
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(completions)
+}
+
+// Snippet expands a keyword typed by the user to the character of an emoji.
+type Snippet struct {
+	Keyword string `json:"keyword"`
+	Name    string `json:"name"`
+	Text    string `json:"text"`
+}
+
+// UID method returns a stable identifier of the Snippet formatted as a UUID, so regenerated collections
+// replace the Snippets imported before rather than duplicating them.
+func (pointer *Snippet) UID() string {
+	sum := sha1.Sum([]byte(pointer.Keyword))
+	return fmt.Sprintf("%X-%X-%X-%X-%X", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// contains returns whether the strings hold the argument string.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gellel/emojipedia/category"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/emojipediaorg"
+	"github.com/gellel/emojipedia/joypixels"
+	"github.com/gellel/emojipedia/lexicon"
+	"github.com/gellel/emojipedia/merge"
+	"github.com/gellel/emojipedia/openmoji"
+	"github.com/gellel/emojipedia/slug"
+	"github.com/gellel/emojipedia/source"
+	"github.com/gellel/emojipedia/subcategory"
+)
+
+// sourced checks whether the named source was selected with --source.
+func sourced(name string) bool {
+	for _, source := range sources {
+		if source == name {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeSources stores the OpenMoji and JoyPixels metadata selected with --source under the emoji.Emoji.Sources of the stored emoji.
+func mergeSources() {
+	emojipedia, err := emojipedia.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, EMOJIPEDIA, err), err)
+	}
+	var (
+		changed   = map[string]*emoji.Emoji{}
+		importers = []struct {
+			name string
+			open func(path string) (*lexicon.Lexicon, error)
+			url  string
+		}{
+			{openmoji.Name, openmoji.Open, openmoji.URL},
+			{joypixels.Name, joypixels.Open, joypixels.URL}}
+	)
+	for _, importer := range importers {
+		if sourced(strings.ToUpper(importer.name)) == false {
+			continue
+		}
+		metadata, err := importer.open(importer.url)
+		if err != nil {
+			fail(fmt.Sprintf(errorCannotOpen, importer.url, err), err)
+		}
+		var (
+			fetched = time.Now()
+			n       = 0
+		)
+		metadata.Each(func(ID string, i interface{}) {
+			if e, ok := emojipedia.Get(ID); ok {
+				if e.Sources == nil {
+					e.Sources = map[string]*source.Source{}
+				}
+				e.Sources[importer.name] = i.(*source.Source)
+				e.Record("sources", importer.name, fetched)
+				changed[e.ID] = e
+				n++
+			}
+		})
+		fmt.Println(fmt.Sprintf("merged %s metadata into %v emoji", importer.name, n))
+	}
+	for _, e := range changed {
+		if err := emoji.Write(e); err != nil {
+			fail(fmt.Sprintf(errorBuildPackage, EMOJIPEDIA, err), err)
+		}
+	}
+}
+
+// mergeEmojipediaOrg adds the emoji listed by the category index pages of emojipedia.org that the stored emoji do not
+// hold, such as newly announced emoji, merged by the configured precedence with emojipedia.org consulted last.
+// Each is filed under the category and subcategory of the emoji listed before it and numbered after the stored emoji.
+func mergeEmojipediaOrg() {
+	links, err := emojipediaorg.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, slug.URL, err), err)
+	}
+	current, err := emojipedia.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, EMOJIPEDIA, err), err)
+	}
+	var (
+		fetched   = time.Now()
+		n         = 0
+		neighbour *emoji.Emoji
+		order     = precedence.Fallback(merge.Emojipedia)
+		page      string
+	)
+	for _, link := range links {
+		if link.Page != page {
+			neighbour, page = nil, link.Page
+		}
+		candidate, err := emojipediaorg.New(link, current.Len()+1)
+		if err != nil {
+			continue
+		}
+		if e, ok := current.Get(candidate.ID); ok {
+			neighbour = e
+			continue
+		}
+		if neighbour == nil {
+			continue
+		}
+		candidate.SetCategory(neighbour.Category).SetSubcategory(neighbour.Subcategory).Stamp(emojipediaorg.Name, fetched)
+		e := merge.Emoji(order, map[string]*emoji.Emoji{merge.Emojipedia: candidate})
+		if err := e.Validate(); err != nil {
+			fmt.Println(fmt.Sprintf(errorSkipped, link.Character, err))
+			continue
+		}
+		if err := emoji.Write(e); err != nil {
+			fail(fmt.Sprintf(errorBuildPackage, EMOJIPEDIA, err), err)
+		}
+		if c, err := category.Open(e.Category); err == nil {
+			if err := category.Write(c.SetEmoji(c.Emoji.Append(e.Name))); err != nil {
+				fail(fmt.Sprintf(errorBuildPackage, CATEGORY, err), err)
+			}
+		}
+		if s, err := subcategory.Open(e.Subcategory); err == nil {
+			if err := subcategory.Write(s.SetEmoji(s.Emoji.Append(e.Name))); err != nil {
+				fail(fmt.Sprintf(errorBuildPackage, SUBCATEGORY, err), err)
+			}
+		}
+		current.Add(e)
+		n++
+	}
+	fmt.Println(fmt.Sprintf("merged %v emoji listed by emojipedia.org", n))
+}
@@ -0,0 +1,37 @@
+package source
+
+import (
+	"strings"
+
+	"github.com/gellel/emojipedia/slice"
+)
+
+// New instantiates a new empty Source pointer.
+func New() *Source {
+	return &Source{
+		Skintones: &slice.Slice{},
+		Tags:      &slice.Slice{}}
+}
+
+// ID returns the stable emoji ID of a hyphen or space separated hexadecimal codepoint sequence (such as "1F44B-1F3FB"):
+// its code points as lowercase hexadecimal joined by hyphens, with variation selectors removed.
+func ID(hexcode string) string {
+	codes := []string{}
+	for _, code := range strings.FieldsFunc(strings.ToLower(hexcode), func(r rune) bool { return r == '-' || r == ' ' || r == '_' }) {
+		code = strings.TrimLeft(strings.TrimPrefix(code, "u+"), "0")
+		if code == "fe0e" || code == "fe0f" || len(code) == 0 {
+			continue
+		}
+		codes = append(codes, code)
+	}
+	return strings.Join(codes, "-")
+}
+
+// Source holds the metadata an emoji art and annotation ecosystem (such as OpenMoji or JoyPixels) publishes for one emoji.
+type Source struct {
+	Annotation string       `json:"annotation"`
+	Art        string       `json:"art"`
+	Group      string       `json:"group"`
+	Skintones  *slice.Slice `json:"skintones"`
+	Tags       *slice.Slice `json:"tags"`
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/fileinfo"
+	"github.com/gellel/emojipedia/manifest"
+	"github.com/gellel/emojipedia/pkg"
+)
+
+// age returns a duration in the largest whole unit it fills, from minutes up to days.
+func age(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%vd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%vh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%vm", int(d.Minutes()))
+}
+
+func statusMain(arguments *arguments.Arguments) {
+	var (
+		now     = time.Now()
+		version = "latest"
+	)
+	if metadata, err := pkg.Meta(); err != nil {
+		version = "-"
+	} else if len(metadata.Version) != 0 {
+		version = metadata.Version
+	}
+	fmt.Fprintln(writer, "Dataset\t|Present\t|Size\t|Files\t|Age\t|Version\t|Checksum")
+	for _, dataset := range datasets() {
+		var (
+			name  = strings.ToLower(dataset.name)
+			state = manifest.Inspect(dataset.name, dataset.path)
+		)
+		info, err := fileinfo.New(dataset.path)
+		if state.Exists == false || err != nil {
+			fmt.Fprintln(writer, fmt.Sprintf("%s\t|no\t|-\t|-\t|-\t|-\t|-", name))
+			continue
+		}
+		fmt.Fprintln(writer, fmt.Sprintf("%s\t|yes\t|%s\t|%v\t|%s\t|%s\t|%s", name, info.Format(), info.Files, age(state.Age(now)), version, info.Checksum[:12]))
+	}
+	writer.Flush()
+}
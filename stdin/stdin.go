@@ -1,16 +1,51 @@
 package stdin
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 )
 
+// Arg describes an option of a command for its usage: what it does, its short and verbose names,
+// the positional arguments it takes, an example of its use, the flags it reads and the packages it needs built.
 type Arg struct {
-	About   string
-	Short   string
-	Verbose string
+	About    string   `json:"about"`
+	Args     string   `json:"args,omitempty"`
+	Example  string   `json:"example,omitempty"`
+	Flags    []string `json:"flags,omitempty"`
+	Requires []string `json:"requires,omitempty"`
+	Short    string   `json:"short"`
+	Verbose  string   `json:"verbose"`
+}
+
+// MarshalJSON method returns the Arg as json, its short and verbose names lower-cased as they are typed.
+func (arg Arg) MarshalJSON() ([]byte, error) {
+	type usage Arg
+	var (
+		buffer  bytes.Buffer
+		encoder = json.NewEncoder(&buffer)
+	)
+	encoder.SetEscapeHTML(false)
+	arg.Short, arg.Verbose = strings.ToLower(arg.Short), strings.ToLower(arg.Verbose)
+	err := encoder.Encode(usage(arg))
+	return bytes.TrimSpace(buffer.Bytes()), err
 }
 
 func (arg Arg) String() string {
-	return fmt.Sprintf("%s\t%s", strings.ToLower(fmt.Sprintf("  [%s %s]", arg.Short, arg.Verbose)), arg.About)
+	usage := strings.ToLower(fmt.Sprintf("  [%s %s]", arg.Short, arg.Verbose))
+	if len(arg.Args) != 0 {
+		usage = fmt.Sprintf("%s %s", usage, arg.Args)
+	}
+	for _, flag := range arg.Flags {
+		usage = fmt.Sprintf("%s [--%s]", usage, flag)
+	}
+	about := arg.About
+	if len(arg.Example) != 0 {
+		about = fmt.Sprintf("%s (%s)", about, arg.Example)
+	}
+	if len(arg.Requires) != 0 {
+		about = fmt.Sprintf("%s; needs %s", about, strings.Join(arg.Requires, ", "))
+	}
+	return fmt.Sprintf("%s\t%s", usage, about)
 }
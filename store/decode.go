@@ -0,0 +1,87 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	errTrailing = errors.New("invalid data after top-level value")
+)
+
+var (
+	// Strict rejects stored documents holding fields the program does not know about,
+	// such as hand-edited files or files written by a different version of the program.
+	// Emoji, categories and subcategories that fail their Validate method are not written while it is set.
+	Strict bool
+	// Reject is called with the Invalid error of every document that is not written in Strict mode, so the row
+	// it was built from can be reported while the rest of the build goes on. It may be called from several goroutines.
+	Reject = func(err error) {}
+)
+
+// Decode unmarshals the JSON content read from the named file into the argument value.
+// Decoding errors are returned as a *FieldError naming the field that failed. When Strict is set, unknown fields are errors.
+func Decode(file string, content []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	if Strict {
+		decoder.DisallowUnknownFields()
+	}
+	err := decoder.Decode(v)
+	if err == nil {
+		// json.Unmarshal rejects anything but whitespace after the value, and so does Decode.
+		if _, err = decoder.Token(); err == io.EOF {
+			return nil
+		}
+		return &FieldError{Err: errTrailing, Field: fmt.Sprintf("offset %v", decoder.InputOffset()), File: file}
+	}
+	fieldError := &FieldError{Err: err, File: file}
+	switch e := err.(type) {
+	case *json.UnmarshalTypeError:
+		fieldError.Field = e.Field
+	case *json.SyntaxError:
+		fieldError.Field = fmt.Sprintf("offset %v", e.Offset)
+	default:
+		if message := err.Error(); strings.HasPrefix(message, "json: unknown field ") {
+			fieldError.Field = strings.Trim(strings.TrimPrefix(message, "json: unknown field "), "\"")
+		}
+	}
+	return fieldError
+}
+
+// Unmarshal is Decode for content that was not read from a file.
+func Unmarshal(content []byte, v interface{}) error {
+	return Decode("", content, v)
+}
+
+// FieldError reports the stored document and field that could not be decoded.
+type FieldError struct {
+	Err   error
+	Field string
+	File  string
+}
+
+// Error describes the FieldError.
+func (pointer *FieldError) Error() string {
+	message := "store"
+	if len(pointer.File) != 0 {
+		message = fmt.Sprintf("%s: %s", message, pointer.File)
+	}
+	if len(pointer.Field) != 0 {
+		message = fmt.Sprintf("%s: field \"%s\"", message, pointer.Field)
+	}
+	return fmt.Sprintf("%s: %s", message, pointer.Err)
+}
+
+// Is reports whether the target is ErrCorruptJSON, which every FieldError is.
+func (pointer *FieldError) Is(target error) bool {
+	return target == ErrCorruptJSON
+}
+
+// Unwrap returns the decoding error that caused the FieldError.
+func (pointer *FieldError) Unwrap() error {
+	return pointer.Err
+}
@@ -0,0 +1,225 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var (
+	hostile  = "<>:\"/\\|?*%"
+	indexes  = map[string]map[string]string{}
+	mutex    = sync.Mutex{}
+	owners   = map[string]map[string]string{}
+	reserved = map[string]bool{"con": true, "prn": true, "aux": true, "nul": true}
+)
+
+func init() {
+	for i := 1; i < 10; i++ {
+		reserved[fmt.Sprintf("com%d", i)] = true
+		reserved[fmt.Sprintf("lpt%d", i)] = true
+	}
+}
+
+// Sanitize converts a key into a filename stem that is valid on Windows, macOS and Linux.
+// Path separators, characters reserved by Windows, control characters and "%" are percent-encoded,
+// trailing dots and spaces are encoded and device names such as "con" gain a trailing underscore.
+// Distinct keys always produce distinct stems before case folding.
+func Sanitize(key string) string {
+	b := strings.Builder{}
+	for _, r := range key {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(hostile, r) {
+			b.WriteString(fmt.Sprintf("%%%02X", r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	s := b.String()
+	for strings.HasSuffix(s, ".") || strings.HasSuffix(s, " ") {
+		s = s[:len(s)-1] + fmt.Sprintf("%%%02X", s[len(s)-1])
+	}
+	if parts := strings.SplitN(s, ".", 2); len(s) == 0 || reserved[strings.ToLower(parts[0])] {
+		parts[0] = parts[0] + "_"
+		s = strings.Join(parts, ".")
+	}
+	return s
+}
+
+// Path returns the file path holding the argument key within a storage folder,
+// consulting the folder's mapping file for keys whose filename had to be sanitized or deduplicated.
+func Path(folder, key string) string {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if stem, ok := index(folder)[key]; ok {
+		return filepath.Join(folder, stem+".json")
+	}
+	return filepath.Join(folder, key+".json")
+}
+
+// Known checks whether a key may be referenced within a storage folder: either the key, or any of the other keys
+// it is known by, is stored in the folder, or the folder has not been built and references into it cannot be checked.
+func Known(folder string, keys ...string) bool {
+	if _, err := os.Stat(folder); os.IsNotExist(err) {
+		return true
+	}
+	for _, key := range keys {
+		if _, err := os.Stat(Path(folder, key)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Assign returns the file path a key should be written to within a storage folder.
+// Keys that are not portable filenames, or whose filename collides case-insensitively with another key's,
+// are given a sanitized and numbered stem that is recorded in the folder's mapping file.
+func Assign(folder, key string) (string, error) {
+	if err := Writable(); err != nil {
+		return "", err
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	mapping := index(folder)
+	if stem, ok := mapping[key]; ok {
+		return filepath.Join(folder, stem+".json"), nil
+	}
+	var (
+		base   = Sanitize(key)
+		owners = stems(folder)
+		stem   = base
+	)
+	for i := 2; ; i++ {
+		owner, ok := owners[strings.ToLower(stem)]
+		if ok == false || owner == key {
+			break
+		}
+		stem = fmt.Sprintf("%s-%d", base, i)
+	}
+	owners[strings.ToLower(stem)] = key
+	if stem != key {
+		mapping[key] = stem
+		if err := save(folder, mapping); err != nil {
+			return "", err
+		}
+	}
+	return filepath.Join(folder, stem+".json"), nil
+}
+
+// Forget removes a key from the folder's mapping file.
+func Forget(folder, key string) error {
+	if err := Writable(); err != nil {
+		return err
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	mapping := index(folder)
+	if _, ok := mapping[key]; ok == false {
+		return nil
+	}
+	delete(mapping, key)
+	return save(folder, mapping)
+}
+
+// Reindex rebuilds the mapping file of a storage folder from the files it holds, using the argument function
+// to read the key each file is stored under. Files whose key cannot be read are skipped.
+// Returns the number of keys whose filename differs from the key.
+func Reindex(folder string, key func(content []byte) (string, bool)) (int, error) {
+	if err := Writable(); err != nil {
+		return 0, err
+	}
+	files, err := ioutil.ReadDir(folder)
+	if err != nil {
+		return 0, err
+	}
+	mapping := map[string]string{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(folder, file.Name()))
+		if err != nil {
+			continue
+		}
+		stem := strings.TrimSuffix(file.Name(), ".json")
+		if k, ok := key(content); ok && k != stem {
+			mapping[k] = stem
+		}
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	indexes[folder] = mapping
+	delete(owners, folder)
+	return len(mapping), save(folder, mapping)
+}
+
+// Reset discards the cached mapping files so they are read again, such as after storage folders have been moved.
+func Reset() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	indexes, owners = map[string]map[string]string{}, map[string]map[string]string{}
+}
+
+// stems loads (once) the key owning each filename stem of a storage folder, by lowercase stem, from the files
+// the folder holds and its mapping file. Stems are compared without case, so keys differing only by case
+// are told apart on case-sensitive file systems as well as on case-insensitive ones.
+func stems(folder string) map[string]string {
+	if owners, ok := owners[folder]; ok {
+		return owners
+	}
+	var (
+		keys   = map[string]string{}
+		stored = map[string]string{}
+	)
+	for key, stem := range index(folder) {
+		keys[stem] = key
+		stored[strings.ToLower(stem)] = key
+	}
+	if files, err := ioutil.ReadDir(folder); err == nil {
+		for _, file := range files {
+			if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+				continue
+			}
+			stem := strings.TrimSuffix(file.Name(), ".json")
+			key, ok := keys[stem]
+			if ok == false {
+				key = stem
+			}
+			stored[strings.ToLower(stem)] = key
+		}
+	}
+	owners[folder] = stored
+	return stored
+}
+
+// index loads (once) the mapping of keys to filename stems kept alongside a storage folder.
+func index(folder string) map[string]string {
+	if mapping, ok := indexes[folder]; ok {
+		return mapping
+	}
+	mapping := map[string]string{}
+	if content, err := ioutil.ReadFile(indexfile(folder)); err == nil {
+		json.Unmarshal(content, &mapping)
+	}
+	indexes[folder] = mapping
+	return mapping
+}
+
+func indexfile(folder string) string {
+	return filepath.Join(filepath.Dir(folder), filepath.Base(folder)+".index.json")
+}
+
+func save(folder string, mapping map[string]string) error {
+	content, err := Marshal(mapping)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(folder), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(indexfile(folder), content, os.ModePerm)
+}
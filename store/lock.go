@@ -0,0 +1,120 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gellel/emojipedia/directory"
+)
+
+const (
+	lockfile string = ".lock"
+)
+
+var (
+	// Stale is the age after which a lock is ignored even though a process with its pid is running,
+	// since the pid of a crashed run may since have been reused.
+	Stale = 6 * time.Hour
+	// Wait is how long Lock waits for another run to release the dataset before giving up.
+	Wait time.Duration
+)
+
+// Lock takes the advisory lock of the active profile, so concurrent runs cannot interleave writes.
+// Lock waits up to Wait for a held lock to be released and returns an ErrLocked *Error describing the holder if it is not.
+// The returned function releases the lock.
+func Lock() (func(), error) {
+	if err := os.MkdirAll(directory.Root, os.ModePerm); err != nil {
+		return nil, err
+	}
+	return take(directory.Root, filepath.Join(directory.Root, lockfile), Wait)
+}
+
+// LockFile takes an advisory lock of the file at the path alone, held beside it, so concurrent runs cannot interleave
+// reading and writing it while the dataset lock is held by another run. LockFile waits up to the argument duration
+// and returns an ErrLocked *Error describing the holder if the lock is not released. The returned function releases the lock.
+func LockFile(path string, wait time.Duration) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+	return take(path, path+lockfile, wait)
+}
+
+// take creates the lock file at the path, waiting up to the argument duration for a lock held on the named file
+// or folder to be released.
+func take(name, path string, wait time.Duration) (func(), error) {
+	deadline := time.Now().Add(wait)
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(file, "%d %s", os.Getpid(), time.Now().UTC().Format(time.RFC3339))
+			file.Close()
+			return func() {
+				os.Remove(path)
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if stale(path) {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			holder, _ := ioutil.ReadFile(path)
+			cause := fmt.Errorf("%s is held by another emojipedia run (pid and start time %s); wait for it to finish, retry with --wait=5m or delete %s if no run is active", name, strings.TrimSpace(string(holder)), path)
+			return nil, &Error{Cause: cause, Err: ErrLocked}
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+// stale returns whether the lock at the path was left behind by a run that is no longer active:
+// the process that took it has exited or the lock is older than Stale.
+func stale(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	if time.Since(info.ModTime()) > Stale {
+		return true
+	}
+	holder, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	fields := strings.Fields(string(holder))
+	if len(fields) == 0 {
+		return false
+	}
+	pid, err := strconv.Atoi(fields[0])
+	if err != nil || pid <= 0 {
+		return false
+	}
+	return alive(pid) == false
+}
+
+// alive returns whether a process with the pid is running on this machine.
+func alive(pid int) bool {
+	if pid == os.Getpid() {
+		return true
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// FindProcess only finds running processes on Windows; elsewhere it always succeeds and signal 0 probes the process.
+	if runtime.GOOS == "windows" {
+		process.Release()
+		return true
+	}
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
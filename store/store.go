@@ -0,0 +1,131 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+var (
+	// ErrCorruptJSON is matched by every *FieldError, the error returned for stored documents that cannot be decoded.
+	ErrCorruptJSON = errors.New("store: stored document is not valid")
+	// ErrInvalid is returned for emoji, categories and subcategories that are not written because they fail validation in Strict mode.
+	ErrInvalid = errors.New("store: document is invalid")
+	// ErrLocked is returned by Lock when another run holds the dataset lock.
+	ErrLocked = errors.New("store: dataset is locked")
+	// ErrMissingCategories is returned when the categories have not been built.
+	ErrMissingCategories = errors.New("store: categories are missing or not built")
+	// ErrMissingEncyclopedia is returned when the emoji of the emojipedia have not been built.
+	ErrMissingEncyclopedia = errors.New("store: emojipedia is missing or not built")
+	// ErrMissingKeywords is returned when the keywords have not been built.
+	ErrMissingKeywords = errors.New("store: keywords are missing or not built")
+	// ErrMissingSubcategories is returned when the subcategories have not been built.
+	ErrMissingSubcategories = errors.New("store: subcategories are missing or not built")
+	// ErrMissingUnicodeFile is returned when the unicode-org chart has not been downloaded.
+	ErrMissingUnicodeFile = errors.New("store: unicode chart is missing or not downloaded")
+	// ErrReadOnly is returned by every operation that would modify the dataset while ReadOnly is set.
+	ErrReadOnly = errors.New("store: dataset is read-only")
+)
+
+var (
+	// ByID names stored emoji files after their stable ID rather than their display name.
+	ByID bool
+	// Indent is the per-level indentation used when writing JSON. An empty string writes compact JSON.
+	Indent string
+	// ReadOnly refuses every write or removal so a shared dataset can be queried without being modified.
+	ReadOnly bool
+)
+
+// Writable returns ErrReadOnly when the dataset must not be modified.
+func Writable() error {
+	if ReadOnly {
+		return ErrReadOnly
+	}
+	return nil
+}
+
+// Missing returns an *Error wrapping the argument error with the sentinel error when the error reports a missing file,
+// so callers can branch on what is missing with errors.Is. Any other error is returned unchanged.
+func Missing(err error, sentinel error) error {
+	if os.IsNotExist(err) {
+		return &Error{Cause: err, Err: sentinel}
+	}
+	return err
+}
+
+// Invalid returns an *Error wrapping the validation error of a document with ErrInvalid, after passing it to Reject.
+func Invalid(err error) error {
+	err = &Error{Cause: err, Err: ErrInvalid}
+	Reject(err)
+	return err
+}
+
+// Error is a storage failure described by one of the sentinel errors of the store package.
+// It matches the sentinel error with errors.Is and unwraps to the error that caused it.
+type Error struct {
+	Cause error
+	Err   error
+}
+
+// Error describes the Error.
+func (pointer *Error) Error() string {
+	return fmt.Sprintf("%s: %s", pointer.Err, pointer.Cause)
+}
+
+// Is reports whether the target is the sentinel error describing the Error.
+func (pointer *Error) Is(target error) bool {
+	return target == pointer.Err
+}
+
+// Unwrap returns the error that caused the Error.
+func (pointer *Error) Unwrap() error {
+	return pointer.Cause
+}
+
+// Marshal encodes the argument as JSON for storage.
+// Struct fields keep their declared order and map-backed types (such as lexicon.Lexicon) are written with sorted keys,
+// so repeated builds of the same dataset produce byte-identical files. Output is indented when Indent is set
+// and HTML characters are not escaped.
+func Marshal(v interface{}) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", Indent)
+	err := encoder.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+	content := buffer.Bytes()
+	if len(Indent) == 0 {
+		content = bytes.TrimSuffix(content, []byte("\n"))
+	}
+	return content, nil
+}
+
+// WriteFile writes the content to the named file by writing a temporary file beside it and moving that into place,
+// so a failed or interrupted write leaves the file as it was. The file is readable by everyone and writable by its owner.
+func WriteFile(path string, content []byte) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	temporary := file.Name()
+	_, err = file.Write(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(temporary, 0644)
+	}
+	if err == nil {
+		err = os.Rename(temporary, path)
+	}
+	if err != nil {
+		os.Remove(temporary)
+	}
+	return err
+}
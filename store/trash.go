@@ -0,0 +1,139 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gellel/emojipedia/directory"
+)
+
+const (
+	trash  string = ".trash"
+	stamps string = "20060102T150405.000000000"
+)
+
+var (
+	// Retention is how long removed files are kept in the trash before they are pruned.
+	Retention = 30 * 24 * time.Hour
+)
+
+var (
+	batch = time.Now().UTC().Format(stamps)
+)
+
+// Trash moves a stored file or folder (and the mapping file of a folder) into the trash of the active profile
+// instead of deleting it. Everything trashed by one run of the program is kept together so it can be restored at once.
+// Trash entries older than the Retention are pruned.
+func Trash(path string) error {
+	if err := Writable(); err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	Prune()
+	paths := []string{path}
+	if _, err := os.Stat(indexfile(path)); err == nil {
+		paths = append(paths, indexfile(path))
+	}
+	for _, path := range paths {
+		relative, err := filepath.Rel(directory.Root, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(directory.Root, trash, batch, relative)
+		if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.Rename(path, target); err != nil {
+			return err
+		}
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	delete(indexes, path)
+	delete(owners, path)
+	if stored, ok := owners[filepath.Dir(path)]; ok {
+		delete(stored, strings.ToLower(strings.TrimSuffix(filepath.Base(path), ".json")))
+	}
+	return nil
+}
+
+// Prune permanently deletes the trash entries older than the Retention.
+func Prune() error {
+	entries, err := ioutil.ReadDir(filepath.Join(directory.Root, trash))
+	if err != nil {
+		return nil
+	}
+	for _, entry := range entries {
+		stamp, err := time.Parse(stamps, entry.Name())
+		if err != nil || time.Since(stamp) < Retention {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(directory.Root, trash, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore moves the files of the most recent trash entry back to where they were removed from,
+// returning the restored paths. Files that have since been rebuilt are left in the trash.
+func Restore() ([]string, error) {
+	if err := Writable(); err != nil {
+		return nil, err
+	}
+	entries, err := ioutil.ReadDir(filepath.Join(directory.Root, trash))
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if _, err := time.Parse(stamps, entry.Name()); err == nil && entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	if len(names) == 0 {
+		return nil, os.ErrNotExist
+	}
+	sort.Strings(names)
+	var (
+		folder   = filepath.Join(directory.Root, trash, names[len(names)-1])
+		kept     = false
+		restored = []string{}
+	)
+	err = filepath.Walk(folder, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relative, err := filepath.Rel(folder, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(directory.Root, relative)
+		if _, err := os.Stat(target); err == nil {
+			kept = true
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.Rename(path, target); err != nil {
+			return err
+		}
+		restored = append(restored, target)
+		return nil
+	})
+	if err != nil {
+		return restored, err
+	}
+	Reset()
+	if kept == false {
+		os.RemoveAll(folder)
+	}
+	return restored, nil
+}
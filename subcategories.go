@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/pattern"
 	"github.com/gellel/emojipedia/slice"
 	"github.com/gellel/emojipedia/stdin"
 	"github.com/gellel/emojipedia/subcategories"
@@ -13,6 +14,7 @@ import (
 func subcategoriesGet(arguments *arguments.Arguments) {
 	var (
 		subcategories = subcategories.Get()
+		unknown       = []string{}
 	)
 	fmt.Fprintln(writer, "Name\t|Number\t|Category")
 	arguments.Each(func(_ int, argument string) {
@@ -24,18 +26,26 @@ func subcategoriesGet(arguments *arguments.Arguments) {
 				output   = fmt.Sprintf("%v\t|%v\t|%v", name, number, category)
 			)
 			fmt.Fprintln(writer, output)
+		} else if strings.HasPrefix(argument, "--") == false {
+			unknown = append(unknown, argument)
 		}
 	})
 	writer.Flush()
+	notFound(unknown, S, SUBCATEGORIES)
 }
 
 func subcategoriesKeys(arguments *arguments.Arguments) {
 	var (
 		subcategories = subcategories.Get()
+		n             = 0
+		patterns      = patternsOf(arguments)
 	)
 	fmt.Fprintln(writer, "N\t|Name")
-	subcategories.Keys().Sort().Each(func(i int, x interface{}) {
-		fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v", i, x.(string)))
+	subcategories.Keys().Sort().Each(func(_ int, x interface{}) {
+		if pattern.Any(patterns, x.(string)) {
+			fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v", n, x.(string)))
+			n++
+		}
 	})
 	writer.Flush()
 }
@@ -69,9 +79,9 @@ func subcategoriesNumber(arguments *arguments.Arguments) {
 }
 
 func subcategoriesMain(arguments *arguments.Arguments) {
-	switch strings.ToUpper(arguments.Get(0)) {
+	switch command(arguments, BUILD, GET, KEYS, LIST, NUMBER, REMOVE) {
 	case B, BUILD:
-		build(SUBCATEGORIES, subcategories.Make)
+		build(SUBCATEGORIES, making(SUBCATEGORIES, subcategories.Make))
 	case G, GET:
 		subcategoriesGet(arguments.Next())
 	case K, KEYS:
@@ -90,6 +100,8 @@ func subcategoriesMain(arguments *arguments.Arguments) {
 				Verbose: BUILD}
 			g = stdin.Arg{
 				About:   "get one or more subcategories",
+				Args:    "<subcategory> [...<subcategory>]",
+				Example: "emojipedia subcategories get face-smiling",
 				Short:   G,
 				Verbose: GET}
 			k = stdin.Arg{
@@ -123,5 +135,6 @@ func subcategoriesMain(arguments *arguments.Arguments) {
 		})
 		fmt.Fprintln(writer)
 		writer.Flush()
+		unknown(arguments)
 	}
 }
@@ -1,13 +1,18 @@
 package subcategories
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
-	"os"
+	"sort"
 	"strings"
 
 	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/store"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/gellel/emojipedia/conflict"
 	"github.com/gellel/emojipedia/lexicon"
 	"github.com/gellel/emojipedia/pkg"
 	"github.com/gellel/emojipedia/slice"
@@ -19,12 +24,12 @@ var _ subcategories = (*Subcategories)(nil)
 
 // New instantiates a new empty Subcategories pointer.
 func New() *Subcategories {
-	return &Subcategories{&lexicon.Lexicon{}}
+	return &Subcategories{lexicon.NewOrdered()}
 }
 
 // NewSubcategories creates a new Subcategories pointer, accepting zero or more subcategory.Subcategory pointers as arguments.
 func NewSubcategories(subcategory ...*subcategory.Subcategory) *Subcategories {
-	subcategories := &Subcategories{&lexicon.Lexicon{}}
+	subcategories := &Subcategories{lexicon.NewOrdered()}
 	for _, subcategory := range subcategory {
 		subcategories.Add(subcategory)
 	}
@@ -45,80 +50,112 @@ func Lexicon() (*lexicon.Lexicon, error) {
 	if err != nil {
 		return nil, err
 	}
-	return subcategories.lexicon, nil
+	return subcategories.lexicon.Lexicon(), nil
 }
 
-func Make(document *goquery.Document) {
-	var key, category string
+// Make builds Subcategory dependencies from HTML scraped from unicode.org.
+// Returns the error of the first subcategory heading that cannot be built, in which case nothing is stored, or of the first
+// Subcategory that cannot be written. Subcategories rejected as invalid in store.Strict mode are skipped.
+func Make(document *goquery.Document) error {
+	var (
+		err           error
+		key, category string
+	)
 	subcategories := New()
 	document.Find("tr").Each(func(i int, selection *goquery.Selection) {
 		selection.Find("th.bighead a").Each(func(j int, s *goquery.Selection) {
-			category = text.Normalize(s.Text())
+			key, category = "", text.Normalize(s.Text())
 		})
 		selection.Find("th.mediumhead a").Each(func(j int, s *goquery.Selection) {
-			var (
-				anchor, _   = s.Attr("href")
-				emoji       = &slice.Slice{}
-				href        = (pkg.URL + anchor)
-				position    = i
-				name        = text.Normalize(s.Text())
-				number      = subcategories.Len()
-				subcategory = subcategory.NewSubcategory(anchor, category, href, name, number, position, emoji)
-			)
+			key = ""
+			anchor, _ := s.Attr("href")
+			subcategory, buildErr := subcategory.NewBuilder().
+				Anchor(anchor).
+				Category(category).
+				Href(pkg.Address() + anchor).
+				Name(text.Normalize(s.Text())).
+				Number(subcategories.Len()).
+				Position(i).
+				Build()
+			if buildErr != nil {
+				if err == nil {
+					err = fmt.Errorf("row %v: %s", i, buildErr)
+				}
+				return
+			}
 			subcategories.Add(subcategory)
 			key = subcategory.Name
 		})
 		selection.Find("td").Eq(3).Each(func(j int, s *goquery.Selection) {
-			var (
-				name           = text.Normalize(s.Text())
-				subcategory, _ = subcategories.Get(key)
-			)
-			subcategory.Emoji.Append(name)
+			if subcategory, ok := subcategories.Get(key); ok {
+				subcategory.Emoji.Append(text.Normalize(s.Text()))
+			}
 		})
 	})
+	if err != nil {
+		return err
+	}
 	subcategories.Each(func(s *subcategory.Subcategory) {
-		subcategory.Write(s)
+		if pkg.Selected(s.Category) == false || err != nil {
+			return
+		}
+		if writeErr := subcategory.Write(s); writeErr != nil && errors.Is(writeErr, store.ErrInvalid) == false {
+			err = writeErr
+		}
 	})
+	return err
 }
 
-// Open attempts to open all Category data from the emojipedia/subcategories folder.
+// Open attempts to open all Subcategory data from the emojipedia/subcategories folder, in chart order.
 func Open() (*Subcategories, error) {
 	files, err := ioutil.ReadDir(directory.Subcategory)
 	if err != nil {
-		return nil, err
+		return nil, store.Missing(err, store.ErrMissingSubcategories)
 	}
-	subcategories := New()
+	values := []*subcategory.Subcategory{}
 	for _, file := range files {
 		name := strings.TrimSuffix(file.Name(), ".json")
 		subcategory, err := subcategory.Open(name)
 		if err != nil {
 			return nil, err
 		}
-		subcategories.Add(subcategory)
+		values = append(values, subcategory)
 	}
-	return subcategories, nil
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Position < values[j].Position
+	})
+	return NewSubcategories(values...), nil
 }
 
 // Remove deletes all Subcategory data stored in the dependencies folder.
 func Remove() error {
-	return os.Remove(directory.Subcategory)
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	return store.Trash(directory.Subcategory)
 }
 
 type subcategories interface {
 	Add(subcategory *subcategory.Subcategory) *Subcategories
 	Each(f func(subcategory *subcategory.Subcategory)) *Subcategories
+	Every(f func(subcategory *subcategory.Subcategory) bool) bool
 	Fetch(key string) *subcategory.Subcategory
+	Find(f func(subcategory *subcategory.Subcategory) bool) (*subcategory.Subcategory, bool)
 	Get(key string) (*subcategory.Subcategory, bool)
 	Has(key string) bool
 	Keys() *slice.Slice
 	Len() int
+	Map(f func(subcategory *subcategory.Subcategory) interface{}) *slice.Slice
+	Merge(subcategories *Subcategories, strategy conflict.Strategy) *Subcategories
+	Reduce(f func(accumulator interface{}, subcategory *subcategory.Subcategory) interface{}, initial interface{}) interface{}
 	Remove(key string) bool
+	Some(f func(subcategory *subcategory.Subcategory) bool) bool
 	Values() *slice.Slice
 }
 
 // Subcategories is a map-like struct with methods used to perform traversal and retrieval of subcategory.Subcategory pointers.
 type Subcategories struct {
-	lexicon *lexicon.Lexicon
+	lexicon *lexicon.OrderedLexicon
 }
 
 // Add method adds one subcategory.Subcategory to the Subcategories using the subcategory.Subcategory.Name as the key reference.
@@ -127,7 +164,7 @@ func (pointer *Subcategories) Add(subcategory *subcategory.Subcategory) *Subcate
 	return pointer
 }
 
-// Each method executes a provided function once for each subcategory.Subcategory pointer.
+// Each method executes a provided function once for each subcategory.Subcategory pointer, in the order they were added.
 func (pointer *Subcategories) Each(f func(subcategory *subcategory.Subcategory)) *Subcategories {
 	pointer.lexicon.Each(func(key string, i interface{}) {
 		f(i.(*subcategory.Subcategory))
@@ -135,12 +172,34 @@ func (pointer *Subcategories) Each(f func(subcategory *subcategory.Subcategory))
 	return pointer
 }
 
+// Every method tests whether every subcategory.Subcategory pointer passes the provided function, in the same order as Each.
+// Returns true when the Subcategories is empty.
+func (pointer *Subcategories) Every(f func(subcategory *subcategory.Subcategory) bool) bool {
+	every := true
+	pointer.Each(func(subcategory *subcategory.Subcategory) {
+		every = every && f(subcategory)
+	})
+	return every
+}
+
 // Fetch retrieves the subcategory.Subcategory pointer held by the argument key. Panics if key does not exist.
 func (pointer *Subcategories) Fetch(key string) *subcategory.Subcategory {
 	property, _ := pointer.Get(key)
 	return property
 }
 
+// Find method returns the first subcategory.Subcategory pointer, in the same order as Each, that passes the provided function
+// and a boolean indicating if one was found.
+func (pointer *Subcategories) Find(f func(subcategory *subcategory.Subcategory) bool) (*subcategory.Subcategory, bool) {
+	var found *subcategory.Subcategory
+	pointer.Each(func(subcategory *subcategory.Subcategory) {
+		if found == nil && f(subcategory) {
+			found = subcategory
+		}
+	})
+	return found, found != nil
+}
+
 // Get returns the subcategory.Subcategory pointer held by the argument key and a boolean indicating if it was successfully retrieved.
 // Panics if cannot convert to subcategory.Subcategory pointer.
 func (pointer *Subcategories) Get(key string) (*subcategory.Subcategory, bool) {
@@ -156,7 +215,7 @@ func (pointer *Subcategories) Has(key string) bool {
 	return pointer.lexicon.Has(key)
 }
 
-// Keys method returns a slice.Slice of a given Subcategories' own property names, in the same order as we get with a normal loop.
+// Keys method returns a slice.Slice of a given Subcategories' own property names, in the order they were added.
 func (pointer *Subcategories) Keys() *slice.Slice {
 	slice := slice.New()
 	pointer.lexicon.Each(func(key string, i interface{}) {
@@ -170,13 +229,80 @@ func (pointer *Subcategories) Len() int {
 	return pointer.lexicon.Len()
 }
 
+// Map method executes a provided function once for each subcategory.Subcategory pointer and returns a slice.Slice of the returned values,
+// in the same order as Each.
+func (pointer *Subcategories) Map(f func(subcategory *subcategory.Subcategory) interface{}) *slice.Slice {
+	slice := slice.New()
+	pointer.Each(func(subcategory *subcategory.Subcategory) {
+		slice.Append(f(subcategory))
+	})
+	return slice
+}
+
+// MarshalJSON encodes the Subcategories as a plain JSON object keyed by name.
+func (pointer *Subcategories) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pointer.lexicon)
+}
+
+// Reduce method executes a provided function once for each subcategory.Subcategory pointer, in the same order as Each,
+// passing the value returned by the previous call (or the initial value) and returning the last value.
+func (pointer *Subcategories) Reduce(f func(accumulator interface{}, subcategory *subcategory.Subcategory) interface{}, initial interface{}) interface{} {
+	accumulator := initial
+	pointer.Each(func(subcategory *subcategory.Subcategory) {
+		accumulator = f(accumulator, subcategory)
+	})
+	return accumulator
+}
+
+// Merge method adds a copy of every subcategory.Subcategory of the argument Subcategories to the Subcategories.
+// Names held by both are resolved by the conflict.Strategy: kept, replaced by the merged copy or combined by subcategory.Subcategory.Union.
+func (pointer *Subcategories) Merge(subcategories *Subcategories, strategy conflict.Strategy) *Subcategories {
+	subcategories.Each(func(subcategory *subcategory.Subcategory) {
+		held, ok := pointer.Get(subcategory.Name)
+		switch {
+		case ok == false || strategy == conflict.Replace:
+			pointer.Add(subcategory.Copy())
+		case strategy == conflict.Union:
+			held.Union(subcategory)
+		}
+	})
+	return pointer
+}
+
 // Remove method removes a entry from the Subcategories if it exists. Returns a boolean to confirm if it succeeded.
 func (pointer *Subcategories) Remove(key string) bool {
 	return pointer.lexicon.Remove(key)
 }
 
+// Some method tests whether at least one subcategory.Subcategory pointer passes the provided function.
+func (pointer *Subcategories) Some(f func(subcategory *subcategory.Subcategory) bool) bool {
+	_, ok := pointer.Find(f)
+	return ok
+}
+
+// UnmarshalJSON decodes a plain JSON object keyed by name into the Subcategories in chart order, replacing its contents.
+func (pointer *Subcategories) UnmarshalJSON(content []byte) error {
+	values := map[string]*subcategory.Subcategory{}
+	err := json.Unmarshal(content, &values)
+	if err != nil {
+		return err
+	}
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return values[keys[i]].Position < values[keys[j]].Position
+	})
+	pointer.lexicon = lexicon.NewOrdered()
+	for _, key := range keys {
+		pointer.lexicon.Add(key, values[key])
+	}
+	return nil
+}
+
 // Values method returns a Slice of a given Subcategories's own enumerable property values,
-// in the same order as that provided by a for...in loop.
+// in the order they were added.
 func (pointer *Subcategories) Values() *slice.Slice {
 	slice := slice.New()
 	pointer.lexicon.Each(func(key string, i interface{}) {
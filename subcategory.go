@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/store"
 	"github.com/gellel/emojipedia/subcategory"
 )
 
@@ -12,7 +13,7 @@ func subcategoryMain(arguments *arguments.Arguments) {
 	s, err := subcategory.Open(arguments.Get(0))
 	switch err == nil {
 	case true:
-		switch strings.ToUpper(arguments.Next().Get(0)) {
+		switch command(arguments.Next(), ANCHOR, CATEGORY, EMOJI, HREF, NUMBER, POSITION, TABLE) {
 		case "-A", ANCHOR:
 			fmt.Println(s.Anchor)
 		case "-C", CATEGORY:
@@ -50,6 +51,9 @@ func subcategoryMain(arguments *arguments.Arguments) {
 			writer.Flush()
 		}
 	default:
-		fmt.Println(fmt.Sprintf(errorChoiceNotFound, arguments.Get(0), "-ss", strings.ToLower(SUBCATEGORY)))
+		if fieldError, ok := err.(*store.FieldError); ok {
+			fail(fieldError.Error(), err)
+		}
+		fail(fmt.Sprintf(errorChoiceNotFound, arguments.Get(0), "-ss", strings.ToLower(SUBCATEGORY)), err)
 	}
 }
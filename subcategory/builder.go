@@ -0,0 +1,71 @@
+package subcategory
+
+import (
+	"fmt"
+
+	"github.com/gellel/emojipedia/slice"
+)
+
+// NewBuilder instantiates a new Builder of an empty Subcategory.
+func NewBuilder() *Builder {
+	return &Builder{subcategory: New()}
+}
+
+// Builder assembles a Subcategory one named field at a time in place of the positional NewSubcategory,
+// checking that the Subcategory can be stored when it is built.
+type Builder struct {
+	subcategory *Subcategory
+}
+
+// Anchor sets the anchor of the Subcategory on the unicode.org page.
+func (pointer *Builder) Anchor(anchor string) *Builder {
+	pointer.subcategory.SetAnchor(anchor)
+	return pointer
+}
+
+// Build returns the Subcategory, or an error if it has no name or category.
+func (pointer *Builder) Build() (*Subcategory, error) {
+	switch {
+	case len(pointer.subcategory.Name) == 0:
+		return nil, fmt.Errorf("subcategory: cannot build a subcategory without a name")
+	case len(pointer.subcategory.Category) == 0:
+		return nil, fmt.Errorf("subcategory: cannot build \"%s\" without its category", pointer.subcategory.Name)
+	}
+	return pointer.subcategory.Copy(), nil
+}
+
+// Category sets the name of the category of the Subcategory.
+func (pointer *Builder) Category(category string) *Builder {
+	pointer.subcategory.SetCategory(category)
+	return pointer
+}
+
+// Emoji sets the names of the emoji in the Subcategory.
+func (pointer *Builder) Emoji(emoji ...string) *Builder {
+	pointer.subcategory.SetEmoji(slice.Strings(emoji...))
+	return pointer
+}
+
+// Href sets the address of the Subcategory on the unicode.org page.
+func (pointer *Builder) Href(href string) *Builder {
+	pointer.subcategory.SetHref(href)
+	return pointer
+}
+
+// Name sets the name of the Subcategory.
+func (pointer *Builder) Name(name string) *Builder {
+	pointer.subcategory.SetName(name)
+	return pointer
+}
+
+// Number sets the number of the Subcategory in the unicode.org chart.
+func (pointer *Builder) Number(number int) *Builder {
+	pointer.subcategory.SetNumber(number)
+	return pointer
+}
+
+// Position sets the row of the Subcategory in the unicode.org chart.
+func (pointer *Builder) Position(position int) *Builder {
+	pointer.subcategory.SetPosition(position)
+	return pointer
+}
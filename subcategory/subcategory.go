@@ -1,14 +1,16 @@
 package subcategory
 
 import (
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
-	"path/filepath"
 
+	"github.com/gellel/emojipedia/alias"
 	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emoji"
 	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/text"
 )
 
 var _ subcategory = (*Subcategory)(nil)
@@ -19,6 +21,8 @@ func New() *Subcategory {
 }
 
 // NewSubcategory creates a new Subcategory pointer, requiring all struct features as arguments.
+//
+// Deprecated: use NewBuilder, which names each field and checks the Subcategory when it is built.
 func NewSubcategory(anchor, category, href, name string, number, position int, emoji *slice.Slice) *Subcategory {
 	return &Subcategory{
 		Anchor:   anchor,
@@ -39,10 +43,20 @@ func Get(name string) *Subcategory {
 	return subcategory
 }
 
-// Open attempts to open a Subcategory from the emojipedia/subcategories folder.
+// Open attempts to open a Subcategory from the emojipedia/subcategories folder, by its name or by another name
+// unicode has known it by.
 func Open(name string) (*Subcategory, error) {
-	filepath := filepath.Join(directory.Subcategory, fmt.Sprintf("%s.json", name))
-	reader, err := os.Open(filepath)
+	var (
+		filepath string
+		reader   *os.File
+		err      error
+	)
+	for _, name := range alias.SubcategoryNames(name) {
+		filepath = store.Path(directory.Subcategory, name)
+		if reader, err = os.Open(filepath); err == nil {
+			break
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -52,25 +66,34 @@ func Open(name string) (*Subcategory, error) {
 		return nil, err
 	}
 	subcategory := &Subcategory{}
-	err = json.Unmarshal(content, subcategory)
+	err = store.Decode(filepath, content, subcategory)
 	if err != nil {
 		return nil, err
 	}
-	return subcategory, nil
+	return rename(subcategory), nil
 }
 
+// Parse decodes stored Subcategory content, giving the subcategory and its category the names unicode has since renamed them to.
 func Parse(content *[]byte) (*Subcategory, error) {
 	category := &Subcategory{}
-	err := json.Unmarshal(*content, category)
+	err := store.Unmarshal(*content, category)
 	if err != nil {
 		return nil, err
 	}
-	return category, nil
+	return rename(category), nil
 }
 
+// Read attempts to read the raw content of a Subcategory by its name, or by another name unicode has known it by.
 func Read(name string) (*[]byte, error) {
-	filepath := filepath.Join(directory.Subcategory, fmt.Sprintf("%s.json", name))
-	reader, err := os.Open(filepath)
+	var (
+		reader *os.File
+		err    error
+	)
+	for _, name := range alias.SubcategoryNames(name) {
+		if reader, err = os.Open(store.Path(directory.Subcategory, name)); err == nil {
+			break
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -82,26 +105,54 @@ func Read(name string) (*[]byte, error) {
 	return &content, nil
 }
 
+// rename gives the Subcategory and its category their current names. The category of a split category is
+// found by the name the Subcategory was stored with.
+func rename(subcategory *Subcategory) *Subcategory {
+	subcategory.Category = alias.Category(subcategory.Category, subcategory.Name)
+	subcategory.Name = alias.Subcategory(subcategory.Name)
+	return subcategory
+}
+
 // Remove deletes the Subcategory data stored in the dependencies folder.
 func Remove(name string) error {
-	return os.Remove(filepath.Join(directory.Subcategory, fmt.Sprintf("%s.json", name)))
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := store.Trash(store.Path(directory.Subcategory, name))
+	if err != nil {
+		return err
+	}
+	return store.Forget(directory.Subcategory, name)
 }
 
 // Write stores and Subcategory pointer to the dependencies folder.
 func Write(subcategory *Subcategory) error {
-	err := os.MkdirAll(directory.Subcategory,  os.ModePerm)
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(directory.Subcategory, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	if store.Strict {
+		if err := subcategory.Validate(); err != nil {
+			return store.Invalid(err)
+		}
+	}
+	content, err := store.Marshal(subcategory)
 	if err != nil {
 		return err
 	}
-	content, err := json.Marshal(subcategory)
+	filepath, err := store.Assign(directory.Subcategory, subcategory.Name)
 	if err != nil {
 		return err
 	}
-	filepath := filepath.Join(directory.Subcategory, fmt.Sprintf("%s.json", subcategory.Name))
-	return ioutil.WriteFile(filepath, content,  os.ModePerm)
+	return store.WriteFile(filepath, content)
 }
 
 type subcategory interface {
+	Copy() *Subcategory
+	EmojiRefs() []*emoji.Emoji
 	SetAnchor(anchor string) *Subcategory
 	SetCategory(category string) *Subcategory
 	SetEmoji(emoji *slice.Slice) *Subcategory
@@ -109,6 +160,8 @@ type subcategory interface {
 	SetName(name string) *Subcategory
 	SetNumber(number int) *Subcategory
 	SetPosition(position int) *Subcategory
+	Union(subcategory *Subcategory) *Subcategory
+	Validate() error
 }
 
 type Subcategory struct {
@@ -121,6 +174,25 @@ type Subcategory struct {
 	Position int          `json:"position"`
 }
 
+// Copy method returns a deep copy of the Subcategory, sharing no slices with it.
+func (pointer *Subcategory) Copy() *Subcategory {
+	subcategory := *pointer
+	subcategory.Emoji = pointer.Emoji.Copy()
+	return &subcategory
+}
+
+// EmojiRefs resolves the Subcategory.Emoji names to their stored emoji.Emoji pointers.
+// Names that cannot be opened are skipped; use the integrity package to report them.
+func (pointer *Subcategory) EmojiRefs() []*emoji.Emoji {
+	refs := []*emoji.Emoji{}
+	pointer.Emoji.Each(func(_ int, i interface{}) {
+		if e, err := emoji.Open(i.(string)); err == nil {
+			refs = append(refs, e)
+		}
+	})
+	return refs
+}
+
 // SetAnchor sets the Subcategory.Anchor property.
 func (pointer *Subcategory) SetAnchor(anchor string) *Subcategory {
 	pointer.Anchor = anchor
@@ -162,3 +234,41 @@ func (pointer *Subcategory) SetPosition(position int) *Subcategory {
 	pointer.Position = position
 	return pointer
 }
+
+// Union method fills the empty anchor, category, href and name of the Subcategory from the argument Subcategory
+// and adds the emoji the Subcategory does not hold.
+func (pointer *Subcategory) Union(subcategory *Subcategory) *Subcategory {
+	if len(pointer.Anchor) == 0 {
+		pointer.Anchor = subcategory.Anchor
+	}
+	if len(pointer.Category) == 0 {
+		pointer.Category = subcategory.Category
+	}
+	if len(pointer.Href) == 0 {
+		pointer.Href = subcategory.Href
+	}
+	if len(pointer.Name) == 0 {
+		pointer.Name = subcategory.Name
+	}
+	if pointer.Emoji == nil {
+		pointer.Emoji = &slice.Slice{}
+	}
+	pointer.Emoji.Union(subcategory.Emoji)
+	return pointer
+}
+
+// Validate checks that the Subcategory can be stored and looked up: it is named, it names a category that is stored (once the categories have been built)
+// and its href is a web address.
+func (pointer *Subcategory) Validate() error {
+	switch {
+	case len(pointer.Name) == 0:
+		return fmt.Errorf("subcategory: subcategory at position %d has no name", pointer.Position)
+	case len(pointer.Category) == 0:
+		return fmt.Errorf("subcategory: \"%s\" has no category", pointer.Name)
+	case store.Known(directory.Category, alias.CategoryNames(pointer.Category)...) == false:
+		return fmt.Errorf("subcategory: \"%s\" references the missing category \"%s\"", pointer.Name, pointer.Category)
+	case len(pointer.Href) != 0 && text.IsURL(pointer.Href) == false:
+		return fmt.Errorf("subcategory: \"%s\" has the href \"%s\" which is not a web address", pointer.Name, pointer.Href)
+	}
+	return nil
+}
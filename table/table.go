@@ -0,0 +1,116 @@
+package table
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/gellel/emojipedia/segment"
+)
+
+const (
+	tabwidth int = 8
+)
+
+// NewWriter instantiates a new Writer pointer that flushes to the argument io.Writer.
+func NewWriter(output io.Writer) *Writer {
+	return &Writer{limit: -1, output: output}
+}
+
+// Writer is a tab-separated column aligner in the style of text/tabwriter.
+// Unlike text/tabwriter, cell widths are measured in terminal columns rather than runes,
+// so columns containing emoji line up.
+type Writer struct {
+	buffer bytes.Buffer
+	limit  int
+	offset int
+	output io.Writer
+}
+
+// Write buffers the argument bytes until Flush is called.
+func (pointer *Writer) Write(p []byte) (int, error) {
+	return pointer.buffer.Write(p)
+}
+
+// Flush aligns the buffered lines and writes them to the output.
+// Tab-terminated cells in consecutive lines form a column and are padded to a shared width rounded up to the next tab stop.
+func (pointer *Writer) Flush() error {
+	var (
+		content = strings.TrimSuffix(pointer.buffer.String(), "\n")
+		lines   = [][]string{}
+	)
+	pointer.buffer.Reset()
+	if len(content) == 0 {
+		return nil
+	}
+	for _, line := range pointer.page(strings.Split(content, "\n")) {
+		lines = append(lines, strings.Split(line, "\t"))
+	}
+	widths := make([][]int, len(lines))
+	for column := 0; ; column++ {
+		found := false
+		for i := 0; i < len(lines); {
+			if len(lines[i])-1 <= column {
+				i++
+				continue
+			}
+			found = true
+			j, width := i, 0
+			for ; j < len(lines) && len(lines[j])-1 > column; j++ {
+				if w := segment.Width(lines[j][column]); w > width {
+					width = w
+				}
+			}
+			width = (width + tabwidth - 1) / tabwidth * tabwidth
+			for ; i < j; i++ {
+				widths[i] = append(widths[i], width)
+			}
+		}
+		if found == false {
+			break
+		}
+	}
+	b := strings.Builder{}
+	for i, cells := range lines {
+		for j, cell := range cells {
+			b.WriteString(cell)
+			if j < len(cells)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i][j]-segment.Width(cell)))
+			}
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(pointer.output, b.String())
+	return err
+}
+
+// Page method selects the rows the next Flush writes: the first line is kept as the header, then offset rows are
+// skipped and at most limit rows are written. A limit below zero writes every remaining row.
+func (pointer *Writer) Page(offset, limit int) *Writer {
+	pointer.offset, pointer.limit = offset, limit
+	return pointer
+}
+
+// SetOutput sets the io.Writer the Writer flushes to.
+func (pointer *Writer) SetOutput(output io.Writer) *Writer {
+	pointer.output = output
+	return pointer
+}
+
+// page returns the header and the rows of the lines selected by Page, clearing the selection.
+func (pointer *Writer) page(lines []string) []string {
+	offset, limit := pointer.offset, pointer.limit
+	pointer.offset, pointer.limit = 0, -1
+	if offset <= 0 && limit < 0 {
+		return lines
+	}
+	rows := lines[1:]
+	if offset > len(rows) {
+		offset = len(rows)
+	}
+	rows = rows[offset:]
+	if limit >= 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return append(lines[:1:1], rows...)
+}
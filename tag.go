@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/stdin"
+	"github.com/gellel/emojipedia/text"
+	"github.com/gellel/emojipedia/user"
+)
+
+// tagChange adds or removes the tags following the first argument on the emoji it names.
+func tagChange(arguments *arguments.Arguments, f func(u *user.User, ID string, tags ...string) int) {
+	var (
+		emojipedia = emojipedia.GetLocale(language)
+		name       = arguments.Get(0)
+		tags       = []string{}
+	)
+	arguments.Next().Each(func(_ int, argument string) {
+		if strings.HasPrefix(argument, "--") == false {
+			tags = append(tags, argument)
+		}
+	})
+	if len(name) == 0 || len(tags) == 0 {
+		failWith(usage, fmt.Sprintf(errorCannotFind, "tag"), ErrMissingArgument)
+	}
+	e, ok := emojipedia.Get(name)
+	if ok == false {
+		notFound([]string{name}, TG, TAG)
+	}
+	personalize(func(u *user.User) {
+		n := f(u, e.ID, tags...)
+		fmt.Println(fmt.Sprintf("%s %s\t%v tags changed; now tagged \"%s\"", text.Emojize(e.Unicode), e.Name, n, strings.Join(u.Tags[e.ID], " ")))
+	})
+}
+
+func tagList(arguments *arguments.Arguments) {
+	var (
+		emojipedia = emojipedia.GetLocale(language)
+	)
+	u, err := user.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, "user", err), err)
+	}
+	fmt.Fprintln(writer, "\t|Name\t|Tags")
+	emojipedia.IDs().Sort().Each(func(_ int, i interface{}) {
+		ID := i.(string)
+		if tags, ok := u.Tags[ID]; ok {
+			e := emojipedia.Fetch(ID)
+			fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v\t|%v", text.Emojize(e.Unicode), e.Name, strings.Join(tags, " ")))
+		}
+	})
+	writer.Flush()
+}
+
+func tagMain(arguments *arguments.Arguments) {
+	switch command(arguments, ADD, LIST, REMOVE) {
+	case A, ADD:
+		tagChange(arguments.Next(), (*user.User).Tag)
+	case L, LIST:
+		tagList(arguments.Next())
+	case R, REMOVE:
+		tagChange(arguments.Next(), (*user.User).Untag)
+	default:
+		var (
+			a = stdin.Arg{
+				About:   "add one or more tags to an emoji",
+				Args:    "<emoji> <tag> [...<tag>]",
+				Example: "emojipedia tag add pile-of-poo oops",
+				Short:   A,
+				Verbose: ADD}
+			l = stdin.Arg{
+				About:   "show the tagged emoji and their tags",
+				Short:   L,
+				Verbose: LIST}
+			r = stdin.Arg{
+				About:   "remove one or more tags from an emoji",
+				Args:    "<emoji> <tag> [...<tag>]",
+				Example: "emojipedia tag remove pile-of-poo oops",
+				Short:   R,
+				Verbose: REMOVE}
+		)
+		fmt.Fprintln(writer, "usage: emojipedia [-tg tag] [<option>] [--flags]")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "tags are kept apart from the dataset and matched by search as keywords")
+		slice.New(a, l, r).Each(func(_ int, i interface{}) {
+			fmt.Fprintln(writer, i.(stdin.Arg))
+		})
+		fmt.Fprintln(writer)
+		writer.Flush()
+		unknown(arguments)
+	}
+}
@@ -1,7 +1,7 @@
 package text
 
 import (
-	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 	"unicode"
@@ -10,28 +10,103 @@ import (
 	"golang.org/x/text/unicode/norm"
 )
 
+const (
+	// Keep leaves punctuation that has no replacement in the slug.
+	Keep Policy = iota
+	// Drop removes punctuation that has no replacement from the slug.
+	Drop
+	// Separate replaces punctuation that has no replacement with the separator.
+	Separate
+)
+
 var (
-	replacements = []string{" ", "-", "(", "", ")", "", "&", "and", ":", "", ",", "", ".", "", "⊛", "", "“", "", "”", "", "’", ""}
-	replacer     = strings.NewReplacer(replacements...)
-	transformer  = transform.Chain(norm.NFD, transform.RemoveFunc(func(r rune) bool { return unicode.Is(unicode.Mn, r) }), norm.NFC)
+	// Default is the Slugger every parser names categories, subcategories, emoji and keywords with.
+	// The names of the unicode.org chart keep the slugs of earlier versions, so "Smileys & Emotion" is "smileys-and-emotion",
+	// "flag: Côte d’Ivoire" is "flag-cote-divoire" and "keycap: #" is "keycap-#". Other text can slug differently than
+	// before: runs of whitespace, hyphens and underscores become one separator and compatibility forms such as "™" are
+	// folded, so a dataset holding such names from other sources is only renamed once it is rebuilt.
+	Default = &Slugger{
+		Compatibility: true,
+		Replacements:  []string{"&", " and ", "(", "", ")", "", ":", "", ",", "", ".", "", "⊛", "", "“", "", "”", "", "’", ""},
+		Separator:     "-",
+		Stop:          Keep}
 )
 
 // Emojize transforms an escaped emoji unicode string to its glyph counterpart.
+// Multi-codepoint sequences (variation selectors, ZWJ sequences) are emojized in full.
 func Emojize(s string) string {
-	r, _ := strconv.ParseInt(strings.TrimPrefix(s, "\\U"), 16, 32)
-	return fmt.Sprintf("%s", string(r))
+	runes := []rune{}
+	for _, code := range strings.Split(s, "\\U") {
+		if len(code) == 0 {
+			continue
+		}
+		r, _ := strconv.ParseInt(code, 16, 32)
+		runes = append(runes, rune(r))
+	}
+	return string(runes)
 }
 
-// Normalize trims and replaces all non utf-8 characters from the argument string.
+// IsURL checks whether the argument string is an absolute http or https address with a host.
+func IsURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && len(u.Host) != 0
+}
+
+// Normalize slugs the argument string with the Default Slugger.
 func Normalize(s string) string {
-	s, _, _ = transform.String(transformer, s)
-	s = strings.ToLower(s)
-	s = replacer.Replace(strings.TrimSpace(s))
-	if len(s) != 0 && strings.HasPrefix(s, "-") {
-		s = strings.TrimPrefix(s, "-")
+	return Default.Slug(s)
+}
+
+// Policy decides what a Slugger does with punctuation it has no replacement for.
+type Policy int
+
+// Slugger turns display names into the lowercase, separator joined slugs used as keys and file names.
+type Slugger struct {
+	// Compatibility decomposes with NFKD rather than NFD, folding ligatures, full-width and superscript forms.
+	Compatibility bool
+	// Diacritics keeps accents rather than stripping them.
+	Diacritics bool
+	// Replacements are old and new string pairs substituted before the slug is separated.
+	Replacements []string
+	// Separator joins the words of the slug. Runs of whitespace, hyphens and underscores become one Separator.
+	Separator string
+	// Stop decides what happens to the remaining punctuation and symbols.
+	Stop Policy
+}
+
+// Slug method returns the slug of the argument string.
+func (pointer *Slugger) Slug(s string) string {
+	form := norm.NFD
+	if pointer.Compatibility {
+		form = norm.NFKD
+	}
+	transformers := []transform.Transformer{form}
+	if pointer.Diacritics == false {
+		transformers = append(transformers, transform.RemoveFunc(func(r rune) bool { return unicode.Is(unicode.Mn, r) }))
 	}
-	if len(s) != 0 && strings.HasSuffix(s, "-") {
-		s = strings.TrimSuffix(s, "-")
+	s, _, _ = transform.String(transform.Chain(append(transformers, norm.NFC)...), s)
+	s = strings.NewReplacer(pointer.Replacements...).Replace(strings.ToLower(strings.TrimSpace(s)))
+	var (
+		b       = strings.Builder{}
+		pending = false
+	)
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			pending = true
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+		case pointer.Stop == Drop:
+			continue
+		case pointer.Stop == Separate:
+			pending = true
+			continue
+		}
+		if pending && b.Len() != 0 {
+			b.WriteString(pointer.Separator)
+		}
+		pending = false
+		b.WriteRune(r)
 	}
-	return s
+	return b.String()
 }
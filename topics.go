@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gellel/emojipedia/analyzer"
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/slice"
+	"github.com/gellel/emojipedia/stdin"
+	"github.com/gellel/emojipedia/text"
+	"github.com/gellel/emojipedia/topics"
+)
+
+// topicsOpen opens the stored topics, failing with how to build them when they have not been built.
+func topicsOpen() *topics.Topics {
+	t, err := topics.Open()
+	if err != nil {
+		failWith(usage, fmt.Sprintf(errorCannotOpen, strings.ToLower(TOPICS), err), err)
+	}
+	return t
+}
+
+// topicsBuild clusters the stored emoji by the keywords they share and stores the clusters as named topics.
+func topicsBuild(arguments *arguments.Arguments) {
+	name := strings.ToLower(TOPICS)
+	fmt.Println(fmt.Sprintf(statusBuildPackage, name))
+	lock()
+	e, err := emojipedia.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorBuildPackage, name, err), err)
+	}
+	documents := map[string][]string{}
+	e.Each(func(key string, e *emoji.Emoji) {
+		keys := []string{}
+		e.Keywords.Each(func(_ int, i interface{}) {
+			keys = append(keys, analyzer.Default.Key(i.(string)))
+		})
+		documents[e.Name] = keys
+	})
+	t := topics.Cluster(documents)
+	if err = topics.Write(t); err != nil {
+		fail(fmt.Sprintf(errorBuildPackage, name, err), err)
+	}
+	fmt.Println(fmt.Sprintf(successTopics, t.Len(), e.Len()))
+}
+
+func topicsGet(arguments *arguments.Arguments) {
+	var (
+		emojipedia = emojipedia.GetLocale(language)
+		t          = topicsOpen()
+		unknown    = []string{}
+	)
+	fmt.Fprintln(writer, "\t|Name\t|Topic")
+	arguments.Each(func(_ int, argument string) {
+		topic, ok := t.Get(analyzer.Default.Key(argument))
+		if ok == false {
+			topic, ok = t.Get(argument)
+		}
+		if ok == false {
+			if strings.HasPrefix(argument, "--") == false {
+				unknown = append(unknown, argument)
+			}
+			return
+		}
+		for _, name := range topic.Emoji {
+			if e, ok := emojipedia.Get(name); ok {
+				fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v\t|%v", text.Emojize(e.Unicode), e.Name, topic.Name))
+			}
+		}
+	})
+	writer.Flush()
+	notFound(unknown, TP, TOPICS)
+}
+
+func topicsList(arguments *arguments.Arguments) {
+	var (
+		t = topicsOpen()
+	)
+	fmt.Fprintln(writer, "N\t|Name\t|Emoji\t|Keywords")
+	for i, topic := range t.Topics {
+		keywords := topic.Keywords
+		if len(keywords) > 5 {
+			keywords = keywords[:5]
+		}
+		fmt.Fprintln(writer, fmt.Sprintf("%v\t|%v\t|%v\t|%v", i, topic.Name, len(topic.Emoji), strings.Join(keywords, " ")))
+	}
+	writer.Flush()
+}
+
+func topicsMain(arguments *arguments.Arguments) {
+	switch command(arguments, BUILD, GET, LIST, REMOVE) {
+	case B, BUILD:
+		topicsBuild(arguments.Next())
+	case G, GET:
+		topicsGet(arguments.Next())
+	case L, LIST:
+		topicsList(arguments.Next())
+	case R, REMOVE:
+		remove(strings.ToLower(TOPICS), topics.Remove)
+	default:
+		var (
+			b = stdin.Arg{
+				About:   "cluster the stored emoji by the keywords they share into named topics",
+				Short:   B,
+				Verbose: BUILD}
+			g = stdin.Arg{
+				About:   "get the emoji of one or more topics, or of the topics holding a keyword or emoji",
+				Args:    "<topic> [...<topic>]",
+				Example: "emojipedia topics get celebration",
+				Short:   G,
+				Verbose: GET}
+			l = stdin.Arg{
+				About:   "show the topics, their size and their most connected keywords",
+				Short:   L,
+				Verbose: LIST}
+			r = stdin.Arg{
+				About:   "remove the topics",
+				Short:   R,
+				Verbose: REMOVE}
+		)
+		fmt.Fprintln(writer, "usage: emojipedia [-tp topics] [<option>] [--flags]")
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "installing topics")
+		fmt.Fprintln(writer, b)
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "removing topics")
+		fmt.Fprintln(writer, r)
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "options that support flags")
+		slice.New(g, l).Each(func(_ int, i interface{}) {
+			fmt.Fprintln(writer, i.(stdin.Arg))
+		})
+		fmt.Fprintln(writer)
+		writer.Flush()
+		unknown(arguments)
+	}
+}
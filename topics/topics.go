@@ -0,0 +1,268 @@
+package topics
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/store"
+)
+
+const (
+	// Common is the share of emoji above which a keyword is too general ("face", "animal") to tie a Topic together.
+	Common float64 = 0.02
+	// Minimum is the fewest emoji a Topic holds. Smaller clusters are dropped.
+	Minimum int = 3
+	// Rounds caps the rounds of label propagation, which almost always settles well before.
+	Rounds int = 50
+)
+
+// Cluster groups the emoji of the documents, the keywords of every emoji by its name, into Topics by keyword
+// co-occurrence. Keywords are the nodes of a graph whose edges join keywords carried by the same emoji, weighted
+// by how often they are carried together against how often they are carried at all. Communities are found by
+// label propagation: every keyword takes the label weighing the most among its neighbours until no label changes.
+// Each Topic is named after its most connected keyword, and every emoji joins the Topic its keywords weigh the most in.
+func Cluster(documents map[string][]string) *Topics {
+	var (
+		counts = map[string]int{}
+		names  = []string{}
+	)
+	for name, keywords := range documents {
+		names = append(names, name)
+		for keyword := range set(keywords) {
+			counts[keyword]++
+		}
+	}
+	sort.Strings(names)
+	ceiling := int(math.Max(float64(Minimum), Common*float64(len(documents))))
+	kept := map[string][]string{}
+	for _, name := range names {
+		for keyword := range set(documents[name]) {
+			if counts[keyword] > 1 && counts[keyword] <= ceiling {
+				kept[name] = append(kept[name], keyword)
+			}
+		}
+		sort.Strings(kept[name])
+	}
+	var (
+		edges = map[string]map[string]float64{}
+		nodes = []string{}
+	)
+	for _, name := range names {
+		keywords := kept[name]
+		for i, a := range keywords {
+			if _, ok := edges[a]; ok == false {
+				edges[a], nodes = map[string]float64{}, append(nodes, a)
+			}
+			for _, b := range keywords[i+1:] {
+				weight := 1 / math.Sqrt(float64(counts[a]*counts[b]))
+				edges[a][b] += weight
+				if _, ok := edges[b]; ok == false {
+					edges[b], nodes = map[string]float64{}, append(nodes, b)
+				}
+				edges[b][a] += weight
+			}
+		}
+	}
+	sort.Strings(nodes)
+	labels := propagate(nodes, edges)
+	var (
+		scores = map[string]map[string]float64{}
+		topics = map[string]*Topic{}
+	)
+	for _, name := range names {
+		scores[name] = map[string]float64{}
+		for _, keyword := range kept[name] {
+			scores[name][labels[keyword]] += math.Log(1 + float64(len(documents))/float64(counts[keyword]))
+		}
+		if label, ok := best(scores[name], ""); ok {
+			if _, ok := topics[label]; ok == false {
+				topics[label] = &Topic{Emoji: []string{}, Keywords: []string{}}
+			}
+			topics[label].Emoji = append(topics[label].Emoji, name)
+		}
+	}
+	strengths := map[string]float64{}
+	for _, keyword := range nodes {
+		label := labels[keyword]
+		if topic, ok := topics[label]; ok {
+			topic.Keywords = append(topic.Keywords, keyword)
+			for neighbour, weight := range edges[keyword] {
+				if labels[neighbour] == label {
+					strengths[keyword] += weight
+				}
+			}
+		}
+	}
+	clusters := New()
+	for _, topic := range topics {
+		if len(topic.Emoji) < Minimum {
+			continue
+		}
+		sort.SliceStable(topic.Keywords, func(i, j int) bool {
+			a, b := topic.Keywords[i], topic.Keywords[j]
+			if strengths[a] != strengths[b] {
+				return strengths[a] > strengths[b]
+			}
+			if counts[a] != counts[b] {
+				return counts[a] > counts[b]
+			}
+			return a < b
+		})
+		topic.Name = topic.Keywords[0]
+		clusters.Topics = append(clusters.Topics, topic)
+	}
+	sort.Slice(clusters.Topics, func(i, j int) bool {
+		a, b := clusters.Topics[i], clusters.Topics[j]
+		if len(a.Emoji) != len(b.Emoji) {
+			return len(a.Emoji) > len(b.Emoji)
+		}
+		return a.Name < b.Name
+	})
+	return clusters
+}
+
+// New instantiates a new empty Topics pointer.
+func New() *Topics {
+	return &Topics{Topics: []*Topic{}}
+}
+
+// Open attempts to open the Topics stored by the last topics build of the active profile.
+func Open() (*Topics, error) {
+	content, err := ioutil.ReadFile(directory.Topics)
+	if err != nil {
+		return nil, err
+	}
+	topics := New()
+	err = store.Decode(directory.Topics, content, topics)
+	if err != nil {
+		return nil, err
+	}
+	return topics, nil
+}
+
+// Remove deletes the Topics stored in the active profile.
+func Remove() error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	return store.Trash(directory.Topics)
+}
+
+// Write stores the Topics in the active profile.
+func Write(topics *Topics) error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(filepath.Dir(directory.Topics), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	content, err := store.Marshal(topics)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(directory.Topics, content, os.ModePerm)
+}
+
+// best returns the label scoring the most, keeping the current label on a tie and otherwise the first label
+// in order, so that clustering the same keywords always gives the same Topics.
+func best(scores map[string]float64, current string) (string, bool) {
+	var (
+		label string
+		top   = 0.0
+	)
+	for candidate, score := range scores {
+		switch {
+		case score > top:
+			label, top = candidate, score
+		case score == top && score > 0 && label != current && (candidate == current || candidate < label):
+			label = candidate
+		}
+	}
+	return label, top > 0
+}
+
+// propagate labels every node with its community. Nodes start with their own label and take the label weighing
+// the most among their neighbours, in order, until a round changes no label.
+func propagate(nodes []string, edges map[string]map[string]float64) map[string]string {
+	var (
+		labels     = map[string]string{}
+		neighbours = map[string][]string{}
+	)
+	// Neighbours are summed in order, so equal scores are not told apart by rounding.
+	for _, node := range nodes {
+		labels[node] = node
+		for neighbour := range edges[node] {
+			neighbours[node] = append(neighbours[node], neighbour)
+		}
+		sort.Strings(neighbours[node])
+	}
+	for round := 0; round < Rounds; round++ {
+		changed := false
+		for _, node := range nodes {
+			scores := map[string]float64{}
+			for _, neighbour := range neighbours[node] {
+				scores[labels[neighbour]] += edges[node][neighbour]
+			}
+			if label, ok := best(scores, labels[node]); ok && label != labels[node] {
+				labels[node], changed = label, true
+			}
+		}
+		if changed == false {
+			break
+		}
+	}
+	return labels
+}
+
+// set returns the distinct values.
+func set(values []string) map[string]bool {
+	s := map[string]bool{}
+	for _, value := range values {
+		if len(value) != 0 {
+			s[value] = true
+		}
+	}
+	return s
+}
+
+// Topic is a named cluster of emoji sharing keywords, such as "celebration" or "weather", listing its keywords
+// from the most to the least connected.
+type Topic struct {
+	Emoji    []string `json:"emoji"`
+	Keywords []string `json:"keywords"`
+	Name     string   `json:"name"`
+}
+
+// Topics are the keyword clusters of a dataset, from the largest to the smallest.
+type Topics struct {
+	Topics []*Topic `json:"topics"`
+}
+
+// Get method returns the Topic of the name, or the Topic holding the keyword or emoji of that name.
+func (pointer *Topics) Get(name string) (*Topic, bool) {
+	for _, topic := range pointer.Topics {
+		if topic.Name == name {
+			return topic, true
+		}
+	}
+	for _, topic := range pointer.Topics {
+		for _, values := range [][]string{topic.Keywords, topic.Emoji} {
+			for _, value := range values {
+				if value == name {
+					return topic, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// Len method returns the number of Topics.
+func (pointer *Topics) Len() int {
+	return len(pointer.Topics)
+}
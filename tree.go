@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/categories"
+	"github.com/gellel/emojipedia/category"
+	"github.com/gellel/emojipedia/text"
+)
+
+func treeBranch(last bool) (string, string) {
+	if last {
+		return "└── ", "    "
+	}
+	return "├── ", "│   "
+}
+
+func treeMain(arguments *arguments.Arguments) {
+	var (
+		categories = categories.Get()
+		depth      = 3
+		filter     = map[string]bool{}
+		roots      = []*category.Category{}
+	)
+	if n, err := strconv.Atoi(arguments.Get(0)); err == nil {
+		depth = n
+		arguments = arguments.Next()
+	}
+	arguments.Each(func(_ int, argument string) {
+		filter[argument] = true
+	})
+	categories.Each(func(c *category.Category) {
+		if len(filter) == 0 || filter[c.Name] {
+			roots = append(roots, c)
+		}
+	})
+	sort.Slice(roots, func(i, j int) bool {
+		return roots[i].Number < roots[j].Number
+	})
+	for _, c := range roots {
+		fmt.Println(fmt.Sprintf("%s (%v)", c.Name, c.Emoji.Len()))
+		if depth < 2 {
+			continue
+		}
+		subcategories := c.SubcategoryRefs()
+		sort.Slice(subcategories, func(i, j int) bool {
+			return subcategories[i].Number < subcategories[j].Number
+		})
+		for i, s := range subcategories {
+			branch, indent := treeBranch(i == len(subcategories)-1)
+			fmt.Println(fmt.Sprintf("%s%s (%v)", branch, s.Name, s.Emoji.Len()))
+			if depth < 3 {
+				continue
+			}
+			emoji := s.EmojiRefs()
+			sort.Slice(emoji, func(i, j int) bool {
+				return emoji[i].Number < emoji[j].Number
+			})
+			for j, e := range emoji {
+				leaf, _ := treeBranch(j == len(emoji)-1)
+				fmt.Println(fmt.Sprintf("%s%s%s %s", indent, leaf, text.Emojize(e.Unicode), e.Name))
+			}
+		}
+	}
+}
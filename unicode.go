@@ -3,40 +3,114 @@ package main
 import (
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/gellel/emojipedia/directory"
 
 	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/emojidata"
+	"github.com/gellel/emojipedia/manifest"
 	"github.com/gellel/emojipedia/pkg"
+	"github.com/gellel/emojipedia/store"
 )
 
 func unicodeorgMain(arguments *arguments.Arguments) {
-	switch strings.ToUpper(arguments.Get(0)) {
+	switch command(arguments, BUILD, REMOVE) {
 	case B, BUILD:
 		fmt.Println("attempting to build unicode-org package.")
+		if err := store.Writable(); err != nil {
+			fail(fmt.Sprintf(errorBuildPackage, UNICODE, err), err)
+		}
+		lock()
 		if _, err := os.Stat(directory.Unicode); os.IsExist(err) {
 			fmt.Println("already built. nothing to do.")
-			os.Exit(0)
+			exit(exitOK)
 		}
 		fmt.Println("must collect package. making http request. can take awhile.")
 		response, err := pkg.HTTP()
 		if err != nil {
-			fmt.Println("cannot collect content. encountered error.")
-			fmt.Println(err)
-			os.Exit(1)
+			fail(fmt.Sprintf("cannot collect content; encountered error \"%s\"", err), err)
 		}
 		fmt.Println("http request succeeded. attempting to store.")
 		err = pkg.Write(response)
 		if err != nil {
-			fmt.Println("unable to store content. error occurred.")
-			fmt.Println(err)
-			os.Exit(1)
+			fail(fmt.Sprintf("unable to store content; encountered error \"%s\"", err), err)
 		}
 		fmt.Println("successfully stored content.")
 		fmt.Println(directory.Unicode)
-		os.Exit(0)
+		fmt.Println("collecting emoji properties. making http request.")
+		response, err = emojidata.HTTP()
+		if err != nil {
+			fail(fmt.Sprintf("cannot collect emoji properties; encountered error \"%s\"", err), err)
+		}
+		err = emojidata.Write(response)
+		if err != nil {
+			fail(fmt.Sprintf("unable to store emoji properties; encountered error \"%s\"", err), err)
+		}
+		for _, file := range emojidata.Files {
+			response, err = emojidata.HTTPSequence(file)
+			if err != nil {
+				fail(fmt.Sprintf("cannot collect emoji sequences; encountered error \"%s\"", err), err)
+			}
+			err = emojidata.WriteSequence(file, response)
+			if err != nil {
+				fail(fmt.Sprintf("unable to store emoji sequences; encountered error \"%s\"", err), err)
+			}
+		}
+		for _, file := range emojidata.CharacterFiles {
+			response, err = emojidata.HTTPCharacters(file)
+			if err != nil {
+				fail(fmt.Sprintf("cannot collect character names; encountered error \"%s\"", err), err)
+			}
+			err = emojidata.WriteCharacters(file, response)
+			if err != nil {
+				fail(fmt.Sprintf("unable to store character names; encountered error \"%s\"", err), err)
+			}
+		}
+		fmt.Println("successfully stored emoji properties.")
+		fmt.Println(directory.Emojidata)
+		if err := manifest.Touch(UNICODE); err != nil {
+			fail(fmt.Sprintf("unable to update the profile manifest; encountered error \"%s\"", err), err)
+		}
+		exit(exitOK)
 	case R, REMOVE:
 		remove(UNICODE, pkg.Remove)
+		remove(EMOJIDATA, emojidata.Remove)
+	}
+}
+
+// collect fetches the unicode.org chart, the emoji properties, the emoji sequences and the character names and stores them in the active profile.
+func collect() error {
+	response, err := pkg.HTTP()
+	if err != nil {
+		return err
+	}
+	if err = pkg.Write(response); err != nil {
+		return err
+	}
+	response, err = emojidata.HTTP()
+	if err != nil {
+		return err
+	}
+	if err = emojidata.Write(response); err != nil {
+		return err
+	}
+	for _, file := range emojidata.Files {
+		response, err = emojidata.HTTPSequence(file)
+		if err != nil {
+			return err
+		}
+		if err = emojidata.WriteSequence(file, response); err != nil {
+			return err
+		}
+	}
+	for _, file := range emojidata.CharacterFiles {
+		response, err = emojidata.HTTPCharacters(file)
+		if err != nil {
+			return err
+		}
+		if err = emojidata.WriteCharacters(file, response); err != nil {
+			return err
+		}
 	}
+	return nil
 }
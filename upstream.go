@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/pkg"
+)
+
+// upstreamMain compares the stored emoji with the live unicode.org chart without modifying storage.
+// It exits with status 1 when the chart has drifted, so it can fail a scheduled CI job.
+func upstreamMain(arguments *arguments.Arguments) {
+	local, err := emojipedia.Open()
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, EMOJIPEDIA, err), err)
+	}
+	metadata, _ := pkg.Meta()
+	response, err := pkg.HTTPIfChanged(metadata)
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, pkg.Address(), err), err)
+	}
+	if response == nil {
+		fmt.Println("unicode.org reports the chart has not been modified. nothing has drifted.")
+		exit(exitOK)
+	}
+	defer response.Body.Close()
+	document, err := goquery.NewDocumentFromReader(response.Body)
+	if err != nil {
+		fail(fmt.Sprintf(errorCannotOpen, pkg.Address(), err), err)
+	}
+	changes := diffOf(local, emojipedia.Parse(document))
+	if len(changes) == 0 {
+		fmt.Println("local dataset matches unicode.org. nothing has drifted.")
+		exit(exitOK)
+	}
+	fmt.Fprintln(writer, "Change\t|ID\t|Name")
+	for _, change := range changes {
+		fmt.Fprintln(writer, change)
+	}
+	writer.Flush()
+	exit(exitFailed)
+}
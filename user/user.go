@@ -0,0 +1,135 @@
+package user
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/text"
+)
+
+// New instantiates a new empty User pointer.
+func New() *User {
+	return &User{Favorites: []string{}, Tags: map[string][]string{}}
+}
+
+// Open attempts to open the User data of the active profile. A profile without User data opens an empty User.
+func Open() (*User, error) {
+	content, err := ioutil.ReadFile(directory.User)
+	if os.IsNotExist(err) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	user := New()
+	err = store.Decode(directory.User, content, user)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Write stores the User data in the active profile.
+func Write(user *User) error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(filepath.Dir(directory.User), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	content, err := store.Marshal(user)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(directory.User, content, os.ModePerm)
+}
+
+// User holds what a person added to the emoji of a profile: the IDs of their favorite emoji and the tags
+// they gave emoji, by emoji ID. It is kept apart from the scraped packages so that rebuilding them keeps it.
+type User struct {
+	Favorites []string            `json:"favorites"`
+	Tags      map[string][]string `json:"tags"`
+}
+
+// Favor method adds the emoji ID to the favorites, returning false if it already was one.
+func (pointer *User) Favor(ID string) bool {
+	if pointer.Favorite(ID) {
+		return false
+	}
+	pointer.Favorites = append(pointer.Favorites, ID)
+	sort.Strings(pointer.Favorites)
+	return true
+}
+
+// Favorite method returns whether the emoji ID is a favorite.
+func (pointer *User) Favorite(ID string) bool {
+	for _, favorite := range pointer.Favorites {
+		if favorite == ID {
+			return true
+		}
+	}
+	return false
+}
+
+// Tag method adds the normalized tags to the emoji ID, returning the number of tags it did not already have.
+func (pointer *User) Tag(ID string, tags ...string) int {
+	n := 0
+	for _, tag := range tags {
+		if tag = text.Normalize(tag); len(tag) != 0 && contains(pointer.Tags[ID], tag) == false {
+			pointer.Tags[ID] = append(pointer.Tags[ID], tag)
+			n++
+		}
+	}
+	sort.Strings(pointer.Tags[ID])
+	return n
+}
+
+// Unfavor method removes the emoji ID from the favorites, returning false if it was not one.
+func (pointer *User) Unfavor(ID string) bool {
+	for i, favorite := range pointer.Favorites {
+		if favorite == ID {
+			pointer.Favorites = append(pointer.Favorites[:i], pointer.Favorites[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Untag method removes the normalized tags from the emoji ID, returning the number of tags it had.
+// Removing every tag removes the emoji ID.
+func (pointer *User) Untag(ID string, tags ...string) int {
+	var (
+		kept    = []string{}
+		removed = map[string]bool{}
+	)
+	for _, tag := range tags {
+		removed[text.Normalize(tag)] = true
+	}
+	for _, tag := range pointer.Tags[ID] {
+		if removed[tag] == false {
+			kept = append(kept, tag)
+		}
+	}
+	n := len(pointer.Tags[ID]) - len(kept)
+	if len(kept) == 0 {
+		delete(pointer.Tags, ID)
+	} else {
+		pointer.Tags[ID] = kept
+	}
+	return n
+}
+
+// contains returns whether the strings hold the argument string.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -4,11 +4,18 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"text/tabwriter"
+
+	"github.com/gellel/emojipedia/merge"
+	"github.com/gellel/emojipedia/table"
 )
 
 var (
-	writer = new(tabwriter.Writer).Init(os.Stdout, 0, 8, 0, '\t', 0)
+	errorFormat string
+	language    string
+	parser      string
+	precedence  = merge.Default()
+	sources     = []string{UNICODE}
+	writer      = table.NewWriter(os.Stdout)
 )
 
 var (
@@ -17,14 +24,68 @@ var (
 )
 
 var (
+	requesting  = fmt.Sprintf("  [--user-agent|--header|--concurrency|--delay]\t%s", "configure requests; emojipedia.org robots.txt is obeyed (--header=\"Name: value\" --concurrency=2 --delay=1s)")
+	proxying    = fmt.Sprintf("  [--proxy|--ca-bundle|--client-cert|--client-key|--insecure]\t%s", "send requests through a proxy (or HTTPS_PROXY) trusting extra certificate authorities (--ca-bundle=file.pem)")
+	encoding    = fmt.Sprintf("  [--as]\t%s", "print the character of \"emojipedia emoji <name>\" as html entities, url-encoded bytes or the escapes of a language (--as=html|url|utf16|java|js|python|css|go), or the emoji of \"emojipedia get\" as json (--as=json|ndjson)")
+	selecting   = fmt.Sprintf("  [--category]\t%s", "build only the emoji and keywords of the named categories, keeping the others stored (--category=smileys-and-emotion,animals-and-nature)")
+	identifying = fmt.Sprintf("  [--by-id]\t%s", "store emoji files named by their stable codepoint id")
+	indenting   = fmt.Sprintf("  [--indent]\t%s", "write stored json indented with tabs (--indent=n for n spaces)")
+	localizing  = fmt.Sprintf("  [--locale]\t%s", "get, search and serve the names, descriptions and keywords translated for a locale, stemming in its language (--locale=fr)")
+	paginating  = fmt.Sprintf("  [--limit|--offset|--range|--no-pager]\t%s", "print some rows of a list (--range=101..200) and page lists printed to a terminal with $PAGER")
+	parsing     = fmt.Sprintf("  [--parser]\t%s", "build emoji with --parser=stream to tokenize the unicode.org page row by row")
+	preferring  = fmt.Sprintf("  [--precedence]\t%s", "read the sources each emoji field is merged from out of a json file (--precedence=file)")
+	profiling   = fmt.Sprintf("  [--profile]\t%s", "read and build the dataset in a named profile (--profile=name)")
+	notifying   = fmt.Sprintf("  [--webhook|--webhook-secret]\t%s", "post a signed json summary of emoji changes after a rebuild (or set EMOJIPEDIA_WEBHOOK_URL)")
+	sourcing    = fmt.Sprintf("  [--source]\t%s", "build emoji from unicode or gemoji, merging gemoji, cldr, emojipedia, openmoji and joypixels metadata (--source=unicode,gemoji,cldr)")
+	strict      = fmt.Sprintf("  [--strict]\t%s", "fail on stored files holding unknown fields and skip and report invalid emoji, categories and subcategories when building")
+	versioning  = fmt.Sprintf("  [--unicode-version]\t%s", "fetch and build from the unicode.org chart of an emoji version (--unicode-version=15.1)")
+	waiting     = fmt.Sprintf("  [--wait]\t%s", "wait for another run holding the dataset lock (--wait=5m)")
+	tracing     = fmt.Sprintf("  [--cpuprofile|--memprofile|--trace]\t%s", "write a pprof profile or execution trace of the command (--cpuprofile=file)")
+	measuring   = fmt.Sprintf("  [--timing]\t%s", "write how long the command took to stderr")
+	protecting  = fmt.Sprintf("  [--read-only]\t%s", "refuse to build or remove anything in the dataset")
+	reporting   = fmt.Sprintf("  [--error-format]\t%s", "write failures to stderr as json objects with a code, message, file and hint (--error-format=json)")
+)
+
+var (
+	aopt = fmt.Sprintf(param, strings.ToLower(A), strings.ToLower(ALT), altDescription)
+	bopt = fmt.Sprintf(param, strings.ToLower(B), strings.ToLower(BUILD), buildDescription)
+	gopt = fmt.Sprintf(param, strings.ToLower(G), strings.ToLower(GEN), genDescription)
+	dopt = fmt.Sprintf(param, strings.ToLower(D), strings.ToLower(DIFF), diffDescription)
 	copt = fmt.Sprintf(param, strings.ToLower(C), strings.ToLower(CATEGORIES), categoriesDescription)
 	kopt = fmt.Sprintf(param, strings.ToLower(K), strings.ToLower(KEYWORDS), keywordsDescription)
 	eopt = fmt.Sprintf(param, strings.ToLower(E), strings.ToLower(EMOJIPEDIA), emojipediaDescription)
 	sopt = fmt.Sprintf(param, strings.ToLower(S), strings.ToLower(SUBCATEGORIES), subcategoriesDescription)
+	topt = fmt.Sprintf(param, strings.ToLower(T), strings.ToLower(TREE), treeDescription)
 )
 
 var (
+	wopt  = fmt.Sprintf(param, strings.ToLower(W), strings.ToLower(WATCH), watchDescription)
+	cuopt = fmt.Sprintf(param, strings.ToLower(CU), strings.ToLower(CHECKUPSTREAM), checkUpstreamDescription)
+	dcopt = fmt.Sprintf(param, strings.ToLower(DC), strings.ToLower(DISCORD), discordDescription)
+	dmopt = fmt.Sprintf(param, strings.ToLower(DM), strings.ToLower(DAEMON), daemonDescription)
+	dropt = fmt.Sprintf(param, strings.ToLower(DR), strings.ToLower(DOCTOR), doctorDescription)
+	imopt = fmt.Sprintf(param, strings.ToLower(IM), strings.ToLower(IMAGES), imagesDescription)
+	nwopt = fmt.Sprintf(param, strings.ToLower(NW), strings.ToLower(NEW), newDescription)
+	fdopt = fmt.Sprintf(param, strings.ToLower(FD), strings.ToLower(FETCHDATASET), fetchDatasetDescription)
+	pkopt = fmt.Sprintf(param, strings.ToLower(PK), strings.ToLower(PACK), packDescription)
+	rnopt = fmt.Sprintf(param, strings.ToLower(RN), strings.ToLower(RENDER), renderDescription)
+	rlopt = fmt.Sprintf(param, strings.ToLower(RL), strings.ToLower(RESTORELAST), restoreDescription)
+	rpopt = fmt.Sprintf(param, strings.ToLower(RP), strings.ToLower(REPAIR), repairDescription)
+	svopt = fmt.Sprintf(param, strings.ToLower(SV), strings.ToLower(SERVE), serveDescription)
+	stopt = fmt.Sprintf(param, strings.ToLower(ST), strings.ToLower(STATUS), statusDescription)
+	shopt = fmt.Sprintf(param, strings.ToLower(SH), strings.ToLower(SHELL), shellDescription)
+	bbopt = fmt.Sprintf(param, strings.ToLower(BB), strings.ToLower(BENCH), benchDescription)
+	anopt = fmt.Sprintf(param, strings.ToLower(AN), strings.ToLower(ANNOTATE), annotateDescription)
+	apopt = fmt.Sprintf(param, strings.ToLower(AP), strings.ToLower(APPLY), applyDescription)
+	gropt = fmt.Sprintf(param, strings.ToLower(GR), strings.ToLower(GREP), grepDescription)
 	ccopt = fmt.Sprintf(param, strings.ToLower(CC), strings.ToLower(CATEGORY), categoryDescription)
 	eeopt = fmt.Sprintf(param, strings.ToLower(EE), strings.ToLower(EMOJI), emojiDescription)
+	hpopt = fmt.Sprintf(param, strings.ToLower(H), strings.ToLower(HELP), helpDescription)
+	hiopt = fmt.Sprintf(param, strings.ToLower(HI), strings.ToLower(HISTORY), historyDescription)
+	fvopt = fmt.Sprintf(param, strings.ToLower(FV), strings.ToLower(FAV), favDescription)
+	tgopt = fmt.Sprintf(param, strings.ToLower(TG), strings.ToLower(TAG), tagDescription)
+	tpopt = fmt.Sprintf(param, strings.ToLower(TP), strings.ToLower(TOPICS), topicsDescription)
+	fopt  = fmt.Sprintf(param, strings.ToLower(F), strings.ToLower(FLAG), flagDescription)
+	popt  = fmt.Sprintf(param, strings.ToLower(PP), strings.ToLower(PROFILES), profilesDescription)
 	ssopt = fmt.Sprintf(param, strings.ToLower(SS), strings.ToLower(SUBCATEGORY), subcategoryDescription)
 )
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gellel/emojipedia/arguments"
+	"github.com/gellel/emojipedia/categories"
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/emoji"
+	"github.com/gellel/emojipedia/emojidata"
+	"github.com/gellel/emojipedia/emojipedia"
+	"github.com/gellel/emojipedia/integrity"
+	"github.com/gellel/emojipedia/keywords"
+	"github.com/gellel/emojipedia/manifest"
+	"github.com/gellel/emojipedia/pkg"
+	"github.com/gellel/emojipedia/store"
+	"github.com/gellel/emojipedia/subcategories"
+)
+
+// refresh fetches unicode.org and rebuilds every package into a staging folder beside the active profile.
+// The staged dataset is only swapped in once it has passed the integrity check,
+// so a failed fetch or broken chart leaves the current dataset untouched.
+func refresh() error {
+	release, err := store.Lock()
+	if err != nil {
+		return err
+	}
+	defer release()
+	var (
+		previous, _ = emojipedia.Open()
+		root        = directory.Root
+		staging     = root + ".staging"
+	)
+	defer func() {
+		directory.At(root)
+		emojidata.Reset()
+		store.Reset()
+	}()
+	err = os.RemoveAll(staging)
+	if err != nil {
+		return err
+	}
+	directory.At(staging)
+	emojidata.Reset()
+	store.Reset()
+	if err = collect(); err != nil {
+		return err
+	}
+	document, err := pkg.Open()
+	if err != nil {
+		return err
+	}
+	if err = categories.Make(document); err != nil {
+		return err
+	}
+	if err = subcategories.Make(document); err != nil {
+		return err
+	}
+	keywords.Make(document)
+	if err = emojipedia.Make(document); err != nil {
+		return err
+	}
+	if err = carry(previous); err != nil {
+		return err
+	}
+	if problems := integrity.Check(); problems.Len() != 0 {
+		return fmt.Errorf(errorIntegrity, "staging", problems.Len())
+	}
+	for _, name := range []string{UNICODE, CATEGORIES, SUBCATEGORIES, KEYWORDS, EMOJIPEDIA} {
+		if err = manifest.Touch(name); err != nil {
+			return err
+		}
+	}
+	reindex()
+	if err = swap(staging, root); err != nil {
+		return err
+	}
+	directory.At(root)
+	store.Reset()
+	notify(previous)
+	return nil
+}
+
+// carry copies the fields fetched lazily into the previous dataset, the descriptions and sentiment of its emoji,
+// onto the staged emoji of the same ID that lack them, along with their provenance.
+func carry(previous *emojipedia.Emojipedia) error {
+	if previous == nil {
+		return nil
+	}
+	staged, err := emojipedia.Open()
+	if err != nil {
+		return err
+	}
+	var changed []*emoji.Emoji
+	staged.Each(func(_ string, e *emoji.Emoji) {
+		old, ok := previous.Get(e.ID)
+		if ok == false {
+			return
+		}
+		fields := []string{}
+		if (len(e.Description) == 0 || e.Description == "NIL") && len(old.Description) != 0 && old.Description != "NIL" {
+			e.SetDescription(old.Description)
+			fields = append(fields, "description")
+		}
+		if e.Sentiment == nil && old.Sentiment != nil {
+			e.SetSentiment(old.Sentiment)
+			fields = append(fields, "sentiment")
+		}
+		if len(fields) == 0 {
+			return
+		}
+		for _, field := range fields {
+			if provenance, ok := old.Provenance[field]; ok {
+				if e.Provenance == nil {
+					e.Provenance = map[string][]*emoji.Provenance{}
+				}
+				e.Provenance[field] = provenance
+			}
+		}
+		changed = append(changed, e)
+	})
+	for _, e := range changed {
+		if err := emoji.Write(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// swap moves every entry of the staging folder over its counterpart in the root folder, one entry after another.
+// Each entry is replaced by a pair of renames, so a folder is read either entirely old or entirely new, but it is
+// missing for the moment between the renames and folders already swapped are read beside folders not yet swapped.
+// Commands that write hold the dataset lock, so only readers can observe the swap in progress.
+func swap(staging, root string) error {
+	files, err := ioutil.ReadDir(staging)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(root, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		var (
+			from = filepath.Join(staging, file.Name())
+			to   = filepath.Join(root, file.Name())
+			old  = to + ".old"
+		)
+		os.RemoveAll(old)
+		if err := os.Rename(to, old); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err := os.Rename(from, to); err != nil {
+			return err
+		}
+		os.RemoveAll(old)
+	}
+	return os.RemoveAll(staging)
+}
+
+func watchMain(arguments *arguments.Arguments) {
+	interval := 24 * time.Hour
+	if len(arguments.Get(0)) != 0 {
+		d, err := time.ParseDuration(arguments.Get(0))
+		if err != nil {
+			failWith(usage, fmt.Sprintf(errorCannotOpen, "interval", err), err)
+		}
+		interval = d
+	}
+	if err := store.Writable(); err != nil {
+		fail(fmt.Sprintf(errorBuildPackage, directory.Root, err), err)
+	}
+	for {
+		fmt.Println(fmt.Sprintf(statusRefresh, time.Now().Format(time.RFC3339), directory.Root))
+		if err := refresh(); err != nil {
+			fmt.Println(fmt.Sprintf(errorRefresh, err))
+		} else {
+			fmt.Println(fmt.Sprintf(successRefresh, time.Now().Add(interval).Format(time.RFC3339)))
+		}
+		time.Sleep(interval)
+	}
+}
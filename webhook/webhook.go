@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gellel/emojipedia/client"
+	"github.com/gellel/emojipedia/store"
+)
+
+const (
+	// Header carries the hex encoded HMAC-SHA256 of the request body, keyed with the Secret.
+	Header string = "X-Emojipedia-Signature"
+)
+
+var (
+	// Secret signs every notification when set. Defaults to the EMOJIPEDIA_WEBHOOK_SECRET environment variable.
+	Secret = os.Getenv("EMOJIPEDIA_WEBHOOK_SECRET")
+	// URL receives a notification after each rebuild that changes the dataset. Defaults to the EMOJIPEDIA_WEBHOOK_URL environment variable.
+	URL = os.Getenv("EMOJIPEDIA_WEBHOOK_URL")
+)
+
+// Enabled checks whether a webhook URL has been configured.
+func Enabled() bool {
+	return len(URL) != 0
+}
+
+// Notify POSTs the Summary as JSON to the configured URL. Summaries without changes are not sent.
+func Notify(summary *Summary) error {
+	if !Enabled() || summary.Len() == 0 {
+		return nil
+	}
+	summary.Text = summary.String()
+	content, err := store.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, URL, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(Secret) != 0 {
+		req.Header.Set(Header, "sha256="+Sign(content, Secret))
+	}
+	resp, err := (&http.Client{Timeout: 30 * time.Second, Transport: client.HTTP.Transport}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the content keyed with the secret.
+func Sign(content []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(content)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Change identifies an emoji that was added, modified or removed by a rebuild.
+type Change struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Summary describes how a rebuild changed the dataset.
+// Text repeats the summary as a sentence so chat services such as Slack and Teams can display it as is.
+type Summary struct {
+	Added    []Change  `json:"added"`
+	Modified []Change  `json:"modified"`
+	Profile  string    `json:"profile"`
+	Removed  []Change  `json:"removed"`
+	Text     string    `json:"text"`
+	Time     time.Time `json:"time"`
+}
+
+// Len returns the number of changes held in the Summary.
+func (pointer *Summary) Len() int {
+	return len(pointer.Added) + len(pointer.Modified) + len(pointer.Removed)
+}
+
+// String describes the Summary in a single line.
+func (pointer *Summary) String() string {
+	names := func(changes []Change) string {
+		s := []string{}
+		for _, change := range changes {
+			s = append(s, change.Name)
+		}
+		return strings.Join(s, ", ")
+	}
+	text := fmt.Sprintf("emojipedia rebuilt: %v added, %v modified, %v removed", len(pointer.Added), len(pointer.Modified), len(pointer.Removed))
+	if len(pointer.Profile) != 0 {
+		text = fmt.Sprintf("%s (profile %s)", text, pointer.Profile)
+	}
+	if len(pointer.Added) != 0 {
+		text = fmt.Sprintf("%s. new: %s", text, names(pointer.Added))
+	}
+	return text
+}
@@ -0,0 +1,97 @@
+package weights
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/gellel/emojipedia/directory"
+	"github.com/gellel/emojipedia/store"
+)
+
+// New instantiates a new empty Weights pointer.
+func New() *Weights {
+	return &Weights{Terms: map[string]int{}}
+}
+
+// Open attempts to open the Weights stored by the last keywords build of the active profile.
+func Open() (*Weights, error) {
+	content, err := ioutil.ReadFile(directory.Weights)
+	if err != nil {
+		return nil, err
+	}
+	weights := New()
+	err = store.Decode(directory.Weights, content, weights)
+	if err != nil {
+		return nil, err
+	}
+	return weights, nil
+}
+
+// Remove deletes the Weights stored in the dependencies folder.
+func Remove() error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	return store.Trash(directory.Weights)
+}
+
+// Write stores the Weights in the active profile.
+func Write(weights *Weights) error {
+	if err := store.Writable(); err != nil {
+		return err
+	}
+	err := os.MkdirAll(filepath.Dir(directory.Weights), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	content, err := store.Marshal(weights)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(directory.Weights, content, os.ModePerm)
+}
+
+// Weights counts how many emoji carry each keyword term, so that terms held by few emoji ("taco")
+// can be weighted above terms held by many ("face").
+type Weights struct {
+	Documents int            `json:"documents"`
+	Terms     map[string]int `json:"terms"`
+}
+
+// Add method counts one emoji carrying the argument terms. Repeated terms are counted once.
+func (pointer *Weights) Add(terms ...string) *Weights {
+	seen := map[string]bool{}
+	for _, term := range terms {
+		if len(term) != 0 && seen[term] == false {
+			seen[term] = true
+			pointer.Terms[term]++
+		}
+	}
+	pointer.Documents++
+	return pointer
+}
+
+// Subtract method uncounts one emoji carrying the argument terms, as counted by the Add method.
+func (pointer *Weights) Subtract(terms ...string) *Weights {
+	seen := map[string]bool{}
+	for _, term := range terms {
+		if len(term) != 0 && seen[term] == false {
+			seen[term] = true
+			if pointer.Terms[term]--; pointer.Terms[term] <= 0 {
+				delete(pointer.Terms, term)
+			}
+		}
+	}
+	if pointer.Documents > 0 {
+		pointer.Documents--
+	}
+	return pointer
+}
+
+// Weight method returns the inverse document frequency of a term. The fewer emoji carry the term the heavier it is,
+// and terms no emoji carries weigh the most.
+func (pointer *Weights) Weight(term string) float64 {
+	return math.Log(1 + float64(pointer.Documents)/float64(1+pointer.Terms[term]))
+}